@@ -9,6 +9,7 @@ import { Navbar } from "@/components/layout/Navbar";
 import { Footer } from "@/components/layout/Footer";
 import HomePage from "./pages/HomePage";
 import CreateAccountPage from "./pages/CreateAccountPage";
+import LoginPage from "./pages/LoginPage";
 import CreateCardPage from "./pages/CreateCardPage";
 import TransactionsPage from "./pages/TransactionsPage";
 import StatementPage from "./pages/StatementPage";
@@ -23,6 +24,7 @@ const AppContent = () => (
       <Routes>
         <Route path="/" element={<HomePage />} />
         <Route path="/create-account" element={<CreateAccountPage />} />
+        <Route path="/login" element={<LoginPage />} />
         <Route path="/create-card" element={<CreateCardPage />} />
         <Route path="/transactions" element={<TransactionsPage />} />
         <Route path="/statement" element={<StatementPage />} />
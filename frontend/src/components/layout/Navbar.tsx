@@ -2,10 +2,18 @@ import { Link, useLocation } from "react-router-dom";
 import { CreditCard, Wallet } from "lucide-react";
 import { Button } from "@/components/ui/button";
 import { useUserStore } from "@/store/userStore";
+import { authApi } from "@/services/api";
 
 export const Navbar = () => {
   const location = useLocation();
-  const { username, clearAccount } = useUserStore();
+  const { username, refreshToken, clearAccount } = useUserStore();
+
+  const handleLogout = () => {
+    if (refreshToken) {
+      authApi.logout(refreshToken).catch(() => undefined);
+    }
+    clearAccount();
+  };
 
   const isActive = (path: string) => location.pathname === path;
 
@@ -32,12 +40,17 @@ export const Navbar = () => {
               Home
             </Link>
             {!username && (
-              <Link
-                to="/create-account"
-                className={navLinkClass("/create-account")}
-              >
-                Criar Conta
-              </Link>
+              <>
+                <Link to="/login" className={navLinkClass("/login")}>
+                  Entrar
+                </Link>
+                <Link
+                  to="/create-account"
+                  className={navLinkClass("/create-account")}
+                >
+                  Criar Conta
+                </Link>
+              </>
             )}
             {username && (
               <>
@@ -64,7 +77,7 @@ export const Navbar = () => {
                   <CreditCard className="h-4 w-4 text-primary" />
                   <span className="text-sm font-medium">{username}</span>
                 </div>
-                <Button variant="ghost" size="sm" onClick={clearAccount}>
+                <Button variant="ghost" size="sm" onClick={handleLogout}>
                   Sair
                 </Button>
               </div>
@@ -10,7 +10,7 @@ import {
 } from "@/components/ui/card";
 import { Input } from "@/components/ui/input";
 import { Label } from "@/components/ui/label";
-import { accountsApi } from "@/services/api";
+import { authApi } from "@/services/api";
 import { useUserStore } from "@/store/userStore";
 import { useSEO } from "@/hooks/useSEO";
 import toast from "react-hot-toast";
@@ -48,9 +48,11 @@ export default function CreateAccountPage() {
   });
 
   const [username, setUsername] = useState("");
+  const [password, setPassword] = useState("");
   const [loading, setLoading] = useState(false);
   const navigate = useNavigate();
   const setAccount = useUserStore((state) => state.setAccount);
+  const setTokens = useUserStore((state) => state.setTokens);
 
   const handleSubmit = async (e: React.FormEvent) => {
     e.preventDefault();
@@ -60,11 +62,20 @@ export default function CreateAccountPage() {
       return;
     }
 
+    if (password.length < 8) {
+      toast.error("A senha deve ter pelo menos 8 caracteres");
+      return;
+    }
+
     setLoading(true);
 
     try {
-      const response = await accountsApi.create({ username: username.trim() });
-      setAccount(response.data.id, response.data.username);
+      const response = await authApi.register({
+        username: username.trim(),
+        password,
+      });
+      setTokens(response.data.access_token, response.data.refresh_token);
+      setAccount(response.data.account.id, response.data.account.username);
       toast.success("Conta criada com sucesso!");
       navigate("/create-card");
     } catch (error) {
@@ -103,6 +114,19 @@ export default function CreateAccountPage() {
               />
             </div>
 
+            <div className="space-y-2">
+              <Label htmlFor="password">Senha</Label>
+              <Input
+                id="password"
+                type="password"
+                placeholder="Pelo menos 8 caracteres"
+                value={password}
+                onChange={(e) => setPassword(e.target.value)}
+                disabled={loading}
+                className="h-12"
+              />
+            </div>
+
             <Button type="submit" className="w-full h-12" disabled={loading}>
               {loading ? "Criando..." : "Criar Conta"}
             </Button>
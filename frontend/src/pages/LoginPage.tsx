@@ -0,0 +1,118 @@
+import { useState } from "react";
+import { Link, useNavigate } from "react-router-dom";
+import { Button } from "@/components/ui/button";
+import {
+  Card,
+  CardContent,
+  CardDescription,
+  CardHeader,
+  CardTitle,
+} from "@/components/ui/card";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { authApi } from "@/services/api";
+import { useUserStore } from "@/store/userStore";
+import { useSEO } from "@/hooks/useSEO";
+import toast from "react-hot-toast";
+import { LogIn } from "lucide-react";
+
+export default function LoginPage() {
+  const seoData = useSEO({
+    title: "Entrar - PayGateway",
+    description:
+      "Acesse sua conta no PayGateway para gerenciar cartões, transações e extratos.",
+    keywords: "entrar, login paygateway, acessar conta",
+    ogType: "website",
+  });
+
+  const [username, setUsername] = useState("");
+  const [password, setPassword] = useState("");
+  const [loading, setLoading] = useState(false);
+  const navigate = useNavigate();
+  const setAccount = useUserStore((state) => state.setAccount);
+  const setTokens = useUserStore((state) => state.setTokens);
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+
+    if (!username.trim() || !password) {
+      toast.error("Por favor, informe usuário e senha");
+      return;
+    }
+
+    setLoading(true);
+
+    try {
+      const response = await authApi.login({
+        username: username.trim(),
+        password,
+      });
+      setTokens(response.data.access_token, response.data.refresh_token);
+      setAccount(response.data.account.id, response.data.account.username);
+      toast.success("Bem-vindo de volta!");
+      navigate("/transactions");
+    } catch (error) {
+      console.error("Erro ao entrar:", error);
+      toast.error("Usuário ou senha inválidos.");
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  return (
+    <div className="container mx-auto flex min-h-[calc(100vh-4rem)] items-center justify-center px-4 py-8">
+      {seoData}
+      <Card className="w-full max-w-md shadow-elegant">
+        <CardHeader className="text-center">
+          <div className="mx-auto mb-4 flex h-16 w-16 items-center justify-center rounded-full bg-gradient-primary">
+            <LogIn className="h-8 w-8 text-primary-foreground" />
+          </div>
+          <CardTitle className="text-2xl">Entrar</CardTitle>
+          <CardDescription>
+            Acesse sua conta no gateway de pagamento
+          </CardDescription>
+        </CardHeader>
+        <CardContent>
+          <form onSubmit={handleSubmit} className="space-y-6">
+            <div className="space-y-2">
+              <Label htmlFor="username">Nome de usuário</Label>
+              <Input
+                id="username"
+                type="text"
+                autoComplete="username"
+                value={username}
+                onChange={(e) => setUsername(e.target.value)}
+                disabled={loading}
+                className="h-12"
+              />
+            </div>
+
+            <div className="space-y-2">
+              <Label htmlFor="password">Senha</Label>
+              <Input
+                id="password"
+                type="password"
+                autoComplete="current-password"
+                value={password}
+                onChange={(e) => setPassword(e.target.value)}
+                disabled={loading}
+                className="h-12"
+              />
+            </div>
+
+            <Button type="submit" className="w-full h-12" disabled={loading}>
+              {loading ? "Entrando..." : "Entrar"}
+            </Button>
+
+            <p className="text-center text-sm text-muted-foreground">
+              Ainda não tem conta?{" "}
+              <Link to="/create-account" className="text-primary">
+                Criar conta
+              </Link>
+            </p>
+          </form>
+        </CardContent>
+      </Card>
+    </div>
+  );
+}
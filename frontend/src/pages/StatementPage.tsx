@@ -87,8 +87,10 @@ export default function StatementPage() {
 
     if (cardId) {
       try {
-        const response = await transactionsApi.getByCardId(cardId);
-        setTransactionsFilteredByCard(response.data || []);
+        const response = await transactionsApi.getByCardId(cardId, {
+          limit: 100,
+        });
+        setTransactionsFilteredByCard(response.data.data || []);
       } catch (error) {
         console.error("Erro ao carregar transações do cartão:", error);
         setTransactionsFilteredByCard([]);
@@ -103,8 +105,8 @@ export default function StatementPage() {
 
     setLoading(true);
     try {
-      const response = await transactionsApi.list(accountId);
-      setTransactions(response.data || []);
+      const response = await transactionsApi.list(accountId, { limit: 100 });
+      setTransactions(response.data.data || []);
     } catch (error) {
       console.error("Erro ao carregar transações:", error);
       setTransactions([]);
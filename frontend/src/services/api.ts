@@ -1,4 +1,5 @@
-import axios from "axios";
+import axios, { AxiosError, InternalAxiosRequestConfig } from "axios";
+import { useUserStore } from "@/store/userStore";
 
 const API_BASE_URL = import.meta.env.VITE_API_BASE_URL;
 
@@ -10,6 +11,81 @@ export const api = axios.create({
   },
 });
 
+api.interceptors.request.use((config) => {
+  const { accessToken } = useUserStore.getState();
+  if (accessToken) {
+    config.headers.Authorization = `Bearer ${accessToken}`;
+  }
+  return config;
+});
+
+// Concurrent 401s share one refresh: refresh tokens are single use.
+let refreshing: Promise<string | null> | null = null;
+
+const refreshSession = async (): Promise<string | null> => {
+  const { refreshToken, setTokens, clearAccount } = useUserStore.getState();
+  if (!refreshToken) return null;
+
+  try {
+    const response = await axios.post<SessionResponse>(
+      `${API_BASE_URL}/auth/refresh`,
+      { refresh_token: refreshToken },
+    );
+    setTokens(response.data.access_token, response.data.refresh_token);
+    return response.data.access_token;
+  } catch {
+    clearAccount();
+    return null;
+  }
+};
+
+api.interceptors.response.use(undefined, async (error: AxiosError) => {
+  const config = error.config as
+    | (InternalAxiosRequestConfig & { _retried?: boolean })
+    | undefined;
+  if (
+    error.response?.status !== 401 ||
+    !config ||
+    config._retried ||
+    config.url?.startsWith("/auth/")
+  ) {
+    return Promise.reject(error);
+  }
+
+  if (!refreshing) {
+    refreshing = refreshSession().finally(() => {
+      refreshing = null;
+    });
+  }
+  const accessToken = await refreshing;
+  if (!accessToken) {
+    return Promise.reject(error);
+  }
+
+  config._retried = true;
+  config.headers.Authorization = `Bearer ${accessToken}`;
+  return api(config);
+});
+
+export interface RegisterRequest {
+  username: string;
+  password: string;
+}
+
+export interface LoginRequest {
+  username: string;
+  password: string;
+}
+
+export interface SessionResponse {
+  access_token: string;
+  token_type: string;
+  expires_in: number;
+  refresh_token: string;
+  refresh_expires_in: number;
+  account: CreateAccountResponse;
+}
+
 export interface CreateAccountRequest {
   username: string;
 }
@@ -52,6 +128,17 @@ export interface Transaction {
   created_at: string;
 }
 
+export interface TransactionPage {
+  data: Transaction[];
+  next_cursor: string | null;
+  has_more: boolean;
+}
+
+export interface TransactionListParams {
+  limit?: number;
+  cursor?: string;
+}
+
 export interface BalanceCalculated {
   account_id: string;
   balance_cents: number;
@@ -64,6 +151,14 @@ export interface BalanceProcessing {
 
 export type BalanceResponse = BalanceCalculated | BalanceProcessing;
 
+export const authApi = {
+  register: (data: RegisterRequest) =>
+    api.post<SessionResponse>("/auth/register", data),
+  login: (data: LoginRequest) => api.post<SessionResponse>("/auth/login", data),
+  logout: (refreshToken: string) =>
+    api.post("/auth/logout", { refresh_token: refreshToken }),
+};
+
 export const accountsApi = {
   create: (data: CreateAccountRequest) =>
     api.post<CreateAccountResponse>("/accounts", data),
@@ -81,10 +176,10 @@ export const cardsApi = {
 export const transactionsApi = {
   create: (data: CreateTransactionRequest) =>
     api.post<Transaction>("/transactions", data),
-  list: (accountId: string) =>
-    api.get<Transaction[]>(`/transactions/${accountId}`),
+  list: (accountId: string, params?: TransactionListParams) =>
+    api.get<TransactionPage>(`/transactions/${accountId}`, { params }),
   getByTransactionId: (transactionId: string) =>
     api.get<Transaction>(`/transactions/id/${transactionId}`),
-  getByCardId: (cardId: string) =>
-    api.get<Transaction[]>(`/transactions/card/${cardId}`),
+  getByCardId: (cardId: string, params?: TransactionListParams) =>
+    api.get<TransactionPage>(`/transactions/card/${cardId}`, { params }),
 };
@@ -12,10 +12,13 @@ interface Card {
 interface UserState {
   accountId: string | null;
   username: string | null;
+  accessToken: string | null;
+  refreshToken: string | null;
   cards: Card[];
   balance: number | null;
   balanceStatus: "CALCULATED" | "PROCESSING" | null;
   setAccount: (accountId: string, username: string) => void;
+  setTokens: (accessToken: string, refreshToken: string) => void;
   setCards: (cards: Card[]) => void;
   addCard: (card: Card) => void;
   setBalance: (balance: number, status: "CALCULATED" | "PROCESSING") => void;
@@ -27,10 +30,14 @@ export const useUserStore = create<UserState>()(
     (set) => ({
       accountId: null,
       username: null,
+      accessToken: null,
+      refreshToken: null,
       cards: [],
       balance: null,
       balanceStatus: null,
       setAccount: (accountId, username) => set({ accountId, username }),
+      setTokens: (accessToken, refreshToken) =>
+        set({ accessToken, refreshToken }),
       setCards: (cards) => set({ cards }),
       addCard: (card) => set((state) => ({ cards: [...state.cards, card] })),
       setBalance: (balance, status) => set({ balance, balanceStatus: status }),
@@ -38,6 +45,8 @@ export const useUserStore = create<UserState>()(
         set({
           accountId: null,
           username: null,
+          accessToken: null,
+          refreshToken: null,
           cards: [],
           balance: null,
           balanceStatus: null,
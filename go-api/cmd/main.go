@@ -1,17 +1,31 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 
 	"payment-gateway/go-api/internal/account"
+	"payment-gateway/go-api/internal/apikey"
+	"payment-gateway/go-api/internal/auth"
 	"payment-gateway/go-api/internal/card"
 	"payment-gateway/go-api/internal/config"
 	"payment-gateway/go-api/internal/connection"
+	"payment-gateway/go-api/internal/idempotency"
+	"payment-gateway/go-api/internal/issuing"
+	"payment-gateway/go-api/internal/ledger"
+	"payment-gateway/go-api/internal/merchant"
+	"payment-gateway/go-api/internal/operator"
+	"payment-gateway/go-api/internal/outbox"
 	"payment-gateway/go-api/internal/router"
+	"payment-gateway/go-api/internal/session"
+	"payment-gateway/go-api/internal/statement"
+	"payment-gateway/go-api/internal/tenant"
 	"payment-gateway/go-api/internal/transaction"
+	"payment-gateway/go-api/internal/vault"
 
 	_ "payment-gateway/go-api/docs"
 
@@ -24,6 +38,10 @@ import (
 // @host localhost:8080
 // @BasePath /
 // @schemes http
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description API key or session access token sent as "Bearer <token>".
 func main() {
 
 	cfg := config.LoadConfig()
@@ -45,18 +63,74 @@ func main() {
 		log.Fatalf("Failed to connect to Redis: %v", err)
 	}
 
-	accountModule := account.NewModule(db)
-	cardModule := *card.NewModule(db, accountModule.Service)
-	transactionModule := transaction.NewModule(db, accountModule.Service, mqClient, cardModule.Service, *redisConn)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-	r := router.NewRouter(accountModule.Handler, cardModule.Handler, transactionModule.Handler)
+	idempotencyModule := idempotency.NewModule(db, cfg.Idempotency.KeyTTL)
+	go idempotency.RunJanitor(ctx, idempotencyModule.Service, cfg.Idempotency.JanitorInterval)
+
+	outboxModule := outbox.NewModule(db, mqClient, outbox.RelayOptions{
+		PollInterval: cfg.Outbox.PollInterval,
+		BatchSize:    cfg.Outbox.BatchSize,
+		BaseBackoff:  cfg.Outbox.BaseBackoff,
+		MaxBackoff:   cfg.Outbox.MaxBackoff,
+	})
+	go outboxModule.Relay.Run(ctx)
+
+	// Background jobs work across merchants; requests are limited to the
+	// merchant of their credentials by the auth middleware.
+	platformCtx := tenant.WithAllMerchants(ctx)
+
+	merchantModule := merchant.NewModule(db)
+	accountModule := account.NewModule(db, merchantModule.Service)
+	operatorModule := operator.NewModule(db, merchantModule.Service)
+	apiKeyModule := apikey.NewModule(db, accountModule.Service, operatorModule.Service, merchantModule.Service)
+	if err := apiKeyModule.Service.EnsureBootstrapKey(platformCtx, cfg.APIKey.BootstrapAdminKey); err != nil {
+		log.Fatalf("Failed to install bootstrap API key: %v", err)
+	}
+
+	jwtSecret := []byte(cfg.Auth.JWTSecret)
+	if len(jwtSecret) == 0 {
+		log.Println("JWT_SECRET is not set; using a random secret, sessions will not survive a restart")
+		jwtSecret = make([]byte, 32)
+		if _, err := rand.Read(jwtSecret); err != nil {
+			log.Fatalf("Failed to generate JWT secret: %v", err)
+		}
+	}
+	sessionModule := session.NewModule(accountModule.Service, *redisConn, jwtSecret, cfg.Auth.AccessTokenTTL, cfg.Auth.RefreshTokenTTL)
+	authMiddleware := auth.NewMiddleware(apiKeyModule.Authenticator, sessionModule.Authenticator, operatorModule.Auditor)
+	binRanges, err := issuing.ParseBINRanges(cfg.Card.BINRanges)
+	if err != nil {
+		log.Fatalf("Invalid CARD_BIN_RANGES: %v", err)
+	}
+	var keyring *vault.Keyring
+	if len(cfg.Vault.KEKs) == 0 {
+		log.Println("VAULT_KEKS is not set; using a random key, vaulted card numbers will not survive a restart")
+		keyring, err = vault.NewRandomKeyring()
+	} else {
+		keyring, err = vault.ParseKeyring(cfg.Vault.KEKs, cfg.Vault.ActiveKEKVersion)
+	}
+	if err != nil {
+		log.Fatalf("Invalid VAULT_KEKS: %v", err)
+	}
+	vaultModule := vault.NewModule(db, keyring)
+	go vault.RunRewrap(ctx, vaultModule.Service, cfg.Vault.RewrapInterval)
+	cardModule := *card.NewModule(db, accountModule.Service, issuing.NewIssuer(binRanges, cfg.Card.ValidityYears), vaultModule.Service)
+	go card.RunCardExpiry(platformCtx, cardModule.Service, cfg.Card.ExpiryInterval)
+	ledgerModule := ledger.NewModule(db, accountModule.Service)
+	statementModule := statement.NewModule(db, accountModule.Service, cfg.Statement.Currency)
+	transactionModule := transaction.NewModule(db, accountModule.Service, mqClient, cardModule.Service, ledgerModule.Service, *redisConn, cfg.Transaction.AuthorizationHoldTTL)
+	go transaction.RunHoldExpiry(platformCtx, transactionModule.Service, cfg.Transaction.HoldExpiryInterval)
+	go transaction.RunBalanceSnapshots(platformCtx, transactionModule.Service, cfg.Transaction.SnapshotInterval)
+
+	r := router.NewRouter(accountModule.Handler, cardModule.Handler, transactionModule.Handler, ledgerModule.Handler, statementModule.Handler, outboxModule.Handler, apiKeyModule.Handler, sessionModule.Handler, operatorModule.Handler, merchantModule.Handler, idempotencyModule.Middleware, authMiddleware)
 	r.RegisterRoutes()
 
 	handlerWithCors := config.EnableCors(r.MuxRouter())
 
 	fmt.Println("Server running 🚀🚀🚀   PORT:8080")
 	fmt.Println("go-api: http://localhost:" + os.Getenv("API_PORT"))
-	fmt.Printf("API Swagger doc up: http://localhost:" + os.Getenv("API_PORT") + "/swagger/index.html\n")
+	fmt.Println("API Swagger doc up: http://localhost:" + os.Getenv("API_PORT") + "/swagger/index.html")
 
 	log.Fatal(http.ListenAndServe(":8080", handlerWithCors))
 }
@@ -17,6 +17,11 @@ const docTemplate = `{
     "paths": {
         "/accounts": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "description": "Returns a list of all accounts, with pagination support.",
                 "produces": [
                     "application/json"
@@ -54,7 +59,12 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Creates a new account with a username.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new account with a username in the caller's merchant. Platform operators name the merchant with merchant_id.",
                 "consumes": [
                     "application/json"
                 ],
@@ -82,51 +92,85 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.Account"
                         }
+                    },
+                    "400": {
+                        "description": "Invalid request body, validation failed or merchant_id missing",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Merchant not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Username already taken in the merchant",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create account",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
                     }
                 }
             }
         },
-        "/accounts/{accountId}/balance": {
+        "/accounts/by-username/{username}": {
             "get": {
-                "description": "Retrieves the current balance for a specific account.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Looks up an account by username, case-insensitively, within the caller's merchant. Usernames are only unique per merchant, so platform operators name the merchant with merchant_id. Soft deleted accounts are not matched.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "accounts"
                 ],
-                "summary": "Get Account Balance",
-                "operationId": "get-account-balance",
+                "summary": "Get an account by username",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Account ID",
-                        "name": "accountId",
+                        "description": "Username",
+                        "name": "username",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant to search (required for platform operators)",
+                        "name": "merchant_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Balance retrieved from cache",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ResponseAccountBalance"
+                            "$ref": "#/definitions/models.Account"
                         }
                     },
-                    "202": {
-                        "description": "Balance calculation triggered",
+                    "400": {
+                        "description": "Invalid or missing merchant_id",
                         "schema": {
-                            "$ref": "#/definitions/dto.ProcessingResponse"
+                            "$ref": "#/definitions/api.APIError"
                         }
                     },
                     "404": {
-                        "description": "Account not found",
+                        "description": "Account or merchant not found",
                         "schema": {
                             "$ref": "#/definitions/api.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to get account",
                         "schema": {
                             "$ref": "#/definitions/api.APIError"
                         }
@@ -134,42 +178,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/cards": {
-            "post": {
-                "description": "Creates a fictional card and associates it with an account.",
-                "consumes": [
-                    "application/json"
+        "/accounts/{accountId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Returns a single account. Soft deleted accounts are still returned, with deleted_at set.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cards"
+                    "accounts"
                 ],
-                "summary": "Create a new card",
-                "operationId": "create-card",
+                "summary": "Get an account by ID",
                 "parameters": [
                     {
+                        "type": "string",
                         "description": "Account ID",
-                        "name": "card",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateCardRequest"
-                        }
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Card created successfully",
-                        "schema": {
-                            "$ref": "#/definitions/dto.CardResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request body or validation failed",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.APIError"
+                            "$ref": "#/definitions/models.Account"
                         }
                     },
                     "404": {
@@ -179,25 +216,30 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Failed to create card",
+                        "description": "Failed to get account",
                         "schema": {
                             "$ref": "#/definitions/api.APIError"
                         }
                     }
                 }
-            }
-        },
-        "/cards/{accountId}": {
-            "get": {
-                "description": "Returns a list of all cards associated with an account, ordered by creation date.",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft deletes an account: it is closed, hidden from the account listing, and its cards, transactions and statements are kept. Requires an operator with the accounts:close permission.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cards"
+                    "accounts"
                 ],
-                "summary": "Get all cards by account ID",
-                "operationId": "get-cards-by-account",
+                "summary": "Delete an account",
                 "parameters": [
                     {
                         "type": "string",
@@ -205,20 +247,25 @@ const docTemplate = `{
                         "name": "accountId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Reason for the deletion",
+                        "name": "reason",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccountStatusRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.CardResponse"
-                            }
+                            "$ref": "#/definitions/models.Account"
                         }
                     },
                     "400": {
-                        "description": "Invalid account ID",
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/api.APIError"
                         }
@@ -228,19 +275,16 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.APIError"
                         }
-                    },
-                    "500": {
-                        "description": "Failed to retrieve cards",
-                        "schema": {
-                            "$ref": "#/definitions/api.APIError"
-                        }
                     }
                 }
-            }
-        },
-        "/transactions": {
-            "post": {
-                "description": "Creates a new transaction (DEPOSIT, PURCHASE, REFUND, CHARGE) in the payment gateway.",
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the username and/or merges metadata into the account. Top-level metadata keys set to null are removed. Closed accounts cannot be updated.",
                 "consumes": [
                     "application/json"
                 ],
@@ -248,32 +292,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "accounts"
                 ],
-                "summary": "Create a new transaction",
-                "operationId": "create-transaction",
+                "summary": "Update an account",
                 "parameters": [
                     {
-                        "description": "Transaction data",
-                        "name": "transaction",
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "account",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateTransactionRequest"
+                            "$ref": "#/definitions/dto.UpdateAccountRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Transaction created successfully",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ResponseCreateTransactionRequest"
-                        },
-                        "headers": {
-                            "Location": {
-                                "type": "string",
-                                "description": "URL of the created transaction"
-                            }
+                            "$ref": "#/definitions/models.Account"
                         }
                     },
                     "400": {
@@ -288,14 +332,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.APIError"
                         }
                     },
-                    "422": {
-                        "description": "Business rule violation (e.g. insufficient funds)",
+                    "409": {
+                        "description": "Account is closed or username already taken",
                         "schema": {
                             "$ref": "#/definitions/api.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to update account",
                         "schema": {
                             "$ref": "#/definitions/api.APIError"
                         }
@@ -303,17 +347,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/transactions/test/{accountId}": {
+        "/accounts/{accountId}/balance": {
             "get": {
-                "description": "Retrieves a list of all transactions for an account, ordered by creation date (desc).",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the current balance for a specific account.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "accounts"
                 ],
-                "summary": "Get All Transactions for an Account (Test)",
-                "operationId": "get-transactions-test",
+                "summary": "Get Account Balance",
+                "operationId": "get-account-balance",
                 "parameters": [
                     {
                         "type": "string",
@@ -321,16 +370,38 @@ const docTemplate = `{
                         "name": "accountId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Return 202 and let the processor compute the balance on a cache miss",
+                        "name": "async",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Point in time (RFC3339), e.g. 2025-10-01T00:00:00Z",
+                        "name": "at",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Balance retrieved",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.ResponseCreateTransactionRequest"
-                            }
+                            "$ref": "#/definitions/dto.ResponseAccountBalance"
+                        }
+                    },
+                    "202": {
+                        "description": "Balance calculation triggered (async=true only)",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProcessingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid at timestamp",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
                         }
                     },
                     "404": {
@@ -348,17 +419,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/transactions/{accountId}": {
+        "/accounts/{accountId}/balance/history": {
             "get": {
-                "description": "Returns a list of transactions for a specific account.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the running balance of an account between from and to, one point per interval period (UTC).\nBalances are settled balances: only APPROVED transactions count, by creation time.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "accounts"
                 ],
-                "summary": "Get transactions by Account ID",
-                "operationId": "get-transactions-by-account",
+                "summary": "Get Account Balance History",
+                "operationId": "get-account-balance-history",
                 "parameters": [
                     {
                         "type": "string",
@@ -366,16 +442,44 @@ const docTemplate = `{
                         "name": "accountId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the range (RFC3339). Defaults to 30 days before to.",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range, exclusive (RFC3339). Defaults to now.",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "hour",
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Period length",
+                        "name": "interval",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.ResponseCreateTransactionRequest"
-                            }
+                            "$ref": "#/definitions/dto.ResponseBalanceHistory"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid range or interval",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
                         }
                     },
                     "404": {
@@ -392,188 +496,4049 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "api.APIError": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
-                },
-                "message": {
-                    "type": "string"
-                }
-            }
-        },
-        "dto.CardResponse": {
-            "description": "Response when a card is created",
-            "type": "object",
-            "properties": {
-                "account_id": {
-                    "type": "string",
-                    "example": "e252f5dd-ded2-4a30-a4a5-6e2940008d54"
-                },
-                "card_token": {
-                    "type": "string",
-                    "example": "5b7c16af7278094cd14bd041079111ed00fa832c8a460d8e3f40156408d99475"
-                },
-                "created_at": {
-                    "type": "string",
-                    "example": "2025-09-22T19:15:24.526505Z"
-                },
-                "id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
-                },
-                "last_four_digits": {
-                    "type": "string",
-                    "example": "8995"
-                }
-            }
         },
-        "dto.CreateAccountRequest": {
-            "type": "object",
-            "required": [
-                "username"
-            ],
-            "properties": {
-                "username": {
-                    "description": "@Description The username of the new account.\n@Example charlie",
-                    "type": "string",
+        "/accounts/{accountId}/close": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves an ACTIVE or FROZEN account to CLOSED. Closing is final. Requires an operator with the accounts:close permission.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Close an account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for the change",
+                        "name": "reason",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccountStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Account"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Account not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Status transition not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/accounts/{accountId}/freeze": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves an ACTIVE account to FROZEN. Frozen accounts cannot create transactions or issue cards. Account holders may freeze their own account; operators need the accounts:freeze permission.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Freeze an account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for the change",
+                        "name": "reason",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccountStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Account"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Account not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Status transition not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/accounts/{accountId}/ledger": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the postings written against an account, newest first, with pagination support.\nEvery transaction writes a balanced journal entry when it is created. When the processor rejects it, a REVERSAL entry cancels those postings, so the postings of an account add up to its balance plus its PENDING transactions.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Get the ledger postings of an account",
+                "operationId": "get-account-ledger",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID (UUID)",
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of items per page (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AccountPosting"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Pagination limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Account not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/accounts/{accountId}/statement": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Streams the opening balance, every approved movement with its running balance and the closing balance of an account.\nColumn headers and descriptions follow Accept-Language (en-US or pt-BR).\nofx (OFX 2.2) and camt053 (ISO 20022 camt.053.001.08) can be imported by accounting software; their transaction IDs are the transaction UUIDs without dashes.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/pdf",
+                    "application/x-ofx",
+                    "application/xml"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Export an account statement",
+                "operationId": "get-account-statement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the period (RFC3339). Defaults to 30 days before to.",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the period, exclusive (RFC3339). Defaults to now.",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "jsonl",
+                            "pdf",
+                            "ofx",
+                            "camt053"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Output format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "en-US",
+                        "description": "Language of labels",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Statement document",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid period or format",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Account not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/accounts/{accountId}/unfreeze": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves a FROZEN account back to ACTIVE. Requires an operator with the accounts:freeze permission.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Unfreeze an account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for the change",
+                        "name": "reason",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccountStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Account"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Account not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Status transition not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists API keys, newest first, including revoked ones. Secrets are never returned. Requires the admin scope.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "List API keys",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only keys of this account",
+                        "name": "account_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.APIKey"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid API key",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "API key lacks the admin scope",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a key tied to an account or to an operator. Keys of an operator with an operator_id act with the permissions of the operator's role, narrowed to the key scopes. Keys belong to the merchant of their owner; platform operators create a merchant's first key with merchant_id. The key is returned once; only its hash is stored. Requires the admin scope.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Create an API key",
+                "parameters": [
+                    {
+                        "description": "Key owner and scopes",
+                        "name": "key",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateAPIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIKeySecretResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, owner or scope",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid API key",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "API key lacks the admin scope",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Account, operator or merchant not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Operator is inactive",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api-keys/{keyId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes the key. Requests made with it are rejected from then on. Requires the admin scope.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Revoke an API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key ID",
+                        "name": "keyId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIKey"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid API key",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "API key lacks the admin scope",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "API key not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api-keys/{keyId}/rotate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issues a new secret for the key, keeping its id, owner and scopes. The previous secret stops working immediately. Requires the admin scope.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Rotate an API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key ID",
+                        "name": "keyId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIKeySecretResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid API key",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "API key lacks the admin scope",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "API key not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "API key has been revoked",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/audit-logs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists requests made by operators of the caller's merchant, or by every operator for platform operators, newest first. To page, pass the created_at of the last entry as created_to. Requires the audit:read permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "operators"
+                ],
+                "summary": "List audit logs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only requests made by this operator",
+                        "name": "operator_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only requests on this account",
+                        "name": "account_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Made at or after (RFC3339)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Made before (RFC3339)",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of entries (max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AuditLog"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller's role does not allow reading audit logs",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Signs in with username and password, within the given merchant or the default one, and returns an access and refresh token pair.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Login",
+                "parameters": [
+                    {
+                        "description": "Username and password",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SessionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid username or password",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "description": "Revokes the refresh token. The access token stays valid until it expires.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Logout",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RefreshRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/password": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the password of the caller's account and revokes every refresh token of the account.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Change password",
+                "parameters": [
+                    {
+                        "description": "Current and new password",
+                        "name": "password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Current password is wrong",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller is not tied to an account",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchanges a refresh token for a new token pair. The refresh token sent is revoked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Refresh session",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RefreshRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SessionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or expired refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Creates an account with a password in the given merchant, the default merchant when omitted, and signs it in.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Register",
+                "parameters": [
+                    {
+                        "description": "Username and password",
+                        "name": "account",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SessionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Merchant not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Username already taken in the merchant",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/cards": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issues a fictional card on an account. The card number is drawn from the configured BIN ranges of the requested brand and carries a valid Luhn check digit. The number is kept encrypted in the card vault behind an opaque card_token; the CVC is returned once and never stored. Virtual cards can be issued with kind: a SINGLE_USE card is cancelled once its first purchase is approved, a MERCHANT_LOCKED card only buys from locked_acceptor_id up to amount_limit_cents over its lifetime. Virtual cards only allow PURCHASE and REFUND transactions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Create a new card",
+                "operationId": "create-card",
+                "parameters": [
+                    {
+                        "description": "Account ID",
+                        "name": "card",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateCardRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Card created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CardSecretResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, validation failed, brand not issued or constraints do not match the card kind",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Account not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Account is frozen or closed",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create card",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/cards/{accountId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a list of all cards associated with an account, ordered by creation date.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Get all cards by account ID",
+                "operationId": "get-cards-by-account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CardResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid account ID",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Account not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve cards",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/cards/{cardId}/block": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves an ACTIVE card to BLOCKED. Blocked cards cannot transact until they are unblocked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Block a card",
+                "operationId": "block-card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Card ID",
+                        "name": "cardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for the change",
+                        "name": "reason",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CardStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid card ID or request body",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Card belongs to another account",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Card not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Status transition not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/cards/{cardId}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves an ACTIVE or BLOCKED card to CANCELLED. Cancelling is final.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Cancel a card",
+                "operationId": "cancel-card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Card ID",
+                        "name": "cardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for the change",
+                        "name": "reason",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CardStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid card ID or request body",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Card belongs to another account",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Card not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Status transition not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/cards/{cardId}/controls": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the spending controls of a card. A card without controls gets empty ones, which do not restrict it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Get card controls",
+                "operationId": "get-card-controls",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Card ID",
+                        "name": "cardId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CardControlsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid card ID",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Card belongs to another account",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Card not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve card controls",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the spending controls of a card: a per-transaction maximum and daily and monthly caps on PURCHASE and TRANSFER spend, the transaction types the card may be used for, blocked merchant categories and a velocity limit on the number of transactions in a time window. Omitted controls are removed. Transactions that break a control are stored REJECTED with a decline_reason.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Set card controls",
+                "operationId": "update-card-controls",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Card ID",
+                        "name": "cardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Card controls",
+                        "name": "controls",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateCardControlsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CardControlsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid card ID, request body or validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Card belongs to another account",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Card not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Card is cancelled or expired",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update card controls",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/cards/{cardId}/detokenize": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Decrypts the full number of a card from the card vault. Requires the cards:detokenize scope, which cards:read does not imply. Cards issued before the vault cannot be revealed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Reveal a card number",
+                "operationId": "detokenize-card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Card ID",
+                        "name": "cardId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DetokenizedCardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid card ID",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Card belongs to another account",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Card not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Card number is not vaulted",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to detokenize card",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/cards/{cardId}/replace": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issues a new card number of the same brand on the card's account, linked to the card through replaces_card_id, and cancels the card. Use it for lost, stolen or expired cards. ACTIVE, BLOCKED and EXPIRED cards can be replaced, each only once; expired cards stay EXPIRED. Virtual cards cannot be replaced. The CVC of the new card is returned once and never stored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Replace a card",
+                "operationId": "replace-card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Card ID",
+                        "name": "cardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for the replacement",
+                        "name": "reason",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CardStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Replacement card",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CardSecretResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid card ID or request body",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Card belongs to another account",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Card not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Card already replaced, cancelled or virtual, or account frozen or closed",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to replace card",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/cards/{cardId}/unblock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves a BLOCKED card back to ACTIVE. Cards past their expiry month cannot be unblocked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Unblock a card",
+                "operationId": "unblock-card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Card ID",
+                        "name": "cardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for the change",
+                        "name": "reason",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CardStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid card ID or request body",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Card belongs to another account",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Card not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Status transition not allowed or card expired",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/merchants": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the merchants the caller can reach, oldest first: every merchant for platform operators, otherwise only the caller's own.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "merchants"
+                ],
+                "summary": "List merchants",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Merchant"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Onboards a new merchant. Create its first operator or API key with the returned merchant id. Only platform operators, whose credentials are not bound to a merchant, can create merchants.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "merchants"
+                ],
+                "summary": "Create a merchant",
+                "parameters": [
+                    {
+                        "description": "Merchant data",
+                        "name": "merchant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateMerchantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Merchant"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller is not a platform operator",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/merchants/{merchantId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a merchant the caller can reach.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "merchants"
+                ],
+                "summary": "Get a merchant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Merchant ID",
+                        "name": "merchantId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Merchant"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid merchant ID",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Merchant not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/operators": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the operators of the caller's merchant, every operator for platform operators, active or not, by username. Requires the operators:manage permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "operators"
+                ],
+                "summary": "List operators",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Operator"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller's role does not allow managing operators",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registers a support agent, finance or admin operator in the caller's merchant. Platform operators choose the merchant with merchant_id, or omit it to create another platform operator. Give them access by creating an OPERATOR API key with their operator_id. Requires the operators:manage permission.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "operators"
+                ],
+                "summary": "Create an operator",
+                "parameters": [
+                    {
+                        "description": "Operator data",
+                        "name": "operator",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateOperatorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Operator"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or unknown role",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller's role does not allow managing operators",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Merchant not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Username already taken",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/operators/{operatorId}": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the role of an operator or deactivates them. Changes apply to the operator's next request. Requires the operators:manage permission.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "operators"
+                ],
+                "summary": "Update an operator",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Operator ID",
+                        "name": "operatorId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "operator",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateOperatorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Operator"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, unknown role or nothing to update",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller's role does not allow managing operators",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Operator not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/outbox/metrics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the size of the outbox backlog and the relay publish counters.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "outbox"
+                ],
+                "summary": "Get outbox metrics",
+                "operationId": "get-outbox-metrics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseOutboxMetrics"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the policy table: each operator role with the permissions it grants. Requires the operators:manage permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "operators"
+                ],
+                "summary": "List roles",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Role"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller's role does not allow managing operators",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new transaction (DEPOSIT, PURCHASE, REFUND, CHARGE, TRANSFER) in the payment gateway.\nA CHARGE is a fee or merchant initiated debit: it takes no card_token, requires a reason_code and may overdraw the account up to the configured overdraft limit.\nA TRANSFER returns its TRANSFER_OUT leg; the TRANSFER_IN leg on the destination account is referenced by counterpart_transaction_id.\nVirtual cards only make purchases and refunds: a SINGLE_USE card declines purchases once it has one pending or approved but still takes refunds after its purchase cancelled it, and a MERCHANT_LOCKED card declines purchases at any acceptor_id other than its own or beyond its remaining amount.\nA card transaction that breaks one of the card's controls is still created, as REJECTED with a decline_reason, and moves no money.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Create a new transaction",
+                "operationId": "create-transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Client generated key; retries with the same key replay the original response while the key is kept (IDEMPOTENCY_KEY_TTL). Afterwards a retry gets the transaction created with the key, or 409 when it asks for a different account, type, amount or refunded transaction",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Transaction data",
+                        "name": "transaction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Transaction created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseCreateTransactionRequest"
+                        },
+                        "headers": {
+                            "Idempotent-Replayed": {
+                                "type": "string",
+                                "description": "Set to true when the response is a replay"
+                            },
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the created transaction"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller may not create this type of transaction on the account; operators need transactions:refund for a REFUND and transactions:write otherwise",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Account, card, destination account or original transaction not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Account or destination account frozen or closed, card blocked, cancelled or expired, or idempotency key in use by a concurrent request or by another transaction",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Business rule violation (e.g. refund of a non approved transaction, idempotency key reused with another payload)",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/card/{cardId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a page of transactions made with a specific card, newest first by default.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Get transactions by Card ID",
+                "operationId": "get-transactions-by-card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Card ID",
+                        "name": "cardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated transaction types, e.g. PURCHASE,REFUND",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated statuses, e.g. APPROVED,PENDING",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum amount in cents (inclusive)",
+                        "name": "min_amount_cents",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum amount in cents (inclusive)",
+                        "name": "max_amount_cents",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (RFC3339)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before (RFC3339)",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at_desc",
+                            "created_at_asc"
+                        ],
+                        "type": "string",
+                        "default": "created_at_desc",
+                        "description": "Sort order",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseTransactionPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/id/{transactionId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a transaction together with how much of it has been refunded and how much can still be refunded.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Get transaction by ID",
+                "operationId": "get-transaction-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "transactionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseTransactionDetails"
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Looks up transactions by any combination of criteria, newest first by default, with the same paging envelope as the listings.\nWithout account_id the search spans every account and requires an operator API key with transactions:read. Account-bound callers only see their own account.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Search transactions",
+                "operationId": "search-transactions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only transactions of this account",
+                        "name": "account_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only transactions of this card",
+                        "name": "card_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the idempotency key (case insensitive)",
+                        "name": "idempotency_key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last four digits of the card used",
+                        "name": "card_last_four",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Exact amount in cents",
+                        "name": "amount_cents",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated transaction types, e.g. PURCHASE,REFUND",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated statuses, e.g. APPROVED,PENDING",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum amount in cents (inclusive)",
+                        "name": "min_amount_cents",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum amount in cents (inclusive)",
+                        "name": "max_amount_cents",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (RFC3339)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before (RFC3339)",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at_desc",
+                            "created_at_asc"
+                        ],
+                        "type": "string",
+                        "default": "created_at_desc",
+                        "description": "Sort order",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseTransactionPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Cross-account search without operator access",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Account not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{accountId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a page of transactions for a specific account, newest first by default.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Get transactions by Account ID",
+                "operationId": "get-transactions-by-account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only transactions of this card",
+                        "name": "card_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated transaction types, e.g. PURCHASE,REFUND",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated statuses, e.g. APPROVED,PENDING",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum amount in cents (inclusive)",
+                        "name": "min_amount_cents",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum amount in cents (inclusive)",
+                        "name": "max_amount_cents",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (RFC3339)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before (RFC3339)",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at_desc",
+                            "created_at_asc"
+                        ],
+                        "type": "string",
+                        "default": "created_at_desc",
+                        "description": "Sort order",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseTransactionPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Account not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{transactionId}/capture": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Settles a PURCHASE created with capture=false. Capturing less than the authorized amount releases the remainder of the hold.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Capture an authorized purchase",
+                "operationId": "capture-transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "transactionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client generated key; retries with the same key replay the original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Amount to capture",
+                        "name": "capture",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CaptureTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Authorization captured",
+                        "schema": {
+                            "$ref": "#/definitions/models.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Transaction is not an open authorization, hold expired or amount above the authorized amount",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{transactionId}/void": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Releases the hold of a PURCHASE created with capture=false without capturing any amount.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Void an authorized purchase",
+                "operationId": "void-transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "transactionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client generated key; retries with the same key replay the original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Authorization voided",
+                        "schema": {
+                            "$ref": "#/definitions/models.Transaction"
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Transaction is not an open authorization",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.APIError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "api.APIError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.APIKeySecretResponse": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "description": "@Description Account the key is tied to. Null for OPERATOR keys.\n@Format uuid",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "created_at": {
+                    "description": "@Description Timestamp when the key was created (UTC, RFC3339 format).\n@Format date-time",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "@Description Unique identifier of the key (UUID).\n@Format uuid",
+                    "type": "string"
+                },
+                "key": {
+                    "description": "@Description The API key. Send it as \"Authorization: Bearer \u003ckey\u003e\". Shown only once.\n@Example pgk_3f9a1c2e_6r0n8rQ4b1Xy5mZ3cV7tK2pL9wD4hS0aE1fG6jU8iO",
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "description": "@Description Timestamp of the last authenticated request made with the key.\n@Format date-time",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "merchant_id": {
+                    "description": "@Description Merchant the key is limited to. Null for platform keys, which reach every merchant.\n@Format uuid",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "name": {
+                    "description": "@Description Human readable label for the key.\n@Example checkout backend",
+                    "type": "string"
+                },
+                "operator_id": {
+                    "description": "@Description Operator the key is tied to. Null for ACCOUNT keys and for operator keys not tied to a person, such as the bootstrap key.\n@Format uuid",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "owner_type": {
+                    "description": "@Description Who the key acts for. ACCOUNT keys can only reach their own account.\n@Enum ACCOUNT,OPERATOR",
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "@Description Non-secret start of the key, shown to tell keys apart.\n@Example pgk_3f9a1c2e",
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "description": "@Description Timestamp when the key was revoked. Revoked keys are rejected.\n@Format date-time",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "rotated_at": {
+                    "description": "@Description Timestamp of the last rotation, if any.\n@Format date-time",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "scopes": {
+                    "description": "@Description Scopes granted to the key.\n@Example [\"transactions:write\",\"cards:read\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.AccountStatusRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "description": "@Description Why the status is being changed. Stored on the account.\n@Example Suspicious activity reported by the owner",
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "dto.BalanceHistoryPoint": {
+            "description": "Balance movement of one history period",
+            "type": "object",
+            "properties": {
+                "closing_balance_cents": {
+                    "description": "@Description Balance at the end of the period, in cents.",
+                    "type": "integer",
+                    "example": 7500
+                },
+                "net_change_cents": {
+                    "description": "@Description Net effect of the approved transactions of the period, in cents.",
+                    "type": "integer",
+                    "example": -2500
+                },
+                "period_start": {
+                    "type": "string",
+                    "example": "2025-10-01T00:00:00Z"
+                }
+            }
+        },
+        "dto.CaptureTransactionRequest": {
+            "description": "Request body for capturing an authorized purchase",
+            "type": "object",
+            "properties": {
+                "amount_cents": {
+                    "description": "@Description Amount to capture in cents. Defaults to the full authorized amount.",
+                    "type": "integer",
+                    "example": 7500
+                }
+            }
+        },
+        "dto.CardControlsResponse": {
+            "description": "Spending controls of a card. A null or empty control does not restrict the card.",
+            "type": "object",
+            "properties": {
+                "allowed_transaction_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "PURCHASE"
+                    ]
+                },
+                "blocked_merchant_categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "7995"
+                    ]
+                },
+                "card_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "daily_limit_cents": {
+                    "type": "integer",
+                    "example": 100000
+                },
+                "max_transaction_cents": {
+                    "type": "integer",
+                    "example": 50000
+                },
+                "monthly_limit_cents": {
+                    "type": "integer",
+                    "example": 1000000
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-09-22T19:15:24.526505Z"
+                },
+                "velocity_limit": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "velocity_window_seconds": {
+                    "type": "integer",
+                    "example": 3600
+                }
+            }
+        },
+        "dto.CardResponse": {
+            "description": "A payment card. Nullable columns are plain values or null.",
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string",
+                    "example": "e252f5dd-ded2-4a30-a4a5-6e2940008d54"
+                },
+                "amount_limit_cents": {
+                    "type": "integer",
+                    "example": 250000
+                },
+                "brand": {
+                    "type": "string",
+                    "enum": [
+                        "VISA",
+                        "MASTERCARD",
+                        "ELO",
+                        "AMEX",
+                        "UNKNOWN"
+                    ],
+                    "example": "VISA"
+                },
+                "card_token": {
+                    "type": "string",
+                    "example": "tok_Xb3kQ9vN2mR7tL1pW8cF5hJ0aZ4yE6sD"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-09-22T19:15:24.526505Z"
+                },
+                "expiry_month": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "expiry_year": {
+                    "type": "integer",
+                    "example": 2030
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "STANDARD",
+                        "SINGLE_USE",
+                        "MERCHANT_LOCKED"
+                    ],
+                    "example": "STANDARD"
+                },
+                "last_four_digits": {
+                    "type": "string",
+                    "example": "8995"
+                },
+                "locked_acceptor_id": {
+                    "type": "string",
+                    "example": "ACQ-000123"
+                },
+                "remaining_amount_cents": {
+                    "type": "integer",
+                    "example": 180000
+                },
+                "remaining_uses": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "replaces_card_id": {
+                    "type": "string",
+                    "example": "16ecac04-9e45-4a5b-b7d4-d6c1c66bafd6"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "ACTIVE",
+                        "BLOCKED",
+                        "CANCELLED",
+                        "EXPIRED"
+                    ],
+                    "example": "ACTIVE"
+                },
+                "status_reason": {
+                    "type": "string",
+                    "example": "Card reported lost"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-09-22T19:15:24.526505Z"
+                }
+            }
+        },
+        "dto.CardSecretResponse": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string",
+                    "example": "e252f5dd-ded2-4a30-a4a5-6e2940008d54"
+                },
+                "amount_limit_cents": {
+                    "type": "integer",
+                    "example": 250000
+                },
+                "brand": {
+                    "type": "string",
+                    "enum": [
+                        "VISA",
+                        "MASTERCARD",
+                        "ELO",
+                        "AMEX",
+                        "UNKNOWN"
+                    ],
+                    "example": "VISA"
+                },
+                "card_token": {
+                    "type": "string",
+                    "example": "tok_Xb3kQ9vN2mR7tL1pW8cF5hJ0aZ4yE6sD"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-09-22T19:15:24.526505Z"
+                },
+                "cvc": {
+                    "description": "@Description Card verification code. Shown only once.\n@Example 123",
+                    "type": "string"
+                },
+                "expiry_month": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "expiry_year": {
+                    "type": "integer",
+                    "example": 2030
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "STANDARD",
+                        "SINGLE_USE",
+                        "MERCHANT_LOCKED"
+                    ],
+                    "example": "STANDARD"
+                },
+                "last_four_digits": {
+                    "type": "string",
+                    "example": "8995"
+                },
+                "locked_acceptor_id": {
+                    "type": "string",
+                    "example": "ACQ-000123"
+                },
+                "remaining_amount_cents": {
+                    "type": "integer",
+                    "example": 180000
+                },
+                "remaining_uses": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "replaces_card_id": {
+                    "type": "string",
+                    "example": "16ecac04-9e45-4a5b-b7d4-d6c1c66bafd6"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "ACTIVE",
+                        "BLOCKED",
+                        "CANCELLED",
+                        "EXPIRED"
+                    ],
+                    "example": "ACTIVE"
+                },
+                "status_reason": {
+                    "type": "string",
+                    "example": "Card reported lost"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-09-22T19:15:24.526505Z"
+                }
+            }
+        },
+        "dto.CardStatusRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "description": "@Description Why the status is being changed. Stored on the card.\n@Example Card reported lost",
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "dto.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "description": "@Example s3nh4-f0rt3",
+                    "type": "string"
+                },
+                "new_password": {
+                    "description": "@Example n0v4-s3nh4-f0rt3",
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8
+                }
+            }
+        },
+        "dto.CreateAPIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "owner_type",
+                "scopes"
+            ],
+            "properties": {
+                "account_id": {
+                    "description": "@Description Account the key is tied to. Required for ACCOUNT keys.\n@Format uuid",
+                    "type": "string"
+                },
+                "merchant_id": {
+                    "description": "@Description Merchant of an OPERATOR key without an operator_id. Keys of accounts and operators belong to their owner's merchant. When omitted the key belongs to the caller's merchant, or reaches every merchant when created by a platform operator.\n@Format uuid",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "@Description Human readable label for the key.\n@Example checkout backend",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "operator_id": {
+                    "description": "@Description Operator the key acts for. Only for OPERATOR keys; without it the key is not tied to a person and acts with its scopes alone.\n@Format uuid",
+                    "type": "string"
+                },
+                "owner_type": {
+                    "description": "@Description Who the key acts for.\n@Enum ACCOUNT,OPERATOR\n@Example ACCOUNT",
+                    "type": "string",
+                    "enum": [
+                        "ACCOUNT",
+                        "OPERATOR"
+                    ]
+                },
+                "scopes": {
+                    "description": "@Description Scopes granted to the key. Operator scopes (admin, transactions:refund, accounts:freeze, accounts:close, operators:manage, audit:read) are only allowed for OPERATOR keys.\n@Example [\"transactions:write\",\"cards:read\"]",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.CreateAccountRequest": {
+            "type": "object",
+            "required": [
+                "username"
+            ],
+            "properties": {
+                "merchant_id": {
+                    "description": "@Description Merchant the account belongs to. Required for platform operators; other callers create accounts in their own merchant and may omit it.\n@Example 00000000-0000-0000-0000-000000000001",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "@Description Optional password used to sign in to the dashboard.\n@Example s3nh4-f0rt3",
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8
+                },
+                "username": {
+                    "description": "@Description The username of the new account.\n@Example charlie",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                }
+            }
+        },
+        "dto.CreateCardRequest": {
+            "description": "Request body for creating a new card",
+            "type": "object",
+            "required": [
+                "account_id"
+            ],
+            "properties": {
+                "account_id": {
+                    "description": "@Description Account ID to associate the new card (UUID)\n@Example e252f5dd-ded2-4a30-a4a5-6e2940008d54",
+                    "type": "string"
+                },
+                "amount_limit_cents": {
+                    "description": "@Description Most the card may spend on purchases over its lifetime, in cents. Required for MERCHANT_LOCKED, optional for SINGLE_USE, not allowed on STANDARD cards.\n@Example 250000",
+                    "type": "integer"
+                },
+                "brand": {
+                    "description": "@Description Network of the new card. Defaults to the network of the first configured BIN range.\n@Enum VISA,MASTERCARD,ELO,AMEX\n@Example VISA",
+                    "type": "string",
+                    "enum": [
+                        "VISA",
+                        "MASTERCARD",
+                        "ELO",
+                        "AMEX"
+                    ]
+                },
+                "kind": {
+                    "description": "@Description Kind of card. SINGLE_USE cards are cancelled once their first purchase is approved; MERCHANT_LOCKED cards need locked_acceptor_id and amount_limit_cents. Defaults to STANDARD.\n@Enum STANDARD,SINGLE_USE,MERCHANT_LOCKED\n@Example SINGLE_USE",
+                    "type": "string",
+                    "enum": [
+                        "STANDARD",
+                        "SINGLE_USE",
+                        "MERCHANT_LOCKED"
+                    ]
+                },
+                "locked_acceptor_id": {
+                    "description": "@Description Merchant (card acceptor) the card may buy from. Required for MERCHANT_LOCKED, optional for SINGLE_USE, not allowed on STANDARD cards.\n@Example ACQ-000123",
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 1
+                }
+            }
+        },
+        "dto.CreateMerchantRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "description": "@Description Display name of the merchant.\n@Example Acme Store",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                }
+            }
+        },
+        "dto.CreateOperatorRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "role",
+                "username"
+            ],
+            "properties": {
+                "merchant_id": {
+                    "description": "@Description Merchant the operator works for. Only platform operators may set it; omitted, the operator belongs to the caller's merchant, or works across merchants when created by a platform operator.\n@Format uuid",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "@Description Display name of the operator.\n@Example Jane Doe",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "role": {
+                    "description": "@Description Role of the operator. See GET /roles.\n@Example SUPPORT",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "@Description Login name of the operator.\n@Example jane.support",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                }
+            }
+        },
+        "dto.CreateTransactionRequest": {
+            "description": "Request body for creating a new transaction",
+            "type": "object",
+            "required": [
+                "account_id",
+                "amount_cents",
+                "type"
+            ],
+            "properties": {
+                "acceptor_id": {
+                    "description": "@Description Merchant (card acceptor) a card transaction is made at. Required for purchases with a card locked to a merchant.",
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 1,
+                    "example": "ACQ-000123"
+                },
+                "account_id": {
+                    "description": "@Description The account's ID for which the transaction will be performed (UUID).",
+                    "type": "string",
+                    "example": "e7b40123-cb12-41fa-b5bc-5a128448027e"
+                },
+                "amount_cents": {
+                    "description": "@Description Transaction amount in cents. Must be positive.",
+                    "type": "integer",
+                    "example": 10000
+                },
+                "capture": {
+                    "description": "@Description When false a PURCHASE only reserves the funds (AUTHORIZED) until it is captured or voided. Defaults to true.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "card_token": {
+                    "description": "@Description The credit card token (optional for some transaction types like DEPOSIT, not allowed for CHARGE).",
+                    "type": "string",
+                    "maxLength": 126,
+                    "minLength": 20,
+                    "example": "16ecac04-9e45-4a5b-b7d4-d6c1c66bafd6"
+                },
+                "destination_account_id": {
+                    "description": "@Description The account credited by a TRANSFER (UUID, only for TRANSFER).",
+                    "type": "string",
+                    "example": "0b6f3c1e-4d2a-4f7e-9a43-1f5c2d7e8b90"
+                },
+                "merchant_category_code": {
+                    "description": "@Description Merchant category code (ISO 18245) of the merchant a card transaction is made at. Checked against the card's blocked categories.",
+                    "type": "string",
+                    "example": "5411"
+                },
+                "reason_code": {
+                    "description": "@Description Why the account is being charged. Required for CHARGE and ignored otherwise.",
+                    "type": "string",
+                    "enum": [
+                        "SERVICE_FEE",
+                        "MAINTENANCE_FEE",
+                        "CHARGEBACK",
+                        "ADJUSTMENT",
+                        "PENALTY"
+                    ],
+                    "example": "SERVICE_FEE"
+                },
+                "refund_transaction_id": {
+                    "description": "@Description The ID of the transaction being refunded (only for REFUND).",
+                    "type": "string",
+                    "example": "3c2b4791-7f84-4d77-b2e0-56de8df97f33"
+                },
+                "type": {
+                    "description": "@Description Transaction type: DEPOSIT, PURCHASE, REFUND, CHARGE, TRANSFER",
+                    "type": "string",
+                    "enum": [
+                        "DEPOSIT",
+                        "PURCHASE",
+                        "REFUND",
+                        "CHARGE",
+                        "TRANSFER"
+                    ],
+                    "example": "PURCHASE"
+                }
+            }
+        },
+        "dto.DetokenizedCardResponse": {
+            "description": "Full card number of a vaulted card",
+            "type": "object",
+            "properties": {
+                "card_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "expiry_month": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "expiry_year": {
+                    "type": "integer",
+                    "example": 2030
+                },
+                "pan": {
+                    "type": "string",
+                    "example": "4532110000000003"
+                }
+            }
+        },
+        "dto.LoginRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "merchant_id": {
+                    "description": "@Description Merchant the account belongs to. Defaults to the default merchant.\n@Format uuid",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "@Example s3nh4-f0rt3",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "@Example charlie",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ProcessingResponse": {
+            "description": "Response when balance calculation is processing",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "The account balance is being calculated. Please try again later."
+                },
+                "status": {
+                    "type": "string",
+                    "example": "processing"
+                }
+            }
+        },
+        "dto.RefreshRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "description": "@Description Refresh token returned by login, register or a previous refresh.",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "merchant_id": {
+                    "description": "@Description Merchant to sign up with. Defaults to the default merchant.\n@Format uuid",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "@Description Password used to sign in.\n@Example s3nh4-f0rt3",
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8
+                },
+                "username": {
+                    "description": "@Description The username of the new account.\n@Example charlie",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                }
+            }
+        },
+        "dto.ResponseAccountBalance": {
+            "description": "Response for account balance",
+            "type": "object",
+            "properties": {
+                "balance_cents": {
+                    "type": "integer",
+                    "example": 10000
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "dto.ResponseBalanceHistory": {
+            "description": "Running balance of an account over time",
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2025-10-01T00:00:00Z"
+                },
+                "interval": {
+                    "type": "string",
+                    "example": "day"
+                },
+                "opening_balance_cents": {
+                    "description": "@Description Balance at from, in cents.",
+                    "type": "integer",
+                    "example": 10000
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BalanceHistoryPoint"
+                    }
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-10-08T00:00:00Z"
+                }
+            }
+        },
+        "dto.ResponseCreateTransactionRequest": {
+            "description": "Response returned when a transaction is created or queried",
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string",
+                    "example": "e7b40123-cb12-41fa-b5bc-5a128448027e"
+                },
+                "amount_cents": {
+                    "type": "integer",
+                    "example": 10000
+                },
+                "card_id": {
+                    "type": "string",
+                    "example": "16ecac04-9e45-4a5b-b7d4-d6c1c66bafd6"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "PURCHASE"
+                }
+            }
+        },
+        "dto.ResponseOutboxMetrics": {
+            "description": "Outbox relay metrics",
+            "type": "object",
+            "properties": {
+                "failed_attempts_total": {
+                    "description": "@Description Failed publish attempts by this instance since startup.",
+                    "type": "integer",
+                    "example": 2
+                },
+                "oldest_pending_age_seconds": {
+                    "description": "@Description Age of the oldest pending message in seconds.",
+                    "type": "number",
+                    "example": 1.5
+                },
+                "pending_messages": {
+                    "description": "@Description Messages written but not yet published.",
+                    "type": "integer",
+                    "example": 3
+                },
+                "published_total": {
+                    "description": "@Description Messages published by this instance since startup.",
+                    "type": "integer",
+                    "example": 1024
+                },
+                "retrying_messages": {
+                    "description": "@Description Pending messages that already failed at least once.",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.ResponseTransactionDetails": {
+            "description": "Transaction with its refund summary. Refund fields are omitted for REFUND transactions.",
+            "type": "object",
+            "properties": {
+                "acceptor_id": {
+                    "description": "@Description Merchant (card acceptor) a card transaction was made at. Checked against the merchant a MERCHANT_LOCKED card is locked to. Nullable.\n@Example ACQ-000123",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "account_id": {
+                    "description": "@Description Identifier of the account associated with this transaction (UUID).\n@Format uuid\n@Example e8b4d4c2-f9b6-4b1e-8e5e-9a9c2c1a1a9e",
+                    "type": "string"
+                },
+                "amount_cents": {
+                    "description": "@Description Transaction amount in the smallest currency unit (e.g., cents). Must be positive.\n@Minimum 1\n@Example 5000",
+                    "type": "integer"
+                },
+                "authorized_amount_cents": {
+                    "description": "@Description Amount reserved by the authorization hold, in cents. Only set for purchases created with capture=false. Nullable.\n@Example 10000",
+                    "type": "integer",
+                    "x-nullable": true
+                },
+                "card_id": {
+                    "description": "@Description Identifier of the card used for the transaction. Nullable.\n@Format uuid\n@Example f0c3a2a6-0b3c-4a3e-8c7a-5b12bf7e4e1a",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "counterpart_transaction_id": {
+                    "description": "@Description Identifier of the other leg when this is a TRANSFER_OUT or TRANSFER_IN. Nullable.\n@Format uuid\n@Example 9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "created_at": {
+                    "description": "@Description Timestamp when the transaction was created (UTC, RFC3339 format).\n@Format date-time\n@Example 2025-10-03T20:30:00.123Z",
+                    "type": "string"
+                },
+                "decline_reason": {
+                    "description": "@Description Card control or virtual card constraint that declined the transaction. Only set on transactions REJECTED when they were created. Nullable.\n@Enum TRANSACTION_TYPE_NOT_ALLOWED MERCHANT_CATEGORY_BLOCKED MAX_TRANSACTION_AMOUNT_EXCEEDED DAILY_LIMIT_EXCEEDED MONTHLY_LIMIT_EXCEEDED VELOCITY_LIMIT_EXCEEDED SINGLE_USE_CARD_USED ACCEPTOR_NOT_ALLOWED AMOUNT_LIMIT_EXCEEDED\n@Example DAILY_LIMIT_EXCEEDED",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "hold_expires_at": {
+                    "description": "@Description Time at which an uncaptured authorization hold expires (UTC, RFC3339 format). Nullable.\n@Format date-time\n@Example 2025-10-10T20:30:00.123Z",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "id": {
+                    "description": "@Description Unique identifier for the transaction (UUID).\n@Format uuid\n@Example a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+                    "type": "string"
+                },
+                "idempotency_key": {
+                    "description": "@Description Unique key to guarantee idempotency of the transaction.\n@Example 2025-10-03-17:30:00:e8b4d4c2:DEPOSIT:5000",
+                    "type": "string"
+                },
+                "merchant_category_code": {
+                    "description": "@Description Merchant category code (ISO 18245) of the merchant a card transaction was made at. Nullable.\n@Example 5411",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "merchant_id": {
+                    "description": "@Description Merchant of the transaction's account (UUID).\n@Format uuid",
+                    "type": "string"
+                },
+                "pending_refund_cents": {
+                    "description": "@Description Sum of refunds against this transaction still waiting for approval, in cents.",
+                    "type": "integer",
+                    "example": 0
+                },
+                "reason_code": {
+                    "description": "@Description Why the account was charged. Only set for CHARGE transactions. Nullable.\n@Enum SERVICE_FEE MAINTENANCE_FEE CHARGEBACK ADJUSTMENT PENALTY\n@Example SERVICE_FEE",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "refund_transaction_id": {
+                    "description": "@Description Identifier of the original transaction when this is a refund. Nullable.\n@Format uuid\n@Example c7a3c3b1-a2e4-4a25-8c7a-5b12bf7e4e1a",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "refundable_cents": {
+                    "description": "@Description Amount that can still be refunded, in cents. Zero unless the transaction is APPROVED.",
+                    "type": "integer",
+                    "example": 7500
+                },
+                "refunded_cents": {
+                    "description": "@Description Sum of approved refunds against this transaction, in cents.",
+                    "type": "integer",
+                    "example": 2500
+                },
+                "status": {
+                    "description": "@Description Current status of the transaction. Purchases created with capture=false become AUTHORIZED and later APPROVED (captured), VOIDED or EXPIRED.\n@Enum PENDING APPROVED REJECTED AUTHORIZED VOIDED EXPIRED\n@Example PENDING",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "@Description Type of the transaction. CHARGE is a fee or merchant initiated debit that may overdraw the account up to the configured limit. A TRANSFER request is stored as a TRANSFER_OUT leg on the source account and a TRANSFER_IN leg on the destination account.\n@Enum DEPOSIT PURCHASE REFUND CHARGE TRANSFER_OUT TRANSFER_IN\n@Example DEPOSIT",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ResponseTransactionPage": {
+            "description": "A page of transactions. Pass next_cursor as cursor to fetch the next page.",
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Transaction"
+                    }
+                },
+                "has_more": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "next_cursor": {
+                    "description": "@Description Cursor of the next page. Null on the last page.",
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "MjAyNS0xMC0wMVQxMjowMDowMFp8NTUwZTg0MDAtZTI5Yi00MWQ0LWE3MTYtNDQ2NjU1NDQwMDAw"
+                }
+            }
+        },
+        "dto.SessionResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "description": "@Description JWT to send as \"Authorization: Bearer \u003ctoken\u003e\".",
+                    "type": "string"
+                },
+                "account": {
+                    "$ref": "#/definitions/models.Account"
+                },
+                "expires_in": {
+                    "description": "@Description Seconds until the access token expires.\n@Example 900",
+                    "type": "integer"
+                },
+                "refresh_expires_in": {
+                    "description": "@Description Seconds until the refresh token expires.\n@Example 2592000",
+                    "type": "integer"
+                },
+                "refresh_token": {
+                    "description": "@Description Opaque token exchanged for a new pair at /auth/refresh.",
+                    "type": "string"
+                },
+                "token_type": {
+                    "description": "@Example Bearer",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UpdateAccountRequest": {
+            "type": "object",
+            "properties": {
+                "metadata": {
+                    "description": "@Description JSON object merged into the account metadata. Keys set to null are removed.",
+                    "type": "object"
+                },
+                "username": {
+                    "description": "@Description New username for the account. Omit to keep the current one.\n@Example charlie",
+                    "type": "string",
                     "maxLength": 100,
                     "minLength": 3
                 }
             }
         },
-        "dto.CreateCardRequest": {
-            "description": "Request body for creating a new card",
+        "dto.UpdateCardControlsRequest": {
+            "description": "Spending controls of a card. The request replaces every control; omitted or null controls are removed.",
             "type": "object",
-            "required": [
-                "account_id"
-            ],
             "properties": {
-                "account_id": {
-                    "description": "@Description Account ID to associate the new card (UUID)\n@Example e252f5dd-ded2-4a30-a4a5-6e2940008d54",
-                    "type": "string"
+                "allowed_transaction_types": {
+                    "description": "@Description Transaction types the card may be used for. Empty allows every type.\n@Example [\"PURCHASE\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "blocked_merchant_categories": {
+                    "description": "@Description Merchant category codes (ISO 18245, four digits) the card may not be used at.\n@Example [\"7995\"]",
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "daily_limit_cents": {
+                    "description": "@Description Most the card may spend on PURCHASE and TRANSFER per UTC day, in cents.\n@Example 100000",
+                    "type": "integer"
+                },
+                "max_transaction_cents": {
+                    "description": "@Description Largest amount a single PURCHASE or TRANSFER may have, in cents.\n@Example 50000",
+                    "type": "integer"
+                },
+                "monthly_limit_cents": {
+                    "description": "@Description Most the card may spend on PURCHASE and TRANSFER per UTC calendar month, in cents.\n@Example 1000000",
+                    "type": "integer"
+                },
+                "velocity_limit": {
+                    "description": "@Description Most transactions the card may make within velocity_window_seconds. Set together with velocity_window_seconds.\n@Example 5",
+                    "type": "integer"
+                },
+                "velocity_window_seconds": {
+                    "description": "@Description Length of the velocity window, in seconds (at most 30 days).\n@Example 3600",
+                    "type": "integer",
+                    "maximum": 2592000
                 }
             }
         },
-        "dto.CreateTransactionRequest": {
-            "description": "Request body for creating a new transaction",
+        "dto.UpdateOperatorRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "@Description Set to false to lock the operator out. Their keys stop working until reactivated.",
+                    "type": "boolean"
+                },
+                "role": {
+                    "description": "@Description New role for the operator. Omit to keep the current one.\n@Example FINANCE",
+                    "type": "string",
+                    "minLength": 1
+                }
+            }
+        },
+        "models.APIKey": {
             "type": "object",
-            "required": [
-                "account_id",
-                "amount_cents",
-                "type"
-            ],
             "properties": {
                 "account_id": {
-                    "description": "@Description The account's ID for which the transaction will be performed (UUID).",
+                    "description": "@Description Account the key is tied to. Null for OPERATOR keys.\n@Format uuid",
                     "type": "string",
-                    "example": "e7b40123-cb12-41fa-b5bc-5a128448027e"
+                    "x-nullable": true
                 },
-                "amount_cents": {
-                    "description": "@Description Transaction amount in cents. Must be positive.",
-                    "type": "integer",
-                    "example": 10000
+                "created_at": {
+                    "description": "@Description Timestamp when the key was created (UTC, RFC3339 format).\n@Format date-time",
+                    "type": "string"
                 },
-                "card_token": {
-                    "description": "@Description The credit card token (optional for some transaction types like DEPOSIT).",
+                "id": {
+                    "description": "@Description Unique identifier of the key (UUID).\n@Format uuid",
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "description": "@Description Timestamp of the last authenticated request made with the key.\n@Format date-time",
                     "type": "string",
-                    "maxLength": 126,
-                    "minLength": 20,
-                    "example": "16ecac04-9e45-4a5b-b7d4-d6c1c66bafd6"
+                    "x-nullable": true
                 },
-                "refund_transaction_id": {
-                    "description": "@Description The ID of the transaction being refunded (only for REFUND).",
+                "merchant_id": {
+                    "description": "@Description Merchant the key is limited to. Null for platform keys, which reach every merchant.\n@Format uuid",
                     "type": "string",
-                    "example": "3c2b4791-7f84-4d77-b2e0-56de8df97f33"
+                    "x-nullable": true
                 },
-                "type": {
-                    "description": "@Description Transaction type: DEPOSIT, PURCHASE, REFUND, CHARGE",
+                "name": {
+                    "description": "@Description Human readable label for the key.\n@Example checkout backend",
+                    "type": "string"
+                },
+                "operator_id": {
+                    "description": "@Description Operator the key is tied to. Null for ACCOUNT keys and for operator keys not tied to a person, such as the bootstrap key.\n@Format uuid",
                     "type": "string",
-                    "enum": [
-                        "DEPOSIT",
-                        "PURCHASE",
-                        "REFUND",
-                        "CHARGE"
-                    ],
-                    "example": "PURCHASE"
+                    "x-nullable": true
+                },
+                "owner_type": {
+                    "description": "@Description Who the key acts for. ACCOUNT keys can only reach their own account.\n@Enum ACCOUNT,OPERATOR",
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "@Description Non-secret start of the key, shown to tell keys apart.\n@Example pgk_3f9a1c2e",
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "description": "@Description Timestamp when the key was revoked. Revoked keys are rejected.\n@Format date-time",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "rotated_at": {
+                    "description": "@Description Timestamp of the last rotation, if any.\n@Format date-time",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "scopes": {
+                    "description": "@Description Scopes granted to the key.\n@Example [\"transactions:write\",\"cards:read\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "dto.ProcessingResponse": {
-            "description": "Response when balance calculation is processing",
+        "models.Account": {
             "type": "object",
             "properties": {
-                "message": {
+                "created_at": {
+                    "description": "@Description Timestamp when the account was created (UTC, RFC3339 format).\n@Format date-time\n@Example 2025-09-22T19:15:24.526505Z",
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "@Description Timestamp when the account was soft deleted (UTC, RFC3339 format). Null while the account is not deleted.\n@Format date-time\n@Example 2025-09-22T19:15:24.526505Z",
                     "type": "string",
-                    "example": "The account balance is being calculated. Please try again later."
+                    "x-nullable": true
+                },
+                "id": {
+                    "description": "@Description Unique identifier of the account (UUID).\n@Format uuid\n@Example 550e8400-e29b-41d4-a716-446655440000",
+                    "type": "string"
+                },
+                "merchant_id": {
+                    "description": "@Description Merchant the account belongs to (UUID).\n@Format uuid",
+                    "type": "string"
+                },
+                "metadata": {
+                    "description": "@Description Free-form JSON object attached to the account by the client.",
+                    "type": "object"
                 },
                 "status": {
+                    "description": "@Description Lifecycle status of the account. Only ACTIVE accounts can transact or issue cards.\n@Enum ACTIVE,FROZEN,CLOSED\n@Example ACTIVE",
+                    "type": "string"
+                },
+                "status_reason": {
+                    "description": "@Description Reason given for the last status change, if any.\n@Example Suspicious activity reported by the owner",
                     "type": "string",
-                    "example": "processing"
+                    "x-nullable": true
+                },
+                "updated_at": {
+                    "description": "@Description Timestamp of the last account update (UTC, RFC3339 format).\n@Format date-time\n@Example 2025-09-22T19:15:24.526505Z",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "@Description Unique username chosen by the account owner. Unique among the merchant's accounts that are not deleted, compared case-insensitively.\n@MinLength 3\n@MaxLength 32\n@Example charlie",
+                    "type": "string"
                 }
             }
         },
-        "dto.ResponseAccountBalance": {
-            "description": "Response for account balance",
+        "models.AccountPosting": {
             "type": "object",
             "properties": {
-                "balance_cents": {
-                    "type": "integer",
-                    "example": 10000
+                "account_id": {
+                    "description": "@Description Customer account of the posting (UUID). Null for system accounts.\n@Format uuid",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "amount_cents": {
+                    "description": "@Description Amount posted in cents. Always positive.\n@Example 1500",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "description": "@Description Timestamp when the posting was written (UTC, RFC3339 format).\n@Format date-time",
+                    "type": "string"
+                },
+                "direction": {
+                    "description": "@Description Side of the posting. A CREDIT increases a customer balance, a DEBIT decreases it.\n@Enum DEBIT CREDIT",
+                    "type": "string"
                 },
                 "id": {
+                    "description": "@Description Unique identifier of the posting (UUID).\n@Format uuid",
+                    "type": "string"
+                },
+                "journal_entry_id": {
+                    "description": "@Description Journal entry the posting belongs to (UUID).\n@Format uuid",
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "@Description Kind of the journal entry.\n@Example PURCHASE",
+                    "type": "string"
+                },
+                "system_account": {
+                    "description": "@Description System account of the posting. Null for customer accounts.\n@Enum SETTLEMENT FEES",
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "x-nullable": true
+                },
+                "transaction_id": {
+                    "description": "@Description Transaction that produced the posting (UUID).\n@Format uuid",
+                    "type": "string"
+                },
+                "transaction_status": {
+                    "description": "@Description Current status of that transaction. Rejected transactions also carry a REVERSAL entry that cancels their postings.\n@Example APPROVED",
+                    "type": "string"
                 }
             }
         },
-        "dto.ResponseCreateTransactionRequest": {
-            "description": "Response returned when a transaction is created or queried",
+        "models.AuditLog": {
             "type": "object",
             "properties": {
                 "account_id": {
+                    "description": "@Description Account named in the route, if any.\n@Format uuid",
                     "type": "string",
-                    "example": "e7b40123-cb12-41fa-b5bc-5a128448027e"
+                    "x-nullable": true
                 },
-                "amount_cents": {
-                    "type": "integer",
-                    "example": 10000
+                "api_key_id": {
+                    "description": "@Description API key the request was made with. Null for entries recorded before operators needed an API key.\n@Format uuid",
+                    "type": "string",
+                    "x-nullable": true
                 },
-                "card_id": {
+                "created_at": {
+                    "description": "@Description Timestamp of the request (UTC, RFC3339 format).\n@Format date-time",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "@Description Unique identifier of the entry (UUID).\n@Format uuid",
+                    "type": "string"
+                },
+                "merchant_id": {
+                    "description": "@Description Merchant the operator was acting for. Null for platform operators.\n@Format uuid",
                     "type": "string",
-                    "example": "16ecac04-9e45-4a5b-b7d4-d6c1c66bafd6"
+                    "x-nullable": true
                 },
-                "type": {
+                "method": {
+                    "description": "@Description HTTP method of the request.\n@Example POST",
+                    "type": "string"
+                },
+                "operator_id": {
+                    "description": "@Description Operator who made the request. Null for operator keys not tied to a person.\n@Format uuid",
                     "type": "string",
-                    "example": "PURCHASE"
+                    "x-nullable": true
+                },
+                "path": {
+                    "description": "@Description Path of the request, including the query string.\n@Example /accounts/550e8400-e29b-41d4-a716-446655440000/freeze",
+                    "type": "string"
+                },
+                "role": {
+                    "description": "@Description Role the operator held at the time of the request.",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "route": {
+                    "description": "@Description Route template matched by the request.\n@Example /accounts/{accountId}/freeze",
+                    "type": "string"
+                },
+                "status_code": {
+                    "description": "@Description HTTP status code of the response.\n@Example 200",
+                    "type": "integer"
                 }
             }
         },
-        "models.Account": {
+        "models.Merchant": {
             "type": "object",
             "properties": {
                 "created_at": {
-                    "description": "@Description Timestamp when the account was created (UTC, RFC3339 format).\n@Format date-time\n@Example 2025-09-22T19:15:24.526505Z",
+                    "description": "@Description Timestamp when the merchant was created (UTC, RFC3339 format).\n@Format date-time",
                     "type": "string"
                 },
                 "id": {
-                    "description": "@Description Unique identifier of the account (UUID).\n@Format uuid\n@Example 550e8400-e29b-41d4-a716-446655440000",
+                    "description": "@Description Unique identifier of the merchant (UUID).\n@Format uuid\n@Example 00000000-0000-0000-0000-000000000001",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "@Description Display name of the merchant.\n@Example Acme Store",
                     "type": "string"
                 },
                 "updated_at": {
-                    "description": "@Description Timestamp of the last account update (UTC, RFC3339 format).\n@Format date-time\n@Example 2025-09-22T19:15:24.526505Z",
+                    "description": "@Description Timestamp of the last merchant update (UTC, RFC3339 format).\n@Format date-time",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Operator": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "@Description Inactive operators are rejected on every request, whatever key they use.",
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "description": "@Description Timestamp when the operator was created (UTC, RFC3339 format).\n@Format date-time",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "@Description Unique identifier of the operator (UUID).\n@Format uuid",
+                    "type": "string"
+                },
+                "merchant_id": {
+                    "description": "@Description Merchant whose staff the operator belongs to. Null for platform staff, who reach every merchant.\n@Format uuid",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "name": {
+                    "description": "@Description Display name of the operator.\n@Example Jane Doe",
+                    "type": "string"
+                },
+                "role": {
+                    "description": "@Description Role of the operator. Its permissions are listed by GET /roles.\n@Enum SUPPORT,FINANCE,ADMIN\n@Example SUPPORT",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "@Description Timestamp of the last operator update (UTC, RFC3339 format).\n@Format date-time",
                     "type": "string"
                 },
                 "username": {
-                    "description": "@Description Unique username chosen by the account owner. Must be unique across the system.\n@MinLength 3\n@MaxLength 32\n@Example charlie",
+                    "description": "@Description Login name of the operator. Unique, compared case-insensitively.\n@Example jane.support",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Role": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "@Description What the role is meant for.",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "@Description Name of the role.\n@Example FINANCE",
+                    "type": "string"
+                },
+                "permissions": {
+                    "description": "@Description Permissions granted by the role. admin grants every permission.\n@Example [\"accounts:read\",\"transactions:read\",\"transactions:refund\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.Transaction": {
+            "type": "object",
+            "properties": {
+                "acceptor_id": {
+                    "description": "@Description Merchant (card acceptor) a card transaction was made at. Checked against the merchant a MERCHANT_LOCKED card is locked to. Nullable.\n@Example ACQ-000123",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "account_id": {
+                    "description": "@Description Identifier of the account associated with this transaction (UUID).\n@Format uuid\n@Example e8b4d4c2-f9b6-4b1e-8e5e-9a9c2c1a1a9e",
+                    "type": "string"
+                },
+                "amount_cents": {
+                    "description": "@Description Transaction amount in the smallest currency unit (e.g., cents). Must be positive.\n@Minimum 1\n@Example 5000",
+                    "type": "integer"
+                },
+                "authorized_amount_cents": {
+                    "description": "@Description Amount reserved by the authorization hold, in cents. Only set for purchases created with capture=false. Nullable.\n@Example 10000",
+                    "type": "integer",
+                    "x-nullable": true
+                },
+                "card_id": {
+                    "description": "@Description Identifier of the card used for the transaction. Nullable.\n@Format uuid\n@Example f0c3a2a6-0b3c-4a3e-8c7a-5b12bf7e4e1a",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "counterpart_transaction_id": {
+                    "description": "@Description Identifier of the other leg when this is a TRANSFER_OUT or TRANSFER_IN. Nullable.\n@Format uuid\n@Example 9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "created_at": {
+                    "description": "@Description Timestamp when the transaction was created (UTC, RFC3339 format).\n@Format date-time\n@Example 2025-10-03T20:30:00.123Z",
+                    "type": "string"
+                },
+                "decline_reason": {
+                    "description": "@Description Card control or virtual card constraint that declined the transaction. Only set on transactions REJECTED when they were created. Nullable.\n@Enum TRANSACTION_TYPE_NOT_ALLOWED MERCHANT_CATEGORY_BLOCKED MAX_TRANSACTION_AMOUNT_EXCEEDED DAILY_LIMIT_EXCEEDED MONTHLY_LIMIT_EXCEEDED VELOCITY_LIMIT_EXCEEDED SINGLE_USE_CARD_USED ACCEPTOR_NOT_ALLOWED AMOUNT_LIMIT_EXCEEDED\n@Example DAILY_LIMIT_EXCEEDED",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "hold_expires_at": {
+                    "description": "@Description Time at which an uncaptured authorization hold expires (UTC, RFC3339 format). Nullable.\n@Format date-time\n@Example 2025-10-10T20:30:00.123Z",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "id": {
+                    "description": "@Description Unique identifier for the transaction (UUID).\n@Format uuid\n@Example a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+                    "type": "string"
+                },
+                "idempotency_key": {
+                    "description": "@Description Unique key to guarantee idempotency of the transaction.\n@Example 2025-10-03-17:30:00:e8b4d4c2:DEPOSIT:5000",
+                    "type": "string"
+                },
+                "merchant_category_code": {
+                    "description": "@Description Merchant category code (ISO 18245) of the merchant a card transaction was made at. Nullable.\n@Example 5411",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "merchant_id": {
+                    "description": "@Description Merchant of the transaction's account (UUID).\n@Format uuid",
+                    "type": "string"
+                },
+                "reason_code": {
+                    "description": "@Description Why the account was charged. Only set for CHARGE transactions. Nullable.\n@Enum SERVICE_FEE MAINTENANCE_FEE CHARGEBACK ADJUSTMENT PENALTY\n@Example SERVICE_FEE",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "refund_transaction_id": {
+                    "description": "@Description Identifier of the original transaction when this is a refund. Nullable.\n@Format uuid\n@Example c7a3c3b1-a2e4-4a25-8c7a-5b12bf7e4e1a",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "status": {
+                    "description": "@Description Current status of the transaction. Purchases created with capture=false become AUTHORIZED and later APPROVED (captured), VOIDED or EXPIRED.\n@Enum PENDING APPROVED REJECTED AUTHORIZED VOIDED EXPIRED\n@Example PENDING",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "@Description Type of the transaction. CHARGE is a fee or merchant initiated debit that may overdraw the account up to the configured limit. A TRANSFER request is stored as a TRANSFER_OUT leg on the source account and a TRANSFER_IN leg on the destination account.\n@Enum DEPOSIT PURCHASE REFUND CHARGE TRANSFER_OUT TRANSFER_IN\n@Example DEPOSIT",
                     "type": "string"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "API key or session access token sent as \"Bearer \u003ctoken\u003e\".",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
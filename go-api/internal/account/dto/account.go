@@ -1,7 +1,32 @@
 package dto
 
+import "github.com/jmoiron/sqlx/types"
+
 type CreateAccountRequest struct {
 	// @Description The username of the new account.
 	// @Example charlie
 	Username string `json:"username" validate:"required,min=3,max=100"`
+
+	// @Description Optional password used to sign in to the dashboard.
+	// @Example s3nh4-f0rt3
+	Password *string `json:"password" validate:"omitempty,min=8,max=72"`
+
+	// @Description Merchant the account belongs to. Required for platform operators; other callers create accounts in their own merchant and may omit it.
+	// @Example 00000000-0000-0000-0000-000000000001
+	MerchantId *string `json:"merchant_id" validate:"omitempty,uuid"`
+}
+
+type UpdateAccountRequest struct {
+	// @Description New username for the account. Omit to keep the current one.
+	// @Example charlie
+	Username *string `json:"username" validate:"omitempty,min=3,max=100"`
+
+	// @Description JSON object merged into the account metadata. Keys set to null are removed.
+	Metadata types.JSONText `json:"metadata" swaggertype:"object"`
+}
+
+type AccountStatusRequest struct {
+	// @Description Why the status is being changed. Stored on the account.
+	// @Example Suspicious activity reported by the owner
+	Reason *string `json:"reason" validate:"omitempty,max=255"`
 }
@@ -0,0 +1,12 @@
+package account
+
+import "errors"
+
+var (
+	ErrAccountNotFound         = errors.New("account not found")
+	ErrAccountClosed           = errors.New("account is closed")
+	ErrEmptyAccountUpdate      = errors.New("at least one of username or metadata is required")
+	ErrInvalidMetadata         = errors.New("metadata must be a JSON object")
+	ErrInvalidStatusTransition = errors.New("account status transition is not allowed")
+	ErrMerchantRequired        = errors.New("a merchant is required")
+)
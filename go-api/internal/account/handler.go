@@ -1,15 +1,22 @@
 package account
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"payment-gateway/go-api/internal/account/dto"
 	"payment-gateway/go-api/internal/api"
 	"payment-gateway/go-api/internal/i18n"
+	"payment-gateway/go-api/internal/merchant"
 	"payment-gateway/go-api/internal/models"
+	"payment-gateway/go-api/internal/repository"
+	"payment-gateway/go-api/internal/tenant"
 	"strconv"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
 )
 
 type AccountHandler struct {
@@ -25,12 +32,17 @@ func NewAccountHandler(service AccountService) *AccountHandler {
 }
 
 // @Summary Create a new account
-// @Description Creates a new account with a username.
+// @Description Creates a new account with a username in the caller's merchant. Platform operators name the merchant with merchant_id.
 // @Tags accounts
 // @Accept json
 // @Produce json
 // @Param account body dto.CreateAccountRequest true "Account data for creation"
 // @Success 201 {object} models.Account
+// @Failure 400 {object} api.APIError "Invalid request body, validation failed or merchant_id missing"
+// @Failure 404 {object} api.APIError "Merchant not found"
+// @Failure 409 {object} api.APIError "Username already taken in the merchant"
+// @Failure 500 {object} api.APIError "Failed to create account"
+// @Security BearerAuth
 // @Router /accounts [post]
 func (h *AccountHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
 	lang := i18n.GetLangFromHeader(r)
@@ -46,8 +58,21 @@ func (h *AccountHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	account, err := h.service.CreateAccount(r.Context(), req.Username)
+	ctx := r.Context()
+	if req.MerchantId != nil {
+		var err error
+		if ctx, err = inMerchant(ctx, *req.MerchantId); err != nil {
+			writeAccountError(w, lang, err)
+			return
+		}
+	}
+
+	account, err := h.service.CreateAccount(ctx, req.Username, req.Password)
 	if err != nil {
+		if errors.Is(err, repository.ErrDuplicateKey) || errors.Is(err, ErrMerchantRequired) || errors.Is(err, merchant.ErrMerchantNotFound) {
+			writeAccountError(w, lang, err)
+			return
+		}
 		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorFailedToCreateAccount))
 		return
 	}
@@ -64,6 +89,7 @@ func (h *AccountHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Number of items per page" default(10)
 // @Success 200 {array} models.Account
+// @Security BearerAuth
 // @Router /accounts [get]
 func (h *AccountHandler) GetAllAccounts(w http.ResponseWriter, r *http.Request) {
 	lang := i18n.GetLangFromHeader(r)
@@ -98,3 +124,237 @@ func (h *AccountHandler) GetAllAccounts(w http.ResponseWriter, r *http.Request)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(accounts)
 }
+
+// @Summary Get an account by ID
+// @Description Returns a single account. Soft deleted accounts are still returned, with deleted_at set.
+// @Tags accounts
+// @Produce json
+// @Param accountId path string true "Account ID"
+// @Success 200 {object} models.Account
+// @Failure 404 {object} api.APIError "Account not found"
+// @Failure 500 {object} api.APIError "Failed to get account"
+// @Security BearerAuth
+// @Router /accounts/{accountId} [get]
+func (h *AccountHandler) GetAccountById(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+	accountId := mux.Vars(r)["accountId"]
+
+	account, err := h.service.GetAccountById(r.Context(), accountId)
+	h.writeAccount(w, lang, account, err)
+}
+
+// @Summary Get an account by username
+// @Description Looks up an account by username, case-insensitively, within the caller's merchant. Usernames are only unique per merchant, so platform operators name the merchant with merchant_id. Soft deleted accounts are not matched.
+// @Tags accounts
+// @Produce json
+// @Param username path string true "Username"
+// @Param merchant_id query string false "Merchant to search (required for platform operators)"
+// @Success 200 {object} models.Account
+// @Failure 400 {object} api.APIError "Invalid or missing merchant_id"
+// @Failure 404 {object} api.APIError "Account or merchant not found"
+// @Failure 500 {object} api.APIError "Failed to get account"
+// @Security BearerAuth
+// @Router /accounts/by-username/{username} [get]
+func (h *AccountHandler) GetAccountByUsername(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+	username := mux.Vars(r)["username"]
+
+	ctx := r.Context()
+	if merchantId := r.URL.Query().Get("merchant_id"); merchantId != "" {
+		if err := h.validate.Var(merchantId, "uuid"); err != nil {
+			api.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		var err error
+		if ctx, err = inMerchant(ctx, merchantId); err != nil {
+			writeAccountError(w, lang, err)
+			return
+		}
+	}
+
+	account, err := h.service.GetAccountByUsername(ctx, username)
+	if errors.Is(err, ErrMerchantRequired) {
+		writeAccountError(w, lang, err)
+		return
+	}
+	h.writeAccount(w, lang, account, err)
+}
+
+// inMerchant narrows ctx to merchantId for callers reaching every merchant.
+// Merchants out of the caller's reach are reported as not found.
+func inMerchant(ctx context.Context, merchantId string) (context.Context, error) {
+	narrowed, ok := tenant.Narrow(ctx, merchantId)
+	if !ok {
+		return ctx, merchant.ErrMerchantNotFound
+	}
+	return narrowed, nil
+}
+
+func (h *AccountHandler) writeAccount(w http.ResponseWriter, lang string, account *models.Account, err error) {
+	if err != nil {
+		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorFailedToGetAccounts))
+		return
+	}
+	if account == nil {
+		api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorAccountNotFound))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(account)
+}
+
+// @Summary Update an account
+// @Description Changes the username and/or merges metadata into the account. Top-level metadata keys set to null are removed. Closed accounts cannot be updated.
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param accountId path string true "Account ID"
+// @Param account body dto.UpdateAccountRequest true "Fields to update"
+// @Success 200 {object} models.Account
+// @Failure 400 {object} api.APIError "Invalid request body or validation failed"
+// @Failure 404 {object} api.APIError "Account not found"
+// @Failure 409 {object} api.APIError "Account is closed or username already taken"
+// @Failure 500 {object} api.APIError "Failed to update account"
+// @Security BearerAuth
+// @Router /accounts/{accountId} [patch]
+func (h *AccountHandler) UpdateAccount(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+	accountId := mux.Vars(r)["accountId"]
+
+	var req dto.UpdateAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidRequestBody))
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		api.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	account, err := h.service.UpdateAccount(r.Context(), accountId, req)
+	if err != nil {
+		writeAccountError(w, lang, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(account)
+}
+
+// @Summary Freeze an account
+// @Description Moves an ACTIVE account to FROZEN. Frozen accounts cannot create transactions or issue cards. Account holders may freeze their own account; operators need the accounts:freeze permission.
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param accountId path string true "Account ID"
+// @Param reason body dto.AccountStatusRequest false "Reason for the change"
+// @Success 200 {object} models.Account
+// @Failure 400 {object} api.APIError "Invalid request body"
+// @Failure 404 {object} api.APIError "Account not found"
+// @Failure 409 {object} api.APIError "Status transition not allowed"
+// @Security BearerAuth
+// @Router /accounts/{accountId}/freeze [post]
+func (h *AccountHandler) FreezeAccount(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.service.FreezeAccount)
+}
+
+// @Summary Unfreeze an account
+// @Description Moves a FROZEN account back to ACTIVE. Requires an operator with the accounts:freeze permission.
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param accountId path string true "Account ID"
+// @Param reason body dto.AccountStatusRequest false "Reason for the change"
+// @Success 200 {object} models.Account
+// @Failure 400 {object} api.APIError "Invalid request body"
+// @Failure 404 {object} api.APIError "Account not found"
+// @Failure 409 {object} api.APIError "Status transition not allowed"
+// @Security BearerAuth
+// @Router /accounts/{accountId}/unfreeze [post]
+func (h *AccountHandler) UnfreezeAccount(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.service.UnfreezeAccount)
+}
+
+// @Summary Close an account
+// @Description Moves an ACTIVE or FROZEN account to CLOSED. Closing is final. Requires an operator with the accounts:close permission.
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param accountId path string true "Account ID"
+// @Param reason body dto.AccountStatusRequest false "Reason for the change"
+// @Success 200 {object} models.Account
+// @Failure 400 {object} api.APIError "Invalid request body"
+// @Failure 404 {object} api.APIError "Account not found"
+// @Failure 409 {object} api.APIError "Status transition not allowed"
+// @Security BearerAuth
+// @Router /accounts/{accountId}/close [post]
+func (h *AccountHandler) CloseAccount(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.service.CloseAccount)
+}
+
+// @Summary Delete an account
+// @Description Soft deletes an account: it is closed, hidden from the account listing, and its cards, transactions and statements are kept. Requires an operator with the accounts:close permission.
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param accountId path string true "Account ID"
+// @Param reason body dto.AccountStatusRequest false "Reason for the deletion"
+// @Success 200 {object} models.Account
+// @Failure 400 {object} api.APIError "Invalid request body"
+// @Failure 404 {object} api.APIError "Account not found"
+// @Security BearerAuth
+// @Router /accounts/{accountId} [delete]
+func (h *AccountHandler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.service.DeleteAccount)
+}
+
+func (h *AccountHandler) changeStatus(w http.ResponseWriter, r *http.Request, apply func(ctx context.Context, id string, reason *string) (*models.Account, error)) {
+	lang := i18n.GetLangFromHeader(r)
+	accountId := mux.Vars(r)["accountId"]
+
+	// The body is optional: a status change without a reason is valid.
+	var req dto.AccountStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidRequestBody))
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		api.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	account, err := apply(r.Context(), accountId, req.Reason)
+	if err != nil {
+		writeAccountError(w, lang, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(account)
+}
+
+func writeAccountError(w http.ResponseWriter, lang string, err error) {
+	switch {
+	case errors.Is(err, ErrAccountNotFound):
+		api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorAccountNotFound))
+	case errors.Is(err, ErrAccountClosed):
+		api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorAccountClosed))
+	case errors.Is(err, repository.ErrDuplicateKey):
+		api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorDuplicateKey))
+	case errors.Is(err, ErrInvalidStatusTransition):
+		api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorInvalidAccountStatusTransition))
+	case errors.Is(err, ErrEmptyAccountUpdate):
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorEmptyAccountUpdate))
+	case errors.Is(err, ErrInvalidMetadata):
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidAccountMetadata))
+	case errors.Is(err, ErrMerchantRequired):
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorMerchantRequired))
+	case errors.Is(err, merchant.ErrMerchantNotFound):
+		api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorMerchantNotFound))
+	default:
+		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorFailedToUpdateAccount))
+	}
+}
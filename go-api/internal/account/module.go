@@ -1,6 +1,7 @@
 package account
 
 import (
+	"payment-gateway/go-api/internal/merchant"
 	"payment-gateway/go-api/internal/repository"
 
 	"github.com/jmoiron/sqlx"
@@ -11,9 +12,9 @@ type Module struct {
 	Service AccountService
 }
 
-func NewModule(db *sqlx.DB) *Module {
+func NewModule(db *sqlx.DB, merchantService merchant.MerchantService) *Module {
 	repo := repository.NewAccountRepository(db)
-	service := NewAccountService(repo)
+	service := NewAccountService(repo, merchantService)
 	handler := NewAccountHandler(service)
 
 	return &Module{
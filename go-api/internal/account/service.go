@@ -1,28 +1,62 @@
 package account
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
+	"fmt"
+	"payment-gateway/go-api/internal/account/dto"
+	"payment-gateway/go-api/internal/merchant"
 	"payment-gateway/go-api/internal/models"
 	"payment-gateway/go-api/internal/repository"
+	"payment-gateway/go-api/internal/tenant"
+
+	"golang.org/x/crypto/bcrypt"
 )
 
 type AccountService interface {
-	CreateAccount(ctx context.Context, username string) (*models.Account, error)
+	CreateAccount(ctx context.Context, username string, password *string) (*models.Account, error)
 	GetAllAccounts(ctx context.Context, page, limit int) ([]*models.Account, error)
 	GetAccountById(ctx context.Context, id string) (*models.Account, error)
+	GetAccountByUsername(ctx context.Context, username string) (*models.Account, error)
+	SetPassword(ctx context.Context, id, password string) error
+	UpdateAccount(ctx context.Context, id string, req dto.UpdateAccountRequest) (*models.Account, error)
+	FreezeAccount(ctx context.Context, id string, reason *string) (*models.Account, error)
+	UnfreezeAccount(ctx context.Context, id string, reason *string) (*models.Account, error)
+	CloseAccount(ctx context.Context, id string, reason *string) (*models.Account, error)
+	DeleteAccount(ctx context.Context, id string, reason *string) (*models.Account, error)
 }
 
 type accountServiceImpl struct {
-	repo repository.AccountRepository
+	repo            repository.AccountRepository
+	merchantService merchant.MerchantService
 }
 
-func NewAccountService(repo repository.AccountRepository) *accountServiceImpl {
-	return &accountServiceImpl{repo: repo}
+func NewAccountService(repo repository.AccountRepository, merchantService merchant.MerchantService) *accountServiceImpl {
+	return &accountServiceImpl{repo: repo, merchantService: merchantService}
 }
 
-func (s *accountServiceImpl) CreateAccount(ctx context.Context, username string) (*models.Account, error) {
+// CreateAccount creates the account under the merchant ctx is limited to.
+// Callers reaching every merchant must narrow ctx to one first.
+func (s *accountServiceImpl) CreateAccount(ctx context.Context, username string, password *string) (*models.Account, error) {
+	merchantId, ok := tenant.MerchantId(ctx)
+	if !ok {
+		return nil, ErrMerchantRequired
+	}
+	if _, err := s.merchantService.GetMerchantById(ctx, merchantId); err != nil {
+		return nil, err
+	}
+
 	account := &models.Account{
-		Username: username,
+		MerchantId: merchantId,
+		Username:   username,
+	}
+	if password != nil {
+		hash, err := bcrypt.GenerateFromPassword([]byte(*password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash password: %w", err)
+		}
+		account.PasswordHash = sql.NullString{String: string(hash), Valid: true}
 	}
 	if err := s.repo.CreateAccount(ctx, account); err != nil {
 		return nil, err
@@ -46,3 +80,131 @@ func (s *accountServiceImpl) GetAccountById(ctx context.Context, id string) (*mo
 	}
 	return account, nil
 }
+
+// GetAccountByUsername looks the username up within the merchant ctx is
+// limited to, as usernames are only unique per merchant.
+func (s *accountServiceImpl) GetAccountByUsername(ctx context.Context, username string) (*models.Account, error) {
+	if _, ok := tenant.MerchantId(ctx); !ok {
+		return nil, ErrMerchantRequired
+	}
+
+	account, err := s.repo.GetAccountByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+func (s *accountServiceImpl) SetPassword(ctx context.Context, id, password string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	return s.repo.SetPasswordHash(ctx, id, string(hash))
+}
+
+func (s *accountServiceImpl) UpdateAccount(ctx context.Context, id string, req dto.UpdateAccountRequest) (*models.Account, error) {
+	metadata := bytes.TrimSpace(req.Metadata)
+	if bytes.Equal(metadata, []byte("null")) {
+		metadata = nil
+	}
+	if len(metadata) > 0 && metadata[0] != '{' {
+		return nil, ErrInvalidMetadata
+	}
+	if req.Username == nil && len(metadata) == 0 {
+		return nil, ErrEmptyAccountUpdate
+	}
+
+	existing, err := s.repo.GetAccountById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrAccountNotFound
+	}
+	if existing.Status == models.AccountStatusClosed {
+		return nil, ErrAccountClosed
+	}
+
+	account, err := s.repo.UpdateAccount(ctx, id, req.Username, metadata)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		// Closed between the read above and the update.
+		return nil, ErrAccountClosed
+	}
+
+	return account, nil
+}
+
+func (s *accountServiceImpl) FreezeAccount(ctx context.Context, id string, reason *string) (*models.Account, error) {
+	return s.transition(ctx, id, models.AccountStatusFrozen, reason, models.AccountStatusActive)
+}
+
+func (s *accountServiceImpl) UnfreezeAccount(ctx context.Context, id string, reason *string) (*models.Account, error) {
+	return s.transition(ctx, id, models.AccountStatusActive, reason, models.AccountStatusFrozen)
+}
+
+// CloseAccount closes the account for good. The authenticators refuse the API
+// keys and sessions of closed accounts from then on.
+func (s *accountServiceImpl) CloseAccount(ctx context.Context, id string, reason *string) (*models.Account, error) {
+	return s.transition(ctx, id, models.AccountStatusClosed, reason, models.AccountStatusActive, models.AccountStatusFrozen)
+}
+
+// DeleteAccount soft deletes the account: it is closed if it was not already,
+// so its API keys and sessions stop working, hidden from listings, and its
+// cards and transactions are kept.
+func (s *accountServiceImpl) DeleteAccount(ctx context.Context, id string, reason *string) (*models.Account, error) {
+	existing, err := s.repo.GetAccountById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil || existing.DeletedAt != nil {
+		return nil, ErrAccountNotFound
+	}
+
+	account, err := s.repo.SoftDeleteAccount(ctx, id, existing.Status, reason)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrInvalidStatusTransition
+	}
+
+	return account, nil
+}
+
+// transition moves the account to status when its current status is one of
+// from. The update is conditional on the status read here, so a concurrent
+// transition makes this one fail instead of silently overwriting it.
+func (s *accountServiceImpl) transition(ctx context.Context, id, status string, reason *string, from ...string) (*models.Account, error) {
+	existing, err := s.repo.GetAccountById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil || existing.DeletedAt != nil {
+		return nil, ErrAccountNotFound
+	}
+
+	allowed := false
+	for _, f := range from {
+		if existing.Status == f {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, ErrInvalidStatusTransition
+	}
+
+	account, err := s.repo.UpdateAccountStatus(ctx, id, existing.Status, status, reason)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrInvalidStatusTransition
+	}
+
+	return account, nil
+}
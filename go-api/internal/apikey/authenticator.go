@@ -0,0 +1,111 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"payment-gateway/go-api/internal/account"
+	"payment-gateway/go-api/internal/auth"
+	"payment-gateway/go-api/internal/models"
+	"payment-gateway/go-api/internal/operator"
+	"payment-gateway/go-api/internal/tenant"
+)
+
+// Authenticator lets the auth middleware accept API keys.
+type Authenticator struct {
+	service         APIKeyService
+	accountService  account.AccountService
+	operatorService operator.OperatorService
+}
+
+func NewAuthenticator(service APIKeyService, accountService account.AccountService, operatorService operator.OperatorService) *Authenticator {
+	return &Authenticator{service: service, accountService: accountService, operatorService: operatorService}
+}
+
+func (a *Authenticator) Authenticate(ctx context.Context, token string) (*auth.Principal, error) {
+	key, err := a.service.Authenticate(ctx, token)
+	switch {
+	case errors.Is(err, ErrInvalidAPIKey):
+		return nil, auth.ErrInvalidCredentials
+	case errors.Is(err, ErrAPIKeyRevoked):
+		return nil, auth.ErrRevokedCredentials
+	case err != nil:
+		return nil, err
+	}
+
+	principal := &auth.Principal{
+		AccountId:  key.AccountId.String,
+		Operator:   key.OwnerType == models.APIKeyOwnerOperator,
+		Scopes:     key.Scopes,
+		APIKeyId:   key.ID,
+		MerchantId: key.MerchantId.String,
+	}
+
+	// Owners are looked up within the merchant of the key, so a key can never
+	// act for an account or operator of another merchant.
+	scoped := tenant.WithAllMerchants(ctx)
+	if key.MerchantId.Valid {
+		scoped = tenant.WithMerchant(ctx, key.MerchantId.String)
+	}
+
+	// Keys tied to an account stop working once it is closed or deleted.
+	if key.AccountId.Valid {
+		owner, err := a.accountService.GetAccountById(scoped, key.AccountId.String)
+		if err != nil {
+			return nil, err
+		}
+		if owner == nil || owner.IsClosed() {
+			return nil, auth.ErrRevokedCredentials
+		}
+	}
+
+	if !key.OperatorId.Valid {
+		return principal, nil
+	}
+
+	// Keys tied to an operator act with their role: the key can narrow what
+	// the role grants, never widen it.
+	owner, err := a.operatorService.GetOperatorById(scoped, key.OperatorId.String)
+	if err != nil {
+		return nil, err
+	}
+	if owner == nil || !owner.Active {
+		return nil, auth.ErrRevokedCredentials
+	}
+	permissions, err := a.operatorService.GetPermissions(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	principal.OperatorId = owner.ID
+	principal.Role = owner.Role
+	principal.Scopes = effectiveScopes(key.Scopes, permissions)
+	return principal, nil
+}
+
+// effectiveScopes intersects the scopes of a key with the permissions of its
+// operator's role, admin on either side standing for everything.
+func effectiveScopes(keyScopes, permissions []string) []string {
+	if contains(keyScopes, models.ScopeAdmin) {
+		return permissions
+	}
+	if contains(permissions, models.ScopeAdmin) {
+		return keyScopes
+	}
+
+	var scopes []string
+	for _, scope := range keyScopes {
+		if contains(permissions, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
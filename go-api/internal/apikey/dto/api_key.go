@@ -0,0 +1,40 @@
+package dto
+
+import "payment-gateway/go-api/internal/models"
+
+type CreateAPIKeyRequest struct {
+	// @Description Human readable label for the key.
+	// @Example checkout backend
+	Name string `json:"name" validate:"required,min=1,max=100"`
+
+	// @Description Who the key acts for.
+	// @Enum ACCOUNT,OPERATOR
+	// @Example ACCOUNT
+	OwnerType string `json:"owner_type" validate:"required,oneof=ACCOUNT OPERATOR"`
+
+	// @Description Account the key is tied to. Required for ACCOUNT keys.
+	// @Format uuid
+	AccountId *string `json:"account_id" validate:"omitempty,uuid"`
+
+	// @Description Operator the key acts for. Only for OPERATOR keys; without it the key is not tied to a person and acts with its scopes alone.
+	// @Format uuid
+	OperatorId *string `json:"operator_id" validate:"omitempty,uuid"`
+
+	// @Description Merchant of an OPERATOR key without an operator_id. Keys of accounts and operators belong to their owner's merchant. When omitted the key belongs to the caller's merchant, or reaches every merchant when created by a platform operator.
+	// @Format uuid
+	MerchantId *string `json:"merchant_id" validate:"omitempty,uuid"`
+
+	// @Description Scopes granted to the key. Operator scopes (admin, transactions:refund, accounts:freeze, accounts:close, operators:manage, audit:read) are only allowed for OPERATOR keys.
+	// @Example ["transactions:write","cards:read"]
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,required"`
+}
+
+// APIKeySecretResponse carries the plain key. It is only returned when a key is
+// created or rotated and cannot be retrieved afterwards.
+type APIKeySecretResponse struct {
+	*models.APIKey
+
+	// @Description The API key. Send it as "Authorization: Bearer <key>". Shown only once.
+	// @Example pgk_3f9a1c2e_6r0n8rQ4b1Xy5mZ3cV7tK2pL9wD4hS0aE1fG6jU8iO
+	Key string `json:"key"`
+}
@@ -0,0 +1,19 @@
+package apikey
+
+import "errors"
+
+var (
+	ErrMissingAPIKey           = errors.New("missing api key")
+	ErrInvalidAPIKey           = errors.New("invalid api key")
+	ErrAPIKeyRevoked           = errors.New("api key has been revoked")
+	ErrAPIKeyNotFound          = errors.New("api key not found")
+	ErrAccountNotFound         = errors.New("account not found")
+	ErrInvalidScope            = errors.New("invalid scope")
+	ErrInvalidOwner            = errors.New("account_id is required for ACCOUNT keys and not allowed for OPERATOR keys")
+	ErrOperatorScopeForAccount = errors.New("operator scopes can only be granted to OPERATOR keys")
+	ErrOperatorForAccountKey   = errors.New("operator_id is only allowed for OPERATOR keys")
+	ErrOperatorNotFound        = errors.New("operator not found")
+	ErrOperatorInactive        = errors.New("operator is inactive")
+	ErrMerchantForOwnedKey     = errors.New("merchant_id is only allowed for OPERATOR keys without an operator_id")
+	ErrInvalidBootstrapAPIKey  = errors.New("bootstrap api key is not a valid api key")
+)
@@ -0,0 +1,182 @@
+package apikey
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"payment-gateway/go-api/internal/api"
+	"payment-gateway/go-api/internal/apikey/dto"
+	"payment-gateway/go-api/internal/i18n"
+	"payment-gateway/go-api/internal/merchant"
+	"payment-gateway/go-api/internal/models"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+type APIKeyHandler struct {
+	service  APIKeyService
+	validate *validator.Validate
+}
+
+func NewAPIKeyHandler(service APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		service:  service,
+		validate: validator.New(),
+	}
+}
+
+// @Summary Create an API key
+// @Description Creates a key tied to an account or to an operator. Keys of an operator with an operator_id act with the permissions of the operator's role, narrowed to the key scopes. Keys belong to the merchant of their owner; platform operators create a merchant's first key with merchant_id. The key is returned once; only its hash is stored. Requires the admin scope.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param key body dto.CreateAPIKeyRequest true "Key owner and scopes"
+// @Success 201 {object} dto.APIKeySecretResponse
+// @Failure 400 {object} api.APIError "Invalid request body, owner or scope"
+// @Failure 401 {object} api.APIError "Missing or invalid API key"
+// @Failure 403 {object} api.APIError "API key lacks the admin scope"
+// @Failure 404 {object} api.APIError "Account, operator or merchant not found"
+// @Failure 409 {object} api.APIError "Operator is inactive"
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Security BearerAuth
+// @Router /api-keys [post]
+func (h *APIKeyHandler) CreateKey(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+
+	var req dto.CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidRequestBody))
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		api.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	key, err := h.service.CreateKey(r.Context(), req)
+	if err != nil {
+		writeAPIKeyError(w, lang, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(key)
+}
+
+// @Summary List API keys
+// @Description Lists API keys, newest first, including revoked ones. Secrets are never returned. Requires the admin scope.
+// @Tags api-keys
+// @Produce json
+// @Param account_id query string false "Only keys of this account"
+// @Success 200 {array} models.APIKey
+// @Failure 401 {object} api.APIError "Missing or invalid API key"
+// @Failure 403 {object} api.APIError "API key lacks the admin scope"
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Security BearerAuth
+// @Router /api-keys [get]
+func (h *APIKeyHandler) ListKeys(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+
+	accountId := r.URL.Query().Get("account_id")
+	if accountId != "" {
+		if err := h.validate.Var(accountId, "uuid"); err != nil {
+			api.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	keys, err := h.service.ListKeys(r.Context(), accountId)
+	if err != nil {
+		writeAPIKeyError(w, lang, err)
+		return
+	}
+	if keys == nil {
+		keys = make([]*models.APIKey, 0)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(keys)
+}
+
+// @Summary Rotate an API key
+// @Description Issues a new secret for the key, keeping its id, owner and scopes. The previous secret stops working immediately. Requires the admin scope.
+// @Tags api-keys
+// @Produce json
+// @Param keyId path string true "API key ID"
+// @Success 200 {object} dto.APIKeySecretResponse
+// @Failure 401 {object} api.APIError "Missing or invalid API key"
+// @Failure 403 {object} api.APIError "API key lacks the admin scope"
+// @Failure 404 {object} api.APIError "API key not found"
+// @Failure 409 {object} api.APIError "API key has been revoked"
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Security BearerAuth
+// @Router /api-keys/{keyId}/rotate [post]
+func (h *APIKeyHandler) RotateKey(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+
+	key, err := h.service.RotateKey(r.Context(), mux.Vars(r)["keyId"])
+	if err != nil {
+		writeAPIKeyError(w, lang, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(key)
+}
+
+// @Summary Revoke an API key
+// @Description Revokes the key. Requests made with it are rejected from then on. Requires the admin scope.
+// @Tags api-keys
+// @Produce json
+// @Param keyId path string true "API key ID"
+// @Success 200 {object} models.APIKey
+// @Failure 401 {object} api.APIError "Missing or invalid API key"
+// @Failure 403 {object} api.APIError "API key lacks the admin scope"
+// @Failure 404 {object} api.APIError "API key not found"
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Security BearerAuth
+// @Router /api-keys/{keyId} [delete]
+func (h *APIKeyHandler) RevokeKey(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+
+	key, err := h.service.RevokeKey(r.Context(), mux.Vars(r)["keyId"])
+	if err != nil {
+		writeAPIKeyError(w, lang, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(key)
+}
+
+func writeAPIKeyError(w http.ResponseWriter, lang string, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidScope):
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidAPIKeyScope))
+	case errors.Is(err, ErrInvalidOwner):
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidAPIKeyOwner))
+	case errors.Is(err, ErrOperatorScopeForAccount):
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorOperatorScopeForAccountKey))
+	case errors.Is(err, ErrOperatorForAccountKey):
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorOperatorForAccountKey))
+	case errors.Is(err, ErrMerchantForOwnedKey):
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorMerchantForOwnedKey))
+	case errors.Is(err, merchant.ErrMerchantNotFound):
+		api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorMerchantNotFound))
+	case errors.Is(err, ErrAccountNotFound):
+		api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorAccountNotFound))
+	case errors.Is(err, ErrOperatorNotFound):
+		api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorOperatorNotFound))
+	case errors.Is(err, ErrOperatorInactive):
+		api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorOperatorInactive))
+	case errors.Is(err, ErrAPIKeyNotFound):
+		api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorAPIKeyNotFound))
+	case errors.Is(err, ErrAPIKeyRevoked):
+		api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorAPIKeyRevoked))
+	default:
+		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorInternalServerError))
+	}
+}
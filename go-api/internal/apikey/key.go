@@ -0,0 +1,46 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// Keys look like pgk_<8 chars>_<secret>. The first 12 characters are the
+// prefix, stored in clear to find the key and to show it in listings.
+const (
+	keyMarker    = "pgk_"
+	prefixLength = len(keyMarker) + 8
+	minKeyLength = prefixLength + 1 + 16
+)
+
+// generateKey returns a new key and its prefix.
+func generateKey() (key, prefix string, err error) {
+	id := make([]byte, 4)
+	if _, err := rand.Read(id); err != nil {
+		return "", "", err
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+
+	prefix = keyMarker + hex.EncodeToString(id)
+	key = prefix + "_" + base64.RawURLEncoding.EncodeToString(secret)
+	return key, prefix, nil
+}
+
+// parseKey returns the prefix of key, or false if key is not shaped like one.
+func parseKey(key string) (string, bool) {
+	if !strings.HasPrefix(key, keyMarker) || len(key) < minKeyLength || key[prefixLength] != '_' {
+		return "", false
+	}
+	return key[:prefixLength], true
+}
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
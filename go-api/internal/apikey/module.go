@@ -0,0 +1,29 @@
+package apikey
+
+import (
+	"payment-gateway/go-api/internal/account"
+	"payment-gateway/go-api/internal/merchant"
+	"payment-gateway/go-api/internal/operator"
+	"payment-gateway/go-api/internal/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type Module struct {
+	Handler       *APIKeyHandler
+	Authenticator *Authenticator
+	Service       APIKeyService
+}
+
+func NewModule(db *sqlx.DB, accountService account.AccountService, operatorService operator.OperatorService, merchantService merchant.MerchantService) *Module {
+	repo := repository.NewAPIKeyRepository(db)
+	service := NewAPIKeyService(repo, accountService, operatorService, merchantService)
+	handler := NewAPIKeyHandler(service)
+	authenticator := NewAuthenticator(service, accountService, operatorService)
+
+	return &Module{
+		Handler:       handler,
+		Authenticator: authenticator,
+		Service:       service,
+	}
+}
@@ -0,0 +1,242 @@
+package apikey
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"fmt"
+	"log"
+	"payment-gateway/go-api/internal/account"
+	"payment-gateway/go-api/internal/apikey/dto"
+	"payment-gateway/go-api/internal/merchant"
+	"payment-gateway/go-api/internal/models"
+	"payment-gateway/go-api/internal/operator"
+	"payment-gateway/go-api/internal/repository"
+	"payment-gateway/go-api/internal/tenant"
+)
+
+type APIKeyService interface {
+	CreateKey(ctx context.Context, req dto.CreateAPIKeyRequest) (*dto.APIKeySecretResponse, error)
+	ListKeys(ctx context.Context, accountId string) ([]*models.APIKey, error)
+	RotateKey(ctx context.Context, id string) (*dto.APIKeySecretResponse, error)
+	RevokeKey(ctx context.Context, id string) (*models.APIKey, error)
+	Authenticate(ctx context.Context, key string) (*models.APIKey, error)
+	EnsureBootstrapKey(ctx context.Context, key string) error
+}
+
+type apiKeyServiceImpl struct {
+	repo            repository.APIKeyRepository
+	accountService  account.AccountService
+	operatorService operator.OperatorService
+	merchantService merchant.MerchantService
+}
+
+func NewAPIKeyService(repo repository.APIKeyRepository, accountService account.AccountService, operatorService operator.OperatorService, merchantService merchant.MerchantService) *apiKeyServiceImpl {
+	return &apiKeyServiceImpl{repo: repo, accountService: accountService, operatorService: operatorService, merchantService: merchantService}
+}
+
+func (s *apiKeyServiceImpl) CreateKey(ctx context.Context, req dto.CreateAPIKeyRequest) (*dto.APIKeySecretResponse, error) {
+	for _, scope := range req.Scopes {
+		if !models.IsScope(scope) {
+			return nil, ErrInvalidScope
+		}
+	}
+
+	key := &models.APIKey{
+		OwnerType: req.OwnerType,
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+	}
+
+	switch req.OwnerType {
+	case models.APIKeyOwnerAccount:
+		if req.AccountId == nil {
+			return nil, ErrInvalidOwner
+		}
+		if req.OperatorId != nil {
+			return nil, ErrOperatorForAccountKey
+		}
+		if req.MerchantId != nil {
+			return nil, ErrMerchantForOwnedKey
+		}
+		for _, scope := range req.Scopes {
+			if models.IsOperatorScope(scope) {
+				return nil, ErrOperatorScopeForAccount
+			}
+		}
+		owner, err := s.accountService.GetAccountById(ctx, *req.AccountId)
+		if err != nil {
+			return nil, err
+		}
+		if owner == nil || owner.DeletedAt != nil {
+			return nil, ErrAccountNotFound
+		}
+		key.AccountId = sql.NullString{String: owner.ID, Valid: true}
+		key.MerchantId = sql.NullString{String: owner.MerchantId, Valid: true}
+	case models.APIKeyOwnerOperator:
+		if req.AccountId != nil {
+			return nil, ErrInvalidOwner
+		}
+		if req.OperatorId != nil {
+			if req.MerchantId != nil {
+				return nil, ErrMerchantForOwnedKey
+			}
+			owner, err := s.operatorService.GetOperatorById(ctx, *req.OperatorId)
+			if err != nil {
+				return nil, err
+			}
+			if owner == nil {
+				return nil, ErrOperatorNotFound
+			}
+			if !owner.Active {
+				return nil, ErrOperatorInactive
+			}
+			key.OperatorId = sql.NullString{String: owner.ID, Valid: true}
+			key.MerchantId = owner.MerchantId
+		} else {
+			merchantId, err := s.keyMerchant(ctx, req.MerchantId)
+			if err != nil {
+				return nil, err
+			}
+			key.MerchantId = merchantId
+		}
+	}
+
+	plain, prefix, err := generateKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate api key: %w", err)
+	}
+	key.Prefix = prefix
+	key.KeyHash = hashKey(plain)
+
+	if err := s.repo.CreateAPIKey(ctx, key); err != nil {
+		return nil, err
+	}
+
+	return &dto.APIKeySecretResponse{APIKey: key, Key: plain}, nil
+}
+
+// keyMerchant is the merchant of an OPERATOR key not tied to an operator: the
+// one named in the request, which must be within the caller's reach, or else
+// the caller's own. Platform callers naming none create a platform key.
+func (s *apiKeyServiceImpl) keyMerchant(ctx context.Context, merchantId *string) (sql.NullString, error) {
+	if merchantId == nil {
+		own, _ := tenant.MerchantId(ctx)
+		return sql.NullString{String: own, Valid: own != ""}, nil
+	}
+
+	scoped, ok := tenant.Narrow(ctx, *merchantId)
+	if !ok {
+		return sql.NullString{}, merchant.ErrMerchantNotFound
+	}
+	if _, err := s.merchantService.GetMerchantById(scoped, *merchantId); err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: *merchantId, Valid: true}, nil
+}
+
+func (s *apiKeyServiceImpl) ListKeys(ctx context.Context, accountId string) ([]*models.APIKey, error) {
+	return s.repo.ListAPIKeys(ctx, accountId)
+}
+
+// RotateKey issues a new secret for an active key, keeping its id, owner and
+// scopes. The previous secret is rejected from then on.
+func (s *apiKeyServiceImpl) RotateKey(ctx context.Context, id string) (*dto.APIKeySecretResponse, error) {
+	existing, err := s.repo.GetAPIKeyById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrAPIKeyNotFound
+	}
+	if existing.RevokedAt != nil {
+		return nil, ErrAPIKeyRevoked
+	}
+
+	plain, prefix, err := generateKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	key, err := s.repo.RotateAPIKey(ctx, id, prefix, hashKey(plain))
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		// Revoked between the read above and the update.
+		return nil, ErrAPIKeyRevoked
+	}
+
+	return &dto.APIKeySecretResponse{APIKey: key, Key: plain}, nil
+}
+
+func (s *apiKeyServiceImpl) RevokeKey(ctx context.Context, id string) (*models.APIKey, error) {
+	key, err := s.repo.RevokeAPIKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, ErrAPIKeyNotFound
+	}
+	return key, nil
+}
+
+// Authenticate resolves the key sent by a client. Unknown and malformed keys
+// both yield ErrInvalidAPIKey so callers cannot probe for prefixes.
+func (s *apiKeyServiceImpl) Authenticate(ctx context.Context, plain string) (*models.APIKey, error) {
+	prefix, ok := parseKey(plain)
+	if !ok {
+		return nil, ErrInvalidAPIKey
+	}
+
+	key, err := s.repo.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil || subtle.ConstantTimeCompare([]byte(hashKey(plain)), []byte(key.KeyHash)) != 1 {
+		return nil, ErrInvalidAPIKey
+	}
+	if key.RevokedAt != nil {
+		return nil, ErrAPIKeyRevoked
+	}
+
+	if err := s.repo.TouchAPIKey(ctx, key.ID); err != nil {
+		log.Printf("apikey: %v", err)
+	}
+
+	return key, nil
+}
+
+// EnsureBootstrapKey stores key as an OPERATOR key with the admin scope unless
+// a key with its prefix already exists. It lets a fresh install create its
+// first keys through the API. The key belongs to no merchant and reaches them
+// all, so ctx must reach every merchant.
+func (s *apiKeyServiceImpl) EnsureBootstrapKey(ctx context.Context, plain string) error {
+	if plain == "" {
+		return nil
+	}
+
+	prefix, ok := parseKey(plain)
+	if !ok {
+		return ErrInvalidBootstrapAPIKey
+	}
+
+	existing, err := s.repo.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		if existing.KeyHash != hashKey(plain) {
+			log.Printf("apikey: bootstrap key prefix %s is taken by another key; bootstrap key not installed", prefix)
+		}
+		return nil
+	}
+
+	return s.repo.CreateAPIKey(ctx, &models.APIKey{
+		OwnerType: models.APIKeyOwnerOperator,
+		Name:      "bootstrap admin",
+		Prefix:    prefix,
+		KeyHash:   hashKey(plain),
+		Scopes:    []string{models.ScopeAdmin},
+	})
+}
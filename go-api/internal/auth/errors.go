@@ -0,0 +1,10 @@
+package auth
+
+import "errors"
+
+// Authenticators return these so the middleware can answer with a 401
+// without knowing how each kind of credential is checked.
+var (
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrRevokedCredentials = errors.New("credentials have been revoked")
+)
@@ -0,0 +1,246 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"payment-gateway/go-api/internal/api"
+	"payment-gateway/go-api/internal/i18n"
+	"payment-gateway/go-api/internal/models"
+	"payment-gateway/go-api/internal/tenant"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	bearerPrefix = "Bearer "
+	// apiKeyMarker starts every API key; any other bearer token is taken as a
+	// session access token.
+	apiKeyMarker = "pgk_"
+)
+
+// Authenticator resolves a bearer token into the principal it belongs to.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*Principal, error)
+}
+
+// Auditor records requests made by operators.
+type Auditor interface {
+	Record(ctx context.Context, entry *models.AuditLog) error
+}
+
+// Middleware authenticates requests sent with "Authorization: Bearer <token>",
+// where the token is an API key or a session access token, checks the scope
+// each route requires, limits the request to the caller's merchant and records
+// every request made by an operator.
+type Middleware struct {
+	apiKeys  Authenticator
+	sessions Authenticator
+	auditor  Auditor
+}
+
+func NewMiddleware(apiKeys, sessions Authenticator, auditor Auditor) *Middleware {
+	return &Middleware{apiKeys: apiKeys, sessions: sessions, auditor: auditor}
+}
+
+// Require rejects requests without credentials granting scope. Account-bound
+// callers are also rejected when the route has an {accountId} that is not
+// their account.
+func (m *Middleware) Require(scope string, next http.HandlerFunc) http.HandlerFunc {
+	return m.RequireFor(scope, scope, next)
+}
+
+// RequireFor is Require for routes open to account holders and operators
+// under different scopes, such as freezing an account: holders may freeze
+// their own account with accounts:write, operators need accounts:freeze.
+func (m *Middleware) RequireFor(accountScope, operatorScope string, next http.HandlerFunc) http.HandlerFunc {
+	return m.require(func(principal *Principal) bool {
+		if principal.Operator {
+			return principal.HasScope(operatorScope)
+		}
+		return principal.HasScope(accountScope)
+	}, next)
+}
+
+// RequireAny is Require for routes where any of scopes lets the caller in and
+// the handler decides, from the request, which one it actually needs.
+func (m *Middleware) RequireAny(scopes []string, next http.HandlerFunc) http.HandlerFunc {
+	return m.require(func(principal *Principal) bool {
+		for _, scope := range scopes {
+			if principal.HasScope(scope) {
+				return true
+			}
+		}
+		return false
+	}, next)
+}
+
+func (m *Middleware) require(permitted func(*Principal) bool, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := m.authenticate(w, r)
+		if !ok {
+			return
+		}
+
+		// Operator requests are recorded whatever their outcome, denials
+		// included.
+		if principal.Operator {
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			defer m.audit(r, principal, recorder)
+			w = recorder
+		}
+
+		if !permitted(principal) {
+			writeScopeError(w, r, principal)
+			return
+		}
+
+		if accountId, ok := mux.Vars(r)["accountId"]; ok && !principal.CanAccess(accountId) {
+			lang := i18n.GetLangFromHeader(r)
+			api.WriteError(w, http.StatusForbidden, i18n.GetErrorMessage(lang, i18n.ErrorAccountAccessDenied))
+			return
+		}
+
+		ctx := withMerchantScope(WithPrincipal(r.Context(), principal), principal)
+		next(w, r.WithContext(ctx))
+	}
+}
+
+// withMerchantScope limits ctx to the merchant of principal. Only operators
+// not bound to a merchant reach every merchant; any other caller without a
+// merchant gets no scope, so the repositories refuse to serve it.
+func withMerchantScope(ctx context.Context, principal *Principal) context.Context {
+	switch {
+	case principal.MerchantId != "":
+		return tenant.WithMerchant(ctx, principal.MerchantId)
+	case principal.Operator:
+		return tenant.WithAllMerchants(ctx)
+	default:
+		return ctx
+	}
+}
+
+// RequireOperator is Require for routes that span accounts, such as listing
+// every account, which account-bound callers may never call.
+func (m *Middleware) RequireOperator(scope string, next http.HandlerFunc) http.HandlerFunc {
+	return m.Require(scope, func(w http.ResponseWriter, r *http.Request) {
+		if !IsOperator(r.Context()) {
+			lang := i18n.GetLangFromHeader(r)
+			api.WriteError(w, http.StatusForbidden, i18n.GetErrorMessage(lang, i18n.ErrorOperatorKeyRequired))
+			return
+		}
+		next(w, r)
+	})
+}
+
+func (m *Middleware) authenticate(w http.ResponseWriter, r *http.Request) (*Principal, bool) {
+	lang := i18n.GetLangFromHeader(r)
+
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, bearerPrefix) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		api.WriteError(w, http.StatusUnauthorized, i18n.GetErrorMessage(lang, i18n.ErrorMissingCredentials))
+		return nil, false
+	}
+	token := strings.TrimSpace(strings.TrimPrefix(header, bearerPrefix))
+
+	authenticator, invalidKey, revokedKey := m.sessions, i18n.ErrorInvalidAccessToken, i18n.ErrorInvalidAccessToken
+	if strings.HasPrefix(token, apiKeyMarker) {
+		authenticator, invalidKey, revokedKey = m.apiKeys, i18n.ErrorInvalidAPIKey, i18n.ErrorAPIKeyRevoked
+	}
+
+	principal, err := authenticator.Authenticate(r.Context(), token)
+	switch {
+	case errors.Is(err, ErrInvalidCredentials):
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		api.WriteError(w, http.StatusUnauthorized, i18n.GetErrorMessage(lang, invalidKey))
+		return nil, false
+	case errors.Is(err, ErrRevokedCredentials):
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		api.WriteError(w, http.StatusUnauthorized, i18n.GetErrorMessage(lang, revokedKey))
+		return nil, false
+	case err != nil:
+		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorInternalServerError))
+		return nil, false
+	}
+
+	return principal, true
+}
+
+// WriteScopeError answers a request whose caller lacks a scope checked by the
+// handler itself, after the middleware let it in.
+func WriteScopeError(w http.ResponseWriter, r *http.Request) {
+	writeScopeError(w, r, FromContext(r.Context()))
+}
+
+// writeScopeError answers a request whose credentials lack the route's scope.
+// Operators with a role are told their role forbids it rather than their key.
+func writeScopeError(w http.ResponseWriter, r *http.Request, principal *Principal) {
+	lang := i18n.GetLangFromHeader(r)
+	if principal != nil && principal.Role != "" {
+		api.WriteError(w, http.StatusForbidden, i18n.GetErrorMessage(lang, i18n.ErrorPermissionDenied))
+		return
+	}
+	api.WriteError(w, http.StatusForbidden, i18n.GetErrorMessage(lang, i18n.ErrorInsufficientScope))
+}
+
+// audit records the request once it has been answered. It runs after the
+// handler, so it uses a context that outlives a client disconnect, and a
+// failure only gets logged: the response is already on its way.
+func (m *Middleware) audit(r *http.Request, principal *Principal, recorder *statusRecorder) {
+	if m.auditor == nil {
+		return
+	}
+
+	entry := &models.AuditLog{
+		MerchantId: nullString(principal.MerchantId),
+		OperatorId: nullString(principal.OperatorId),
+		APIKeyId:   nullString(principal.APIKeyId),
+		Role:       nullString(principal.Role),
+		Method:     r.Method,
+		Route:      r.URL.Path,
+		Path:       r.URL.RequestURI(),
+		AccountId:  nullString(mux.Vars(r)["accountId"]),
+		StatusCode: recorder.status,
+	}
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			entry.Route = template
+		}
+	}
+
+	if err := m.auditor.Record(context.WithoutCancel(r.Context()), entry); err != nil {
+		log.Printf("auth: failed to record audit log for %s %s: %v", r.Method, entry.Path, err)
+	}
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+// statusRecorder remembers the status code written through it.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	if !s.wroteHeader {
+		s.status = status
+		s.wroteHeader = true
+	}
+	s.ResponseWriter.WriteHeader(status)
+}
+
+func (s *statusRecorder) Write(b []byte) (int, error) {
+	s.wroteHeader = true
+	return s.ResponseWriter.Write(b)
+}
+
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+	"payment-gateway/go-api/internal/models"
+)
+
+// Principal is the authenticated caller of a request, whether it came with an
+// API key or a session access token.
+type Principal struct {
+	// AccountId is the account the caller acts for. Empty for operators.
+	AccountId string
+	Operator  bool
+	Scopes    []string
+
+	// OperatorId and Role identify the operator behind an operator key. Both
+	// are empty for operator keys not tied to a person, such as the bootstrap
+	// key.
+	OperatorId string
+	Role       string
+
+	// APIKeyId is the key the request was made with. Empty for sessions.
+	APIKeyId string
+
+	// MerchantId is the merchant the credentials belong to. Empty only for
+	// platform operators, who reach every merchant.
+	MerchantId string
+}
+
+// HasScope reports whether the principal was granted scope, directly or
+// through admin.
+func (p *Principal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope || s == models.ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// CallerId identifies the credentials behind the principal: the API key the
+// request was made with, or the account of a session.
+func (p *Principal) CallerId() string {
+	if p.APIKeyId != "" {
+		return "key:" + p.APIKeyId
+	}
+	return "account:" + p.AccountId
+}
+
+// CanAccess reports whether the principal may act on accountId.
+func (p *Principal) CanAccess(accountId string) bool {
+	return p.Operator || p.AccountId == accountId
+}
+
+type contextKey struct{}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, principal)
+}
+
+// FromContext returns the caller of the request behind ctx, or nil.
+func FromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(contextKey{}).(*Principal)
+	return principal
+}
+
+// AccountScope returns the account an account-bound caller is tied to. It
+// returns false for operators and unauthenticated contexts.
+func AccountScope(ctx context.Context) (string, bool) {
+	principal := FromContext(ctx)
+	if principal == nil || principal.Operator {
+		return "", false
+	}
+	return principal.AccountId, true
+}
+
+// IsOperator reports whether the caller behind ctx authenticated as an
+// operator. What the operator may do is still decided by its scopes.
+func IsOperator(ctx context.Context) bool {
+	principal := FromContext(ctx)
+	return principal != nil && principal.Operator
+}
+
+// CallerId returns the CallerId of the caller behind ctx, or "" for
+// unauthenticated contexts.
+func CallerId(ctx context.Context) string {
+	principal := FromContext(ctx)
+	if principal == nil {
+		return ""
+	}
+	return principal.CallerId()
+}
+
+// HasScope reports whether the caller behind ctx was granted scope.
+func HasScope(ctx context.Context, scope string) bool {
+	principal := FromContext(ctx)
+	return principal != nil && principal.HasScope(scope)
+}
+
+// CanAccessAccount reports whether the caller behind ctx may act on accountId.
+// Handlers use it for accounts named in the body or found through another id.
+func CanAccessAccount(ctx context.Context, accountId string) bool {
+	principal := FromContext(ctx)
+	return principal != nil && principal.CanAccess(accountId)
+}
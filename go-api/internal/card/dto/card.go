@@ -1,17 +1,185 @@
 package dto
 
+import (
+	"database/sql"
+
+	"payment-gateway/go-api/internal/models"
+)
+
 // @Description Request body for creating a new card
 type CreateCardRequest struct {
 	// @Description Account ID to associate the new card (UUID)
 	// @Example e252f5dd-ded2-4a30-a4a5-6e2940008d54
 	AccountId string `json:"account_id" validate:"required,uuid4"`
+
+	// @Description Network of the new card. Defaults to the network of the first configured BIN range.
+	// @Enum VISA,MASTERCARD,ELO,AMEX
+	// @Example VISA
+	Brand *string `json:"brand" validate:"omitempty,oneof=VISA MASTERCARD ELO AMEX"`
+
+	// @Description Kind of card. SINGLE_USE cards are cancelled once their first purchase is approved; MERCHANT_LOCKED cards need locked_acceptor_id and amount_limit_cents. Defaults to STANDARD.
+	// @Enum STANDARD,SINGLE_USE,MERCHANT_LOCKED
+	// @Example SINGLE_USE
+	Kind *string `json:"kind" validate:"omitempty,oneof=STANDARD SINGLE_USE MERCHANT_LOCKED"`
+
+	// @Description Merchant (card acceptor) the card may buy from. Required for MERCHANT_LOCKED, optional for SINGLE_USE, not allowed on STANDARD cards.
+	// @Example ACQ-000123
+	LockedAcceptorId *string `json:"locked_acceptor_id" validate:"omitempty,min=1,max=64"`
+
+	// @Description Most the card may spend on purchases over its lifetime, in cents. Required for MERCHANT_LOCKED, optional for SINGLE_USE, not allowed on STANDARD cards.
+	// @Example 250000
+	AmountLimitCents *int64 `json:"amount_limit_cents" validate:"omitempty,gt=0"`
+}
+
+type CardStatusRequest struct {
+	// @Description Why the status is being changed. Stored on the card.
+	// @Example Card reported lost
+	Reason *string `json:"reason" validate:"omitempty,max=255"`
 }
 
-// @Description Response when a card is created
+// @Description A payment card. Nullable columns are plain values or null.
 type CardResponse struct {
-	ID             string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	AccountId      string `json:"account_id" example:"e252f5dd-ded2-4a30-a4a5-6e2940008d54"`
-	CardToken      string `json:"card_token" example:"5b7c16af7278094cd14bd041079111ed00fa832c8a460d8e3f40156408d99475"`
-	LastFourDigits string `json:"last_four_digits" example:"8995"`
-	CreatedAt      string `json:"created_at" example:"2025-09-22T19:15:24.526505Z"`
+	ID                   string  `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	AccountId            string  `json:"account_id" example:"e252f5dd-ded2-4a30-a4a5-6e2940008d54"`
+	CardToken            string  `json:"card_token" example:"tok_Xb3kQ9vN2mR7tL1pW8cF5hJ0aZ4yE6sD"`
+	LastFourDigits       string  `json:"last_four_digits" example:"8995"`
+	Brand                string  `json:"brand" enums:"VISA,MASTERCARD,ELO,AMEX,UNKNOWN" example:"VISA"`
+	ExpiryMonth          int     `json:"expiry_month" example:"9"`
+	ExpiryYear           int     `json:"expiry_year" example:"2030"`
+	Status               string  `json:"status" enums:"ACTIVE,BLOCKED,CANCELLED,EXPIRED" example:"ACTIVE"`
+	StatusReason         *string `json:"status_reason" example:"Card reported lost"`
+	ReplacesCardId       *string `json:"replaces_card_id" example:"16ecac04-9e45-4a5b-b7d4-d6c1c66bafd6"`
+	Kind                 string  `json:"kind" enums:"STANDARD,SINGLE_USE,MERCHANT_LOCKED" example:"STANDARD"`
+	LockedAcceptorId     *string `json:"locked_acceptor_id" example:"ACQ-000123"`
+	AmountLimitCents     *int64  `json:"amount_limit_cents" example:"250000"`
+	RemainingUses        *int64  `json:"remaining_uses" example:"1"`
+	RemainingAmountCents *int64  `json:"remaining_amount_cents" example:"180000"`
+	CreatedAt            string  `json:"created_at" example:"2025-09-22T19:15:24.526505Z"`
+	UpdatedAt            string  `json:"updated_at" example:"2025-09-22T19:15:24.526505Z"`
+}
+
+// NewCardResponse maps card to the shape returned by the API, turning its
+// nullable columns into pointers so they encode as values or null.
+func NewCardResponse(card *models.Card) CardResponse {
+	return CardResponse{
+		ID:                   card.ID,
+		AccountId:            card.AccountId,
+		CardToken:            card.CardToken,
+		LastFourDigits:       card.LastFourDigits,
+		Brand:                card.Brand,
+		ExpiryMonth:          card.ExpiryMonth,
+		ExpiryYear:           card.ExpiryYear,
+		Status:               card.Status,
+		StatusReason:         nullString(card.StatusReason),
+		ReplacesCardId:       nullString(card.ReplacesCardId),
+		Kind:                 card.Kind,
+		LockedAcceptorId:     nullString(card.LockedAcceptorId),
+		AmountLimitCents:     nullInt64(card.AmountLimitCents),
+		RemainingUses:        nullInt64(card.RemainingUses),
+		RemainingAmountCents: nullInt64(card.RemainingAmountCents),
+		CreatedAt:            card.CreatedAt,
+		UpdatedAt:            card.UpdatedAt,
+	}
+}
+
+// NewCardResponses maps every card with NewCardResponse.
+func NewCardResponses(cards []*models.Card) []CardResponse {
+	responses := make([]CardResponse, 0, len(cards))
+	for _, card := range cards {
+		responses = append(responses, NewCardResponse(card))
+	}
+	return responses
+}
+
+// @Description Spending controls of a card. A null or empty control does not restrict the card.
+type CardControlsResponse struct {
+	CardId                    string   `json:"card_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	MaxTransactionCents       *int64   `json:"max_transaction_cents" example:"50000"`
+	DailyLimitCents           *int64   `json:"daily_limit_cents" example:"100000"`
+	MonthlyLimitCents         *int64   `json:"monthly_limit_cents" example:"1000000"`
+	AllowedTransactionTypes   []string `json:"allowed_transaction_types" example:"PURCHASE"`
+	BlockedMerchantCategories []string `json:"blocked_merchant_categories" example:"7995"`
+	VelocityLimit             *int64   `json:"velocity_limit" example:"5"`
+	VelocityWindowSeconds     *int64   `json:"velocity_window_seconds" example:"3600"`
+	UpdatedAt                 string   `json:"updated_at" example:"2025-09-22T19:15:24.526505Z"`
+}
+
+// NewCardControlsResponse maps controls to the shape returned by the API. Its
+// lists are never null, so a card without controls gets empty ones.
+func NewCardControlsResponse(controls *models.CardControls) CardControlsResponse {
+	return CardControlsResponse{
+		CardId:                    controls.CardId,
+		MaxTransactionCents:       nullInt64(controls.MaxTransactionCents),
+		DailyLimitCents:           nullInt64(controls.DailyLimitCents),
+		MonthlyLimitCents:         nullInt64(controls.MonthlyLimitCents),
+		AllowedTransactionTypes:   append([]string{}, controls.AllowedTransactionTypes...),
+		BlockedMerchantCategories: append([]string{}, controls.BlockedMerchantCategories...),
+		VelocityLimit:             nullInt64(controls.VelocityLimit),
+		VelocityWindowSeconds:     nullInt64(controls.VelocityWindowSeconds),
+		UpdatedAt:                 controls.UpdatedAt,
+	}
+}
+
+func nullString(v sql.NullString) *string {
+	if !v.Valid {
+		return nil
+	}
+	return &v.String
+}
+
+func nullInt64(v sql.NullInt64) *int64 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Int64
+}
+
+// CardSecretResponse carries the card verification code. It is only returned
+// when a card is created: the code is never stored and cannot be retrieved
+// afterwards.
+type CardSecretResponse struct {
+	CardResponse
+
+	// @Description Card verification code. Shown only once.
+	// @Example 123
+	CVC string `json:"cvc"`
+}
+
+// @Description Full card number of a vaulted card
+type DetokenizedCardResponse struct {
+	CardId      string `json:"card_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	PAN         string `json:"pan" example:"4532110000000003"`
+	ExpiryMonth int    `json:"expiry_month" example:"9"`
+	ExpiryYear  int    `json:"expiry_year" example:"2030"`
+}
+
+// @Description Spending controls of a card. The request replaces every control; omitted or null controls are removed.
+type UpdateCardControlsRequest struct {
+	// @Description Largest amount a single PURCHASE or TRANSFER may have, in cents.
+	// @Example 50000
+	MaxTransactionCents *int64 `json:"max_transaction_cents" validate:"omitempty,gt=0"`
+
+	// @Description Most the card may spend on PURCHASE and TRANSFER per UTC day, in cents.
+	// @Example 100000
+	DailyLimitCents *int64 `json:"daily_limit_cents" validate:"omitempty,gt=0"`
+
+	// @Description Most the card may spend on PURCHASE and TRANSFER per UTC calendar month, in cents.
+	// @Example 1000000
+	MonthlyLimitCents *int64 `json:"monthly_limit_cents" validate:"omitempty,gt=0"`
+
+	// @Description Transaction types the card may be used for. Empty allows every type.
+	// @Example ["PURCHASE"]
+	AllowedTransactionTypes []string `json:"allowed_transaction_types" validate:"omitempty,dive,oneof=DEPOSIT PURCHASE REFUND TRANSFER"`
+
+	// @Description Merchant category codes (ISO 18245, four digits) the card may not be used at.
+	// @Example ["7995"]
+	BlockedMerchantCategories []string `json:"blocked_merchant_categories" validate:"omitempty,max=100,dive,len=4,numeric"`
+
+	// @Description Most transactions the card may make within velocity_window_seconds. Set together with velocity_window_seconds.
+	// @Example 5
+	VelocityLimit *int64 `json:"velocity_limit" validate:"required_with=VelocityWindowSeconds,omitempty,gt=0"`
+
+	// @Description Length of the velocity window, in seconds (at most 30 days).
+	// @Example 3600
+	VelocityWindowSeconds *int64 `json:"velocity_window_seconds" validate:"required_with=VelocityLimit,omitempty,gt=0,max=2592000"`
 }
@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"payment-gateway/go-api/internal/models"
+)
+
+func encodeCard(t *testing.T, card *models.Card) map[string]any {
+	t.Helper()
+	body, err := json.Marshal(NewCardResponse(card))
+	if err != nil {
+		t.Fatalf("failed to encode card: %v", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(body, &fields); err != nil {
+		t.Fatalf("failed to decode card: %v", err)
+	}
+	return fields
+}
+
+func TestNewCardResponseEncodesVirtualCardFieldsAsValues(t *testing.T) {
+	fields := encodeCard(t, &models.Card{
+		Kind:                 models.CardKindSingleUse,
+		Status:               models.CardStatusCancelled,
+		StatusReason:         sql.NullString{String: "Single-use card used", Valid: true},
+		ReplacesCardId:       sql.NullString{String: "16ecac04-9e45-4a5b-b7d4-d6c1c66bafd6", Valid: true},
+		LockedAcceptorId:     sql.NullString{String: "ACQ-000123", Valid: true},
+		AmountLimitCents:     sql.NullInt64{Int64: 250000, Valid: true},
+		RemainingUses:        sql.NullInt64{Int64: 0, Valid: true},
+		RemainingAmountCents: sql.NullInt64{Int64: 180000, Valid: true},
+	})
+
+	want := map[string]any{
+		"status_reason":          "Single-use card used",
+		"replaces_card_id":       "16ecac04-9e45-4a5b-b7d4-d6c1c66bafd6",
+		"locked_acceptor_id":     "ACQ-000123",
+		"amount_limit_cents":     float64(250000),
+		"remaining_uses":         float64(0),
+		"remaining_amount_cents": float64(180000),
+	}
+	for field, value := range want {
+		if fields[field] != value {
+			t.Errorf("%s = %#v, want %#v", field, fields[field], value)
+		}
+	}
+}
+
+func TestNewCardResponseEncodesMissingFieldsAsNull(t *testing.T) {
+	fields := encodeCard(t, &models.Card{Kind: models.CardKindStandard, Status: models.CardStatusActive})
+
+	for _, field := range []string{
+		"status_reason", "replaces_card_id", "locked_acceptor_id",
+		"amount_limit_cents", "remaining_uses", "remaining_amount_cents",
+	} {
+		value, ok := fields[field]
+		if !ok {
+			t.Errorf("%s is missing", field)
+		} else if value != nil {
+			t.Errorf("%s = %#v, want null", field, value)
+		}
+	}
+}
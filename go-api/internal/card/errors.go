@@ -0,0 +1,19 @@
+package card
+
+import "errors"
+
+var (
+	ErrAccountNotFound         = errors.New("account not found")
+	ErrAccountFrozen           = errors.New("account is frozen")
+	ErrAccountClosed           = errors.New("account is closed")
+	ErrBrandNotIssued          = errors.New("brand is not issued")
+	ErrCardNotVaulted          = errors.New("card number is not held in the vault")
+	ErrCardNotFound            = errors.New("card not found")
+	ErrCardBlocked             = errors.New("card is blocked")
+	ErrCardCancelled           = errors.New("card is cancelled")
+	ErrCardExpired             = errors.New("card is expired")
+	ErrInvalidStatusTransition = errors.New("card status transition is not allowed")
+	ErrCardAlreadyReplaced     = errors.New("card was already replaced")
+	ErrInvalidCardConstraints  = errors.New("card constraints do not match the card kind")
+	ErrCardNotReplaceable      = errors.New("virtual cards cannot be replaced")
+)
@@ -1,11 +1,16 @@
 package card
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"payment-gateway/go-api/internal/api"
+	"payment-gateway/go-api/internal/auth"
 	"payment-gateway/go-api/internal/card/dto"
 	"payment-gateway/go-api/internal/i18n"
+	"payment-gateway/go-api/internal/models"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gorilla/mux"
@@ -25,15 +30,17 @@ func NewCardHandler(service CardService) *CardHandler {
 
 // @ID create-card
 // @Summary Create a new card
-// @Description Creates a fictional card and associates it with an account.
+// @Description Issues a fictional card on an account. The card number is drawn from the configured BIN ranges of the requested brand and carries a valid Luhn check digit. The number is kept encrypted in the card vault behind an opaque card_token; the CVC is returned once and never stored. Virtual cards can be issued with kind: a SINGLE_USE card is cancelled once its first purchase is approved, a MERCHANT_LOCKED card only buys from locked_acceptor_id up to amount_limit_cents over its lifetime. Virtual cards only allow PURCHASE and REFUND transactions.
 // @Tags cards
 // @Accept json
 // @Produce json
 // @Param card body dto.CreateCardRequest true "Account ID"
-// @Success 201 {object} dto.CardResponse "Card created successfully"
-// @Failure 400 {object} api.APIError "Invalid request body or validation failed"
+// @Success 201 {object} dto.CardSecretResponse "Card created successfully"
+// @Failure 400 {object} api.APIError "Invalid request body, validation failed, brand not issued or constraints do not match the card kind"
 // @Failure 404 {object} api.APIError "Account not found"
+// @Failure 409 {object} api.APIError "Account is frozen or closed"
 // @Failure 500 {object} api.APIError "Failed to create card"
+// @Security BearerAuth
 // @Router /cards [post]
 func (h *CardHandler) CreateCard(w http.ResponseWriter, r *http.Request) {
 	lang := i18n.GetLangFromHeader(r)
@@ -50,9 +57,14 @@ func (h *CardHandler) CreateCard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	card, err := h.service.CreateCard(r.Context(), req.AccountId)
+	if !auth.CanAccessAccount(r.Context(), req.AccountId) {
+		api.WriteError(w, http.StatusForbidden, i18n.GetErrorMessage(lang, i18n.ErrorAccountAccessDenied))
+		return
+	}
+
+	card, err := h.service.CreateCard(r.Context(), req)
 	if err != nil {
-		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorFailedToCreateCard))
+		writeCreateCardError(w, lang, err)
 		return
 	}
 
@@ -71,6 +83,7 @@ func (h *CardHandler) CreateCard(w http.ResponseWriter, r *http.Request) {
 // @Failure 400 {object} api.APIError "Invalid account ID"
 // @Failure 404 {object} api.APIError "Account not found"
 // @Failure 500 {object} api.APIError "Failed to retrieve cards"
+// @Security BearerAuth
 // @Router /cards/{accountId} [get]
 func (h *CardHandler) GetAllCardsByAccountId(w http.ResponseWriter, r *http.Request) {
 	lang := i18n.GetLangFromHeader(r)
@@ -86,5 +99,332 @@ func (h *CardHandler) GetAllCardsByAccountId(w http.ResponseWriter, r *http.Requ
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(cards)
+	json.NewEncoder(w).Encode(dto.NewCardResponses(cards))
+}
+
+// @ID detokenize-card
+// @Summary Reveal a card number
+// @Description Decrypts the full number of a card from the card vault. Requires the cards:detokenize scope, which cards:read does not imply. Cards issued before the vault cannot be revealed.
+// @Tags cards
+// @Produce json
+// @Param cardId path string true "Card ID"
+// @Success 200 {object} dto.DetokenizedCardResponse
+// @Failure 400 {object} api.APIError "Invalid card ID"
+// @Failure 403 {object} api.APIError "Card belongs to another account"
+// @Failure 404 {object} api.APIError "Card not found"
+// @Failure 409 {object} api.APIError "Card number is not vaulted"
+// @Failure 500 {object} api.APIError "Failed to detokenize card"
+// @Security BearerAuth
+// @Router /cards/{cardId}/detokenize [post]
+func (h *CardHandler) DetokenizeCard(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+
+	card, ok := h.cardFromPath(w, r, lang)
+	if !ok {
+		return
+	}
+
+	detokenized, err := h.service.DetokenizeCard(r.Context(), card)
+	if err != nil {
+		if errors.Is(err, ErrCardNotVaulted) {
+			api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorCardNotVaulted))
+			return
+		}
+		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorFailedToDetokenizeCard))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(detokenized)
+}
+
+// @ID block-card
+// @Summary Block a card
+// @Description Moves an ACTIVE card to BLOCKED. Blocked cards cannot transact until they are unblocked.
+// @Tags cards
+// @Accept json
+// @Produce json
+// @Param cardId path string true "Card ID"
+// @Param reason body dto.CardStatusRequest false "Reason for the change"
+// @Success 200 {object} dto.CardResponse
+// @Failure 400 {object} api.APIError "Invalid card ID or request body"
+// @Failure 403 {object} api.APIError "Card belongs to another account"
+// @Failure 404 {object} api.APIError "Card not found"
+// @Failure 409 {object} api.APIError "Status transition not allowed"
+// @Security BearerAuth
+// @Router /cards/{cardId}/block [post]
+func (h *CardHandler) BlockCard(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.service.BlockCard)
+}
+
+// @ID unblock-card
+// @Summary Unblock a card
+// @Description Moves a BLOCKED card back to ACTIVE. Cards past their expiry month cannot be unblocked.
+// @Tags cards
+// @Accept json
+// @Produce json
+// @Param cardId path string true "Card ID"
+// @Param reason body dto.CardStatusRequest false "Reason for the change"
+// @Success 200 {object} dto.CardResponse
+// @Failure 400 {object} api.APIError "Invalid card ID or request body"
+// @Failure 403 {object} api.APIError "Card belongs to another account"
+// @Failure 404 {object} api.APIError "Card not found"
+// @Failure 409 {object} api.APIError "Status transition not allowed or card expired"
+// @Security BearerAuth
+// @Router /cards/{cardId}/unblock [post]
+func (h *CardHandler) UnblockCard(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.service.UnblockCard)
+}
+
+// @ID cancel-card
+// @Summary Cancel a card
+// @Description Moves an ACTIVE or BLOCKED card to CANCELLED. Cancelling is final.
+// @Tags cards
+// @Accept json
+// @Produce json
+// @Param cardId path string true "Card ID"
+// @Param reason body dto.CardStatusRequest false "Reason for the change"
+// @Success 200 {object} dto.CardResponse
+// @Failure 400 {object} api.APIError "Invalid card ID or request body"
+// @Failure 403 {object} api.APIError "Card belongs to another account"
+// @Failure 404 {object} api.APIError "Card not found"
+// @Failure 409 {object} api.APIError "Status transition not allowed"
+// @Security BearerAuth
+// @Router /cards/{cardId}/cancel [post]
+func (h *CardHandler) CancelCard(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.service.CancelCard)
+}
+
+// @ID replace-card
+// @Summary Replace a card
+// @Description Issues a new card number of the same brand on the card's account, linked to the card through replaces_card_id, and cancels the card. Use it for lost, stolen or expired cards. ACTIVE, BLOCKED and EXPIRED cards can be replaced, each only once; expired cards stay EXPIRED. Virtual cards cannot be replaced. The CVC of the new card is returned once and never stored.
+// @Tags cards
+// @Accept json
+// @Produce json
+// @Param cardId path string true "Card ID"
+// @Param reason body dto.CardStatusRequest false "Reason for the replacement"
+// @Success 201 {object} dto.CardSecretResponse "Replacement card"
+// @Failure 400 {object} api.APIError "Invalid card ID or request body"
+// @Failure 403 {object} api.APIError "Card belongs to another account"
+// @Failure 404 {object} api.APIError "Card not found"
+// @Failure 409 {object} api.APIError "Card already replaced, cancelled or virtual, or account frozen or closed"
+// @Failure 500 {object} api.APIError "Failed to replace card"
+// @Security BearerAuth
+// @Router /cards/{cardId}/replace [post]
+func (h *CardHandler) ReplaceCard(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+
+	req, ok := h.decodeStatusRequest(w, r, lang)
+	if !ok {
+		return
+	}
+
+	card, ok := h.cardFromPath(w, r, lang)
+	if !ok {
+		return
+	}
+
+	replacement, err := h.service.ReplaceCard(r.Context(), card, req.Reason)
+	if err != nil {
+		writeCardError(w, lang, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(replacement)
+}
+
+// @ID get-card-controls
+// @Summary Get card controls
+// @Description Returns the spending controls of a card. A card without controls gets empty ones, which do not restrict it.
+// @Tags cards
+// @Produce json
+// @Param cardId path string true "Card ID"
+// @Success 200 {object} dto.CardControlsResponse
+// @Failure 400 {object} api.APIError "Invalid card ID"
+// @Failure 403 {object} api.APIError "Card belongs to another account"
+// @Failure 404 {object} api.APIError "Card not found"
+// @Failure 500 {object} api.APIError "Failed to retrieve card controls"
+// @Security BearerAuth
+// @Router /cards/{cardId}/controls [get]
+func (h *CardHandler) GetCardControls(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+
+	card, ok := h.cardFromPath(w, r, lang)
+	if !ok {
+		return
+	}
+
+	controls, err := h.service.GetCardControls(r.Context(), card)
+	if err != nil {
+		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorToFindCards))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewCardControlsResponse(controls))
+}
+
+// @ID update-card-controls
+// @Summary Set card controls
+// @Description Replaces the spending controls of a card: a per-transaction maximum and daily and monthly caps on PURCHASE and TRANSFER spend, the transaction types the card may be used for, blocked merchant categories and a velocity limit on the number of transactions in a time window. Omitted controls are removed. Transactions that break a control are stored REJECTED with a decline_reason.
+// @Tags cards
+// @Accept json
+// @Produce json
+// @Param cardId path string true "Card ID"
+// @Param controls body dto.UpdateCardControlsRequest true "Card controls"
+// @Success 200 {object} dto.CardControlsResponse
+// @Failure 400 {object} api.APIError "Invalid card ID, request body or validation failed"
+// @Failure 403 {object} api.APIError "Card belongs to another account"
+// @Failure 404 {object} api.APIError "Card not found"
+// @Failure 409 {object} api.APIError "Card is cancelled or expired"
+// @Failure 500 {object} api.APIError "Failed to update card controls"
+// @Security BearerAuth
+// @Router /cards/{cardId}/controls [put]
+func (h *CardHandler) UpdateCardControls(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+
+	var req dto.UpdateCardControlsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidRequestBody))
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		api.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	card, ok := h.cardFromPath(w, r, lang)
+	if !ok {
+		return
+	}
+
+	controls, err := h.service.UpdateCardControls(r.Context(), card, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrCardCancelled):
+			api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorCardCancelled))
+		case errors.Is(err, ErrCardExpired):
+			api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorCardExpired))
+		default:
+			api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorFailedToUpdateCardControls))
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewCardControlsResponse(controls))
+}
+
+func (h *CardHandler) changeStatus(w http.ResponseWriter, r *http.Request, apply func(ctx context.Context, card *models.Card, reason *string) (*models.Card, error)) {
+	lang := i18n.GetLangFromHeader(r)
+
+	req, ok := h.decodeStatusRequest(w, r, lang)
+	if !ok {
+		return
+	}
+
+	card, ok := h.cardFromPath(w, r, lang)
+	if !ok {
+		return
+	}
+
+	updated, err := apply(r.Context(), card, req.Reason)
+	if err != nil {
+		writeCardError(w, lang, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.NewCardResponse(updated))
+}
+
+// decodeStatusRequest reads the optional body of a status change: a status
+// change without a reason is valid.
+func (h *CardHandler) decodeStatusRequest(w http.ResponseWriter, r *http.Request, lang string) (dto.CardStatusRequest, bool) {
+	var req dto.CardStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidRequestBody))
+		return req, false
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		api.WriteError(w, http.StatusBadRequest, err.Error())
+		return req, false
+	}
+
+	return req, true
+}
+
+// cardFromPath loads the card named by the cardId path variable. Card routes
+// are not keyed by account, so the caller's access to the card's account is
+// checked here rather than by the auth middleware.
+func (h *CardHandler) cardFromPath(w http.ResponseWriter, r *http.Request, lang string) (*models.Card, bool) {
+	cardId := mux.Vars(r)["cardId"]
+
+	if err := h.validate.Var(cardId, "uuid"); err != nil {
+		api.WriteError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+
+	card, err := h.service.GetCardById(r.Context(), cardId)
+	if err != nil {
+		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorToFindCards))
+		return nil, false
+	}
+	if card == nil {
+		api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorCardNotFound))
+		return nil, false
+	}
+
+	if !auth.CanAccessAccount(r.Context(), card.AccountId) {
+		api.WriteError(w, http.StatusForbidden, i18n.GetErrorMessage(lang, i18n.ErrorAccountAccessDenied))
+		return nil, false
+	}
+
+	return card, true
+}
+
+func writeCardError(w http.ResponseWriter, lang string, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidStatusTransition):
+		api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorInvalidCardStatusTransition))
+	case errors.Is(err, ErrCardAlreadyReplaced):
+		api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorCardAlreadyReplaced))
+	case errors.Is(err, ErrCardNotReplaceable):
+		api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorCardNotReplaceable))
+	case errors.Is(err, ErrCardExpired):
+		api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorCardExpired))
+	case errors.Is(err, ErrAccountNotFound):
+		api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorAccountNotFound))
+	case errors.Is(err, ErrAccountFrozen):
+		api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorAccountFrozen))
+	case errors.Is(err, ErrAccountClosed):
+		api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorAccountClosed))
+	case errors.Is(err, ErrBrandNotIssued):
+		api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorCardBrandNotIssued))
+	default:
+		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorFailedToUpdateCard))
+	}
+}
+
+func writeCreateCardError(w http.ResponseWriter, lang string, err error) {
+	switch {
+	case errors.Is(err, ErrAccountNotFound):
+		api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorAccountNotFound))
+	case errors.Is(err, ErrAccountFrozen):
+		api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorAccountFrozen))
+	case errors.Is(err, ErrAccountClosed):
+		api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorAccountClosed))
+	case errors.Is(err, ErrBrandNotIssued):
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorCardBrandNotIssued))
+	case errors.Is(err, ErrInvalidCardConstraints):
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidCardConstraints))
+	default:
+		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorFailedToCreateCard))
+	}
 }
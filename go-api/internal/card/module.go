@@ -2,7 +2,9 @@ package card
 
 import (
 	"payment-gateway/go-api/internal/account"
+	"payment-gateway/go-api/internal/issuing"
 	"payment-gateway/go-api/internal/repository"
+	"payment-gateway/go-api/internal/vault"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -12,9 +14,10 @@ type Module struct {
 	Service CardService
 }
 
-func NewModule(db *sqlx.DB, accountService account.AccountService) *Module {
+func NewModule(db *sqlx.DB, accountService account.AccountService, issuer *issuing.Issuer, vaultService vault.VaultService) *Module {
 	repo := repository.NewCardRepository(db)
-	service := NewCardService(repo, accountService)
+	controlsRepo := repository.NewCardControlsRepository(db)
+	service := NewCardService(repo, controlsRepo, accountService, issuer, vaultService)
 	handler := NewCardHandler(service)
 
 	return &Module{
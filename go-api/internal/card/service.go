@@ -2,57 +2,163 @@ package card
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"log"
+	"payment-gateway/go-api/internal/card/dto"
+	"payment-gateway/go-api/internal/issuing"
 	"payment-gateway/go-api/internal/models"
 	"payment-gateway/go-api/internal/repository"
-	"payment-gateway/go-api/internal/utils"
+	"payment-gateway/go-api/internal/vault"
+	"time"
 
 	"payment-gateway/go-api/internal/account"
+
+	"github.com/jmoiron/sqlx"
 )
 
 type CardService interface {
-	CreateCard(ctx context.Context, accountID string) (*models.Card, error)
+	CreateCard(ctx context.Context, req dto.CreateCardRequest) (*dto.CardSecretResponse, error)
+	GetCardById(ctx context.Context, id string) (*models.Card, error)
 	GetAllCardsByAccountId(ctx context.Context, accountId string) ([]*models.Card, error)
-	GetCardByTokenAndAccountId(ctx context.Context, cardToken, accountId string) (string, error)
+	GetCardByTokenAndAccountId(ctx context.Context, cardToken, accountId string) (*models.Card, error)
+	GetRefundCardByTokenAndAccountId(ctx context.Context, cardToken, accountId string) (*models.Card, error)
+	GetCardForUpdate(ctx context.Context, dbTx *sqlx.Tx, id string) (*models.Card, error)
+	DetokenizeCard(ctx context.Context, card *models.Card) (*dto.DetokenizedCardResponse, error)
+	BlockCard(ctx context.Context, card *models.Card, reason *string) (*models.Card, error)
+	UnblockCard(ctx context.Context, card *models.Card, reason *string) (*models.Card, error)
+	CancelCard(ctx context.Context, card *models.Card, reason *string) (*models.Card, error)
+	ReplaceCard(ctx context.Context, card *models.Card, reason *string) (*dto.CardSecretResponse, error)
+	ExpireCards(ctx context.Context) (int64, error)
+	GetCardControls(ctx context.Context, card *models.Card) (*models.CardControls, error)
+	GetCardControlsForUpdate(ctx context.Context, dbTx *sqlx.Tx, cardId string) (*models.CardControls, error)
+	UpdateCardControls(ctx context.Context, card *models.Card, req dto.UpdateCardControlsRequest) (*models.CardControls, error)
 }
 
 type cardServiceImpl struct {
 	repo           repository.CardRepository
+	controlsRepo   repository.CardControlsRepository
 	accountService account.AccountService
+	issuer         *issuing.Issuer
+	vault          vault.VaultService
 }
 
-func NewCardService(repo repository.CardRepository, accountService account.AccountService) *cardServiceImpl {
-	return &cardServiceImpl{repo: repo, accountService: accountService}
+func NewCardService(repo repository.CardRepository, controlsRepo repository.CardControlsRepository, accountService account.AccountService, issuer *issuing.Issuer, vaultService vault.VaultService) *cardServiceImpl {
+	return &cardServiceImpl{repo: repo, controlsRepo: controlsRepo, accountService: accountService, issuer: issuer, vault: vaultService}
 }
 
-func (s *cardServiceImpl) CreateCard(ctx context.Context, accountID string) (*models.Card, error) {
+// CreateCard issues a card and stores its number in the vault. The returned
+// CVC is not kept anywhere.
+func (s *cardServiceImpl) CreateCard(ctx context.Context, req dto.CreateCardRequest) (*dto.CardSecretResponse, error) {
+	account, err := s.activeAccount(ctx, req.AccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	kind := models.CardKindStandard
+	if req.Kind != nil {
+		kind = *req.Kind
+	}
+	if err := checkKindConstraints(kind, req); err != nil {
+		return nil, err
+	}
+
+	var brand string
+	if req.Brand != nil {
+		brand = *req.Brand
+	}
 
-	account, err := s.accountService.GetAccountById(ctx, accountID)
+	card, cvc, err := s.issue(ctx, account.ID, brand)
 	if err != nil {
 		return nil, err
 	}
+	card.Kind = kind
+	if req.LockedAcceptorId != nil {
+		card.LockedAcceptorId = sql.NullString{String: *req.LockedAcceptorId, Valid: true}
+	}
+	card.AmountLimitCents = nullInt64(req.AmountLimitCents)
 
-	if account == nil {
-		return nil, fmt.Errorf("account not found")
+	if err := s.repo.CreateCard(ctx, card); err != nil {
+		return nil, fmt.Errorf("failed to create card: %w", err)
 	}
 
-	cardNumber, cvc, expiryMonth, expiryYear, err := utils.GenerateCardDetails()
+	return &dto.CardSecretResponse{CardResponse: dto.NewCardResponse(card), CVC: cvc}, nil
+}
+
+// checkKindConstraints checks that a card of kind carries the constraints it
+// needs: merchant-locked cards are locked to a merchant and an amount ceiling,
+// single-use cards may be, standard cards never are.
+func checkKindConstraints(kind string, req dto.CreateCardRequest) error {
+	constrained := req.LockedAcceptorId != nil || req.AmountLimitCents != nil
+
+	switch kind {
+	case models.CardKindStandard:
+		if constrained {
+			return ErrInvalidCardConstraints
+		}
+	case models.CardKindMerchantLocked:
+		if req.LockedAcceptorId == nil || req.AmountLimitCents == nil {
+			return ErrInvalidCardConstraints
+		}
+	}
+
+	return nil
+}
+
+func (s *cardServiceImpl) activeAccount(ctx context.Context, accountId string) (*models.Account, error) {
+	account, err := s.accountService.GetAccountById(ctx, accountId)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate card details: %w", err)
+		return nil, err
+	}
+
+	if account == nil {
+		return nil, ErrAccountNotFound
+	}
+
+	switch account.Status {
+	case models.AccountStatusFrozen:
+		return nil, ErrAccountFrozen
+	case models.AccountStatusClosed:
+		return nil, ErrAccountClosed
 	}
 
-	cardToken := utils.GenerateCardToken(cardNumber, cvc, expiryMonth, expiryYear)
+	return account, nil
+}
 
-	lastFourDigits := cardNumber[len(cardNumber)-4:]
+// issue draws a new card number of brand for accountId and vaults it. The card
+// is not stored yet.
+func (s *cardServiceImpl) issue(ctx context.Context, accountId, brand string) (*models.Card, string, error) {
+	issued, err := s.issuer.Issue(brand)
+	if err != nil {
+		if errors.Is(err, issuing.ErrBrandNotIssued) {
+			return nil, "", ErrBrandNotIssued
+		}
+		return nil, "", fmt.Errorf("failed to generate card details: %w", err)
+	}
+
+	cardToken, err := s.vault.Tokenize(ctx, issued.PAN)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to vault card number: %w", err)
+	}
 
 	card := &models.Card{
-		AccountId:      account.ID,
+		AccountId:      accountId,
 		CardToken:      cardToken,
-		LastFourDigits: lastFourDigits,
+		LastFourDigits: issued.LastFour(),
+		Brand:          issued.Brand,
+		ExpiryMonth:    issued.ExpiryMonth,
+		ExpiryYear:     issued.ExpiryYear,
+		Kind:           models.CardKindStandard,
 	}
 
-	if err := s.repo.CreateCard(ctx, card); err != nil {
-		return nil, fmt.Errorf("failed to create card: %w", err)
+	return card, issued.CVC, nil
+}
+
+func (s *cardServiceImpl) GetCardById(ctx context.Context, id string) (*models.Card, error) {
+	card, err := s.repo.GetCardById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get card: %w", err)
 	}
 
 	return card, nil
@@ -76,16 +182,290 @@ func (s *cardServiceImpl) GetAllCardsByAccountId(ctx context.Context, accountId
 	return cards, nil
 }
 
-func (s *cardServiceImpl) GetCardByTokenAndAccountId(ctx context.Context, cardToken, accountId string) (string, error) {
+// GetCardByTokenAndAccountId returns the card a transaction is made with. Only
+// ACTIVE cards within their expiry month are returned; any other card fails
+// with the error for its status.
+func (s *cardServiceImpl) GetCardByTokenAndAccountId(ctx context.Context, cardToken, accountId string) (*models.Card, error) {
+	card, err := s.repo.GetCardByTokenAndAccountId(ctx, cardToken, accountId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get card by token and account id: %w", err)
+	}
+
+	if card == nil {
+		return nil, ErrCardNotFound
+	}
+	if err := checkUsable(card); err != nil {
+		return nil, err
+	}
+
+	return card, nil
+}
+
+// GetRefundCardByTokenAndAccountId returns the card a refund is made with. It
+// is GetCardByTokenAndAccountId, except that a SINGLE_USE card cancelled by its
+// purchase is returned too, so the purchase can still be refunded.
+func (s *cardServiceImpl) GetRefundCardByTokenAndAccountId(ctx context.Context, cardToken, accountId string) (*models.Card, error) {
 	card, err := s.repo.GetCardByTokenAndAccountId(ctx, cardToken, accountId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get card by token and account id: %w", err)
+	}
+
+	if card == nil {
+		return nil, ErrCardNotFound
+	}
+	if err := checkRefundable(card); err != nil {
+		return nil, err
+	}
+
+	return card, nil
+}
 
+// GetCardForUpdate locks the card until dbTx ends and returns it with its
+// current remaining usage. Like GetCardByTokenAndAccountId, it fails unless the
+// card can still transact.
+func (s *cardServiceImpl) GetCardForUpdate(ctx context.Context, dbTx *sqlx.Tx, id string) (*models.Card, error) {
+	card, err := s.repo.GetCardForUpdate(ctx, dbTx, id)
 	if err != nil {
-		return "", fmt.Errorf("failed to get card by token and account id: %w", err)
+		return nil, err
 	}
 
-	if card == "" {
-		return "", fmt.Errorf("card not found")
+	if card == nil {
+		return nil, ErrCardNotFound
+	}
+	if err := checkUsable(card); err != nil {
+		return nil, err
 	}
 
 	return card, nil
 }
+
+// checkUsable returns the error for the status of card unless it is ACTIVE and
+// within its expiry month.
+func checkUsable(card *models.Card) error {
+	switch card.Status {
+	case models.CardStatusBlocked:
+		return ErrCardBlocked
+	case models.CardStatusCancelled:
+		return ErrCardCancelled
+	case models.CardStatusExpired:
+		return ErrCardExpired
+	}
+	if isExpired(card, time.Now().UTC()) {
+		return ErrCardExpired
+	}
+
+	return nil
+}
+
+// checkRefundable is checkUsable for refunds, which a SINGLE_USE card still
+// takes after its purchase cancelled it. Cards cancelled for any other reason
+// take no refunds.
+func checkRefundable(card *models.Card) error {
+	usedSingleUse := card.Kind == models.CardKindSingleUse &&
+		card.Status == models.CardStatusCancelled &&
+		card.StatusReason.Valid && card.StatusReason.String == models.CardStatusReasonSingleUseCardUsed
+	if !usedSingleUse {
+		return checkUsable(card)
+	}
+	if isExpired(card, time.Now().UTC()) {
+		return ErrCardExpired
+	}
+
+	return nil
+}
+
+// DetokenizeCard reads the full number of card back from the vault. Cards
+// issued before the vault only have a one-way token and cannot be revealed.
+func (s *cardServiceImpl) DetokenizeCard(ctx context.Context, card *models.Card) (*dto.DetokenizedCardResponse, error) {
+	pan, err := s.vault.Detokenize(ctx, card.CardToken)
+	if err != nil {
+		if errors.Is(err, vault.ErrTokenNotFound) {
+			return nil, ErrCardNotVaulted
+		}
+		return nil, fmt.Errorf("failed to detokenize card: %w", err)
+	}
+
+	return &dto.DetokenizedCardResponse{
+		CardId:      card.ID,
+		PAN:         pan,
+		ExpiryMonth: card.ExpiryMonth,
+		ExpiryYear:  card.ExpiryYear,
+	}, nil
+}
+
+func (s *cardServiceImpl) BlockCard(ctx context.Context, card *models.Card, reason *string) (*models.Card, error) {
+	return s.transition(ctx, card, models.CardStatusBlocked, reason, models.CardStatusActive)
+}
+
+func (s *cardServiceImpl) UnblockCard(ctx context.Context, card *models.Card, reason *string) (*models.Card, error) {
+	if isExpired(card, time.Now().UTC()) {
+		return nil, ErrCardExpired
+	}
+	return s.transition(ctx, card, models.CardStatusActive, reason, models.CardStatusBlocked)
+}
+
+func (s *cardServiceImpl) CancelCard(ctx context.Context, card *models.Card, reason *string) (*models.Card, error) {
+	return s.transition(ctx, card, models.CardStatusCancelled, reason, models.CardStatusActive, models.CardStatusBlocked)
+}
+
+// ReplaceCard issues a new card number on the account of card, of the same
+// brand, and cancels card. Expired cards can be replaced too and stay EXPIRED.
+// A card is replaced at most once. Virtual cards are not replaced: their usage
+// would start over on the new card.
+func (s *cardServiceImpl) ReplaceCard(ctx context.Context, card *models.Card, reason *string) (*dto.CardSecretResponse, error) {
+	if card.Kind != models.CardKindStandard {
+		return nil, ErrCardNotReplaceable
+	}
+	if !isStatus(card.Status, models.CardStatusActive, models.CardStatusBlocked, models.CardStatusExpired) {
+		return nil, ErrInvalidStatusTransition
+	}
+
+	account, err := s.activeAccount(ctx, card.AccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cards issued before brands were recorded are replaced with the default
+	// brand.
+	brand := card.Brand
+	if brand == models.CardBrandUnknown {
+		brand = ""
+	}
+
+	replacement, cvc, err := s.issue(ctx, account.ID, brand)
+	if err != nil {
+		return nil, err
+	}
+
+	replaced, err := s.repo.ReplaceCard(ctx, card.ID, card.Status, reason, replacement)
+	if err != nil {
+		if errors.Is(err, repository.ErrDuplicateKey) {
+			return nil, ErrCardAlreadyReplaced
+		}
+		return nil, err
+	}
+	if !replaced {
+		return nil, ErrInvalidStatusTransition
+	}
+
+	return &dto.CardSecretResponse{CardResponse: dto.NewCardResponse(replacement), CVC: cvc}, nil
+}
+
+// transition moves card to status when its current status is one of from. The
+// update is conditional on that status, so a concurrent transition makes this
+// one fail instead of silently overwriting it.
+func (s *cardServiceImpl) transition(ctx context.Context, card *models.Card, status string, reason *string, from ...string) (*models.Card, error) {
+	if !isStatus(card.Status, from...) {
+		return nil, ErrInvalidStatusTransition
+	}
+
+	updated, err := s.repo.UpdateCardStatus(ctx, card.ID, card.Status, status, reason)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, ErrInvalidStatusTransition
+	}
+
+	return updated, nil
+}
+
+func (s *cardServiceImpl) ExpireCards(ctx context.Context) (int64, error) {
+	return s.repo.ExpireCards(ctx)
+}
+
+// GetCardControls returns the controls of card. A card without controls gets
+// empty ones, which do not restrict it.
+func (s *cardServiceImpl) GetCardControls(ctx context.Context, card *models.Card) (*models.CardControls, error) {
+	controls, err := s.controlsRepo.GetCardControls(ctx, card.ID)
+	if err != nil {
+		return nil, err
+	}
+	if controls == nil {
+		controls = &models.CardControls{
+			CardId:                    card.ID,
+			MerchantId:                card.MerchantId,
+			AllowedTransactionTypes:   []string{},
+			BlockedMerchantCategories: []string{},
+			UpdatedAt:                 card.CreatedAt,
+		}
+	}
+
+	return controls, nil
+}
+
+// GetCardControlsForUpdate returns the controls of cardId, or nil when it has
+// none, and locks them until dbTx ends.
+func (s *cardServiceImpl) GetCardControlsForUpdate(ctx context.Context, dbTx *sqlx.Tx, cardId string) (*models.CardControls, error) {
+	return s.controlsRepo.GetCardControlsForUpdate(ctx, dbTx, cardId)
+}
+
+// UpdateCardControls replaces the controls of card. Controls cannot change
+// once the card is cancelled or expired.
+func (s *cardServiceImpl) UpdateCardControls(ctx context.Context, card *models.Card, req dto.UpdateCardControlsRequest) (*models.CardControls, error) {
+	switch card.Status {
+	case models.CardStatusCancelled:
+		return nil, ErrCardCancelled
+	case models.CardStatusExpired:
+		return nil, ErrCardExpired
+	}
+
+	controls := &models.CardControls{
+		CardId:                    card.ID,
+		MaxTransactionCents:       nullInt64(req.MaxTransactionCents),
+		DailyLimitCents:           nullInt64(req.DailyLimitCents),
+		MonthlyLimitCents:         nullInt64(req.MonthlyLimitCents),
+		AllowedTransactionTypes:   append([]string{}, req.AllowedTransactionTypes...),
+		BlockedMerchantCategories: append([]string{}, req.BlockedMerchantCategories...),
+		VelocityLimit:             nullInt64(req.VelocityLimit),
+		VelocityWindowSeconds:     nullInt64(req.VelocityWindowSeconds),
+	}
+	if err := s.controlsRepo.SaveCardControls(ctx, controls); err != nil {
+		return nil, err
+	}
+
+	return controls, nil
+}
+
+func nullInt64(value *int64) sql.NullInt64 {
+	if value == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *value, Valid: true}
+}
+
+func isStatus(status string, statuses ...string) bool {
+	for _, candidate := range statuses {
+		if status == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// isExpired reports whether now is past the end of the expiry month of card.
+func isExpired(card *models.Card, now time.Time) bool {
+	return now.Year()*12+int(now.Month()) > card.ExpiryYear*12+card.ExpiryMonth
+}
+
+// RunCardExpiry periodically marks cards past their expiry month as EXPIRED until ctx is cancelled.
+func RunCardExpiry(ctx context.Context, service CardService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cards, err := service.ExpireCards(ctx)
+			if err != nil {
+				log.Printf("Failed to expire cards: %v", err)
+				continue
+			}
+			if cards > 0 {
+				log.Printf("Expired %d cards", cards)
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package card
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"payment-gateway/go-api/internal/models"
+)
+
+func TestCheckRefundable(t *testing.T) {
+	nextYear := time.Now().UTC().Year() + 1
+	usedReason := sql.NullString{String: models.CardStatusReasonSingleUseCardUsed, Valid: true}
+	lostReason := sql.NullString{String: "Card reported lost", Valid: true}
+
+	tests := []struct {
+		name string
+		card models.Card
+		want error
+	}{
+		{
+			name: "active card",
+			card: models.Card{Kind: models.CardKindStandard, Status: models.CardStatusActive, ExpiryMonth: 12, ExpiryYear: nextYear},
+		},
+		{
+			name: "single-use card cancelled by its purchase",
+			card: models.Card{Kind: models.CardKindSingleUse, Status: models.CardStatusCancelled, StatusReason: usedReason, ExpiryMonth: 12, ExpiryYear: nextYear},
+		},
+		{
+			name: "single-use card cancelled by its purchase and past expiry",
+			card: models.Card{Kind: models.CardKindSingleUse, Status: models.CardStatusCancelled, StatusReason: usedReason, ExpiryMonth: 1, ExpiryYear: 2020},
+			want: ErrCardExpired,
+		},
+		{
+			name: "single-use card cancelled by hand",
+			card: models.Card{Kind: models.CardKindSingleUse, Status: models.CardStatusCancelled, StatusReason: lostReason, ExpiryMonth: 12, ExpiryYear: nextYear},
+			want: ErrCardCancelled,
+		},
+		{
+			name: "single-use card cancelled without reason",
+			card: models.Card{Kind: models.CardKindSingleUse, Status: models.CardStatusCancelled, ExpiryMonth: 12, ExpiryYear: nextYear},
+			want: ErrCardCancelled,
+		},
+		{
+			name: "standard card cancelled with the single-use reason",
+			card: models.Card{Kind: models.CardKindStandard, Status: models.CardStatusCancelled, StatusReason: usedReason, ExpiryMonth: 12, ExpiryYear: nextYear},
+			want: ErrCardCancelled,
+		},
+		{
+			name: "blocked single-use card",
+			card: models.Card{Kind: models.CardKindSingleUse, Status: models.CardStatusBlocked, ExpiryMonth: 12, ExpiryYear: nextYear},
+			want: ErrCardBlocked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkRefundable(&tt.card); !errors.Is(err, tt.want) {
+				t.Errorf("checkRefundable() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package config
+
+import "os"
+
+type APIKeyConfig struct {
+	// BootstrapAdminKey is installed as an OPERATOR key with the admin scope on
+	// startup, so a fresh install can create its first keys.
+	BootstrapAdminKey string
+}
+
+func apiKeyConfigParser() *APIKeyConfig {
+	return &APIKeyConfig{
+		BootstrapAdminKey: os.Getenv("API_BOOTSTRAP_ADMIN_KEY"),
+	}
+}
@@ -0,0 +1,22 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+type AuthConfig struct {
+	// JWTSecret signs session access tokens. When empty a random secret is
+	// generated on startup and sessions do not survive a restart.
+	JWTSecret       string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+func authConfigParser() *AuthConfig {
+	return &AuthConfig{
+		JWTSecret:       os.Getenv("JWT_SECRET"),
+		AccessTokenTTL:  durationFromEnv("JWT_ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL: durationFromEnv("JWT_REFRESH_TOKEN_TTL", 30*24*time.Hour),
+	}
+}
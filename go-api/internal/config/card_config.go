@@ -0,0 +1,32 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+type CardConfig struct {
+	// BINRanges are the prefixes new card numbers are drawn from, written as
+	// "<prefix>:<length>". A card without a requested brand is issued from the
+	// first range.
+	BINRanges []string
+	// ValidityYears is how long new cards are valid for.
+	ValidityYears int
+	// ExpiryInterval is how often cards past their expiry month are marked
+	// EXPIRED.
+	ExpiryInterval time.Duration
+}
+
+func cardConfigParser() *CardConfig {
+	ranges := os.Getenv("CARD_BIN_RANGES")
+	if ranges == "" {
+		ranges = "453211:16,545454:16,506722:16,378282:15"
+	}
+
+	return &CardConfig{
+		BINRanges:      strings.Split(ranges, ","),
+		ValidityYears:  intFromEnv("CARD_VALIDITY_YEARS", 5),
+		ExpiryInterval: durationFromEnv("CARD_EXPIRY_INTERVAL", time.Hour),
+	}
+}
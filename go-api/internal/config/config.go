@@ -9,6 +9,14 @@ type Config struct {
 	DatabaseURL string
 	AmqpURI     string
 	RedisURI    string
+	Idempotency *IdempotencyConfig
+	Outbox      *OutboxConfig
+	Transaction *TransactionConfig
+	Statement   *StatementConfig
+	APIKey      *APIKeyConfig
+	Auth        *AuthConfig
+	Card        *CardConfig
+	Vault       *VaultConfig
 }
 
 func LoadConfig() *Config {
@@ -20,10 +28,26 @@ func LoadConfig() *Config {
 	dbURL := dbUrlParser().DatabaseURL
 	amqpURI := rabbitMQURIParser().AmqpURI
 	redisURI := redisUriParser().RedisURI
+	idempotency := idempotencyConfigParser()
+	outbox := outboxConfigParser()
+	transaction := transactionConfigParser()
+	statement := statementConfigParser()
+	apiKey := apiKeyConfigParser()
+	auth := authConfigParser()
+	card := cardConfigParser()
+	vault := vaultConfigParser()
 
 	return &Config{
 		DatabaseURL: dbURL,
 		AmqpURI:     amqpURI,
 		RedisURI:    redisURI,
+		Idempotency: idempotency,
+		Outbox:      outbox,
+		Transaction: transaction,
+		Statement:   statement,
+		APIKey:      apiKey,
+		Auth:        auth,
+		Card:        card,
+		Vault:       vault,
 	}
 }
@@ -0,0 +1,17 @@
+package config
+
+import "time"
+
+type IdempotencyConfig struct {
+	// KeyTTL is how long stored responses are replayed. Transaction keys stay
+	// unique after that; see CreateTransaction in the transaction service.
+	KeyTTL          time.Duration
+	JanitorInterval time.Duration
+}
+
+func idempotencyConfigParser() *IdempotencyConfig {
+	return &IdempotencyConfig{
+		KeyTTL:          durationFromEnv("IDEMPOTENCY_KEY_TTL", 24*time.Hour),
+		JanitorInterval: durationFromEnv("IDEMPOTENCY_JANITOR_INTERVAL", 10*time.Minute),
+	}
+}
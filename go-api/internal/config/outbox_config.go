@@ -0,0 +1,19 @@
+package config
+
+import "time"
+
+type OutboxConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+}
+
+func outboxConfigParser() *OutboxConfig {
+	return &OutboxConfig{
+		PollInterval: durationFromEnv("OUTBOX_POLL_INTERVAL", time.Second),
+		BatchSize:    intFromEnv("OUTBOX_BATCH_SIZE", 100),
+		BaseBackoff:  durationFromEnv("OUTBOX_BASE_BACKOFF", time.Second),
+		MaxBackoff:   durationFromEnv("OUTBOX_MAX_BACKOFF", 5*time.Minute),
+	}
+}
@@ -0,0 +1,17 @@
+package config
+
+import "os"
+
+type StatementConfig struct {
+	// Currency is the ISO 4217 code written in OFX and camt.053 statements.
+	Currency string
+}
+
+func statementConfigParser() *StatementConfig {
+	currency := os.Getenv("STATEMENT_CURRENCY")
+	if currency == "" {
+		currency = "BRL"
+	}
+
+	return &StatementConfig{Currency: currency}
+}
@@ -0,0 +1,17 @@
+package config
+
+import "time"
+
+type TransactionConfig struct {
+	AuthorizationHoldTTL time.Duration
+	HoldExpiryInterval   time.Duration
+	SnapshotInterval     time.Duration
+}
+
+func transactionConfigParser() *TransactionConfig {
+	return &TransactionConfig{
+		AuthorizationHoldTTL: durationFromEnv("AUTHORIZATION_HOLD_TTL", 7*24*time.Hour),
+		HoldExpiryInterval:   durationFromEnv("AUTHORIZATION_HOLD_EXPIRY_INTERVAL", time.Minute),
+		SnapshotInterval:     durationFromEnv("BALANCE_SNAPSHOT_INTERVAL", time.Hour),
+	}
+}
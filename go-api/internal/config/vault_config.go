@@ -0,0 +1,33 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+type VaultConfig struct {
+	// KEKs are the key-encryption keys protecting card numbers, written as
+	// "<version>:<base64 32-byte key>". When empty a random key is generated
+	// on startup and vaulted card numbers do not survive a restart.
+	KEKs []string
+	// ActiveKEKVersion is the key new card numbers are protected with;
+	// 0 selects the highest configured version.
+	ActiveKEKVersion int
+	// RewrapInterval is how often data keys still wrapped by a retired KEK
+	// are rewrapped with the active one.
+	RewrapInterval time.Duration
+}
+
+func vaultConfigParser() *VaultConfig {
+	var keks []string
+	if value := os.Getenv("VAULT_KEKS"); value != "" {
+		keks = strings.Split(value, ",")
+	}
+
+	return &VaultConfig{
+		KEKs:             keks,
+		ActiveKEKVersion: intFromEnv("VAULT_ACTIVE_KEK_VERSION", 0),
+		RewrapInterval:   durationFromEnv("VAULT_REWRAP_INTERVAL", time.Hour),
+	}
+}
@@ -2,17 +2,37 @@ package connection
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"sync"
+	"time"
 
 	"github.com/rabbitmq/amqp091-go"
 )
 
+const (
+	rabbitMQBaseReconnectDelay = time.Second
+	rabbitMQMaxReconnectDelay  = 30 * time.Second
+)
+
+// ErrRabbitMQNotConnected is returned by Publish while the connection to
+// RabbitMQ is lost and being re-established.
+var ErrRabbitMQNotConnected = errors.New("RabbitMQ is not connected")
+
 type RabbitMQClient interface {
 	Publish(ctx context.Context, queueName string, message []byte) error
 }
 
+// rabbitMQClientImpl publishes on a single channel in confirm mode. When the
+// connection closes it is dialled again in the background, with exponential
+// backoff; publishes fail with ErrRabbitMQNotConnected until it is back.
 type rabbitMQClientImpl struct {
+	amqpURI string
+
+	mu   sync.Mutex
 	conn *amqp091.Connection
+	ch   *amqp091.Channel
 }
 
 func NewRabbitMQClient(amqpURI string) (RabbitMQClient, error) {
@@ -21,15 +41,74 @@ func NewRabbitMQClient(amqpURI string) (RabbitMQClient, error) {
 		return nil, fmt.Errorf("fail to connect RabbitMQ: %w", err)
 	}
 
-	return &rabbitMQClientImpl{conn: conn}, nil
+	c := &rabbitMQClientImpl{amqpURI: amqpURI, conn: conn}
+	go c.reconnect(conn.NotifyClose(make(chan *amqp091.Error, 1)))
+
+	return c, nil
 }
 
-func (c *rabbitMQClientImpl) Publish(ctx context.Context, queueName string, message []byte) error {
+// reconnect waits for the connection to close and dials it again, for as long
+// as the process runs.
+func (c *rabbitMQClientImpl) reconnect(closed chan *amqp091.Error) {
+	for {
+		err := <-closed
+		log.Printf("RabbitMQ connection closed: %v", err)
+
+		delay := rabbitMQBaseReconnectDelay
+		for {
+			time.Sleep(delay)
+
+			conn, err := amqp091.Dial(c.amqpURI)
+			if err != nil {
+				log.Printf("Failed to reconnect to RabbitMQ: %v", err)
+				delay = min(delay*2, rabbitMQMaxReconnectDelay)
+				continue
+			}
+
+			closed = conn.NotifyClose(make(chan *amqp091.Error, 1))
+			c.mu.Lock()
+			c.conn = conn
+			c.ch = nil
+			c.mu.Unlock()
+			log.Printf("Reconnected to RabbitMQ")
+			break
+		}
+	}
+}
+
+// channel returns the confirm-mode channel, opening it when it is missing or
+// was closed by a channel error. c.mu must be held.
+func (c *rabbitMQClientImpl) channel() (*amqp091.Channel, error) {
+	if c.ch != nil && !c.ch.IsClosed() {
+		return c.ch, nil
+	}
+	if c.conn.IsClosed() {
+		return nil, ErrRabbitMQNotConnected
+	}
+
 	ch, err := c.conn.Channel()
 	if err != nil {
-		return fmt.Errorf("fail to open channel: %w", err)
+		return nil, fmt.Errorf("fail to open channel: %w", err)
+	}
+	if err := ch.Confirm(false); err != nil {
+		ch.Close()
+		return nil, fmt.Errorf("fail to put channel in confirm mode: %w", err)
+	}
+
+	c.ch = ch
+	return ch, nil
+}
+
+// Publish sends message to queueName and waits for the broker to confirm it.
+// A nil error means the broker took responsibility for the message.
+func (c *rabbitMQClientImpl) Publish(ctx context.Context, queueName string, message []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch, err := c.channel()
+	if err != nil {
+		return err
 	}
-	defer ch.Close()
 
 	q, err := ch.QueueDeclare(
 		queueName,
@@ -43,19 +122,29 @@ func (c *rabbitMQClientImpl) Publish(ctx context.Context, queueName string, mess
 		return fmt.Errorf("fail to declare queue: %w", err)
 	}
 
-	err = ch.Publish(
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(
+		ctx,
 		"",
 		q.Name,
 		false,
 		false,
 		amqp091.Publishing{
-			ContentType: "application/json",
-			Body:        message,
+			ContentType:  "application/json",
+			DeliveryMode: amqp091.Persistent,
+			Body:         message,
 		},
 	)
 	if err != nil {
 		return fmt.Errorf("fail to publish message: %w", err)
 	}
 
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("fail to confirm message: %w", err)
+	}
+	if !acked {
+		return fmt.Errorf("message to %s was not confirmed by the broker", queueName)
+	}
+
 	return nil
 }
@@ -6,78 +6,300 @@ import (
 )
 
 const (
-	ErrorInvalidRequestBody        = "invalid_request_body"
-	ErrorMethodNotAllowed          = "method_not_allowed"
-	ErrorInternalServerError       = "internal_server_error"
-	ErrorValidationFailed          = "validation_failed"
-	ErrorNotFound                  = "not_found"
-	ErrorAccountNotFound           = "account_not_found"
-	ErrorCardNotFound              = "card_not_found"
-	ErrorTransactionNotFound       = "transaction_not_found"
-	ErrorDuplicateKey              = "duplicate_key"
-	ErrorInsufficientFunds         = "insufficient_funds"
-	ErrorFailedToCreateAccount     = "failed_to_create_account"
-	ErrorFailedToCreateCard        = "failed_to_create_card"
-	ErrorFailedToCreateTransaction = "failed_to_create_transaction"
-	ErrorFailedToGetAccounts       = "failed_to_get_accounts"
-	PaginationLimitExceeded        = "pagination_limit_exceeded"
-	ErrorToFindCards               = "error_to_find_cards"
-	ErrorCreatingTransaction       = "error_creating_transaction"
-	ErrIdempotencyKeyExists        = "err_idempotency_key_exists"
-	ErrorFindAllTransaction        = "error_find_all_transaction"
-	InfoBalanceProcessing          = "info_balance_processing"
-	ErrorFetchingBalanceFromCache  = "error_fetching_balance_from_cache"
-	ErrorFindTransactionById       = "error_find_transaction_by_id"
+	ErrorInvalidRequestBody             = "invalid_request_body"
+	ErrorMethodNotAllowed               = "method_not_allowed"
+	ErrorInternalServerError            = "internal_server_error"
+	ErrorValidationFailed               = "validation_failed"
+	ErrorNotFound                       = "not_found"
+	ErrorAccountNotFound                = "account_not_found"
+	ErrorCardNotFound                   = "card_not_found"
+	ErrorTransactionNotFound            = "transaction_not_found"
+	ErrorDuplicateKey                   = "duplicate_key"
+	ErrorInsufficientFunds              = "insufficient_funds"
+	ErrorFailedToCreateAccount          = "failed_to_create_account"
+	ErrorFailedToCreateCard             = "failed_to_create_card"
+	ErrorFailedToCreateTransaction      = "failed_to_create_transaction"
+	ErrorFailedToGetAccounts            = "failed_to_get_accounts"
+	PaginationLimitExceeded             = "pagination_limit_exceeded"
+	ErrorToFindCards                    = "error_to_find_cards"
+	ErrorCreatingTransaction            = "error_creating_transaction"
+	ErrIdempotencyKeyExists             = "err_idempotency_key_exists"
+	ErrorFindAllTransaction             = "error_find_all_transaction"
+	InfoBalanceProcessing               = "info_balance_processing"
+	ErrorFetchingBalanceFromCache       = "error_fetching_balance_from_cache"
+	ErrorFindTransactionById            = "error_find_transaction_by_id"
+	ErrorInvalidIdempotencyKey          = "invalid_idempotency_key"
+	ErrorIdempotencyKeyInProgress       = "idempotency_key_in_progress"
+	ErrorIdempotencyKeyReused           = "idempotency_key_reused"
+	ErrorFetchingOutboxMetrics          = "error_fetching_outbox_metrics"
+	ErrorRefundTransactionIdRequired    = "refund_transaction_id_required"
+	ErrorOriginalTransactionNotFound    = "original_transaction_not_found"
+	ErrorRefundAccountMismatch          = "refund_account_mismatch"
+	ErrorOriginalTransactionNotApproved = "original_transaction_not_approved"
+	ErrorTransactionNotRefundable       = "transaction_not_refundable"
+	ErrorRefundExceedsRefundableAmount  = "refund_exceeds_refundable_amount"
+	ErrorDestinationAccountRequired     = "destination_account_required"
+	ErrorTransferToSameAccount          = "transfer_to_same_account"
+	ErrorDestinationAccountNotFound     = "destination_account_not_found"
+	ErrorCaptureOnlyForPurchase         = "capture_only_for_purchase"
+	ErrorTransactionNotAuthorized       = "transaction_not_authorized"
+	ErrorAuthorizationExpired           = "authorization_expired"
+	ErrorCaptureExceedsAuthorizedAmount = "capture_exceeds_authorized_amount"
+	ErrorReasonCodeRequired             = "reason_code_required"
+	ErrorCardNotAllowedForCharge        = "card_not_allowed_for_charge"
+	ErrorFetchingLedger                 = "error_fetching_ledger"
+	ErrorCalculatingBalance             = "error_calculating_balance"
+	ErrorInvalidTimestamp               = "invalid_timestamp"
+	ErrorInvalidHistoryRange            = "invalid_history_range"
+	ErrorInvalidHistoryInterval         = "invalid_history_interval"
+	ErrorHistoryTooManyPoints           = "history_too_many_points"
+	ErrorInvalidTransactionFilter       = "invalid_transaction_filter"
+	ErrorInvalidCursor                  = "invalid_cursor"
+	ErrorSearchRequiresOperator         = "search_requires_operator"
+	ErrorInvalidStatementFormat         = "invalid_statement_format"
+	ErrorGeneratingStatement            = "error_generating_statement"
+	ErrorAccountFrozen                  = "account_frozen"
+	ErrorAccountClosed                  = "account_closed"
+	ErrorInvalidAccountStatusTransition = "invalid_account_status_transition"
+	ErrorEmptyAccountUpdate             = "empty_account_update"
+	ErrorInvalidAccountMetadata         = "invalid_account_metadata"
+	ErrorFailedToUpdateAccount          = "failed_to_update_account"
+	ErrorDestinationAccountInactive     = "destination_account_inactive"
+	ErrorMissingCredentials             = "missing_credentials"
+	ErrorInvalidAPIKey                  = "invalid_api_key"
+	ErrorAPIKeyRevoked                  = "api_key_revoked"
+	ErrorInsufficientScope              = "insufficient_scope"
+	ErrorAccountAccessDenied            = "account_access_denied"
+	ErrorOperatorKeyRequired            = "operator_key_required"
+	ErrorAPIKeyNotFound                 = "api_key_not_found"
+	ErrorInvalidAPIKeyScope             = "invalid_api_key_scope"
+	ErrorInvalidAPIKeyOwner             = "invalid_api_key_owner"
+	ErrorOperatorScopeForAccountKey     = "operator_scope_for_account_key"
+	ErrorInvalidAccessToken             = "invalid_access_token"
+	ErrorInvalidCredentials             = "invalid_credentials"
+	ErrorInvalidRefreshToken            = "invalid_refresh_token"
+	ErrorAccountRequired                = "account_required"
+	ErrorPermissionDenied               = "permission_denied"
+	ErrorOperatorNotFound               = "operator_not_found"
+	ErrorOperatorInactive               = "operator_inactive"
+	ErrorInvalidOperatorRole            = "invalid_operator_role"
+	ErrorEmptyOperatorUpdate            = "empty_operator_update"
+	ErrorOperatorForAccountKey          = "operator_for_account_key"
+	ErrorInvalidAuditLogFilter          = "invalid_audit_log_filter"
+	ErrorMerchantNotFound               = "merchant_not_found"
+	ErrorMerchantRequired               = "merchant_required"
+	ErrorPlatformOperatorRequired       = "platform_operator_required"
+	ErrorMerchantForOwnedKey            = "merchant_for_owned_key"
+	ErrorCardBrandNotIssued             = "card_brand_not_issued"
+	ErrorCardNotVaulted                 = "card_not_vaulted"
+	ErrorFailedToDetokenizeCard         = "failed_to_detokenize_card"
+	ErrorCardBlocked                    = "card_blocked"
+	ErrorCardCancelled                  = "card_cancelled"
+	ErrorCardExpired                    = "card_expired"
+	ErrorInvalidCardStatusTransition    = "invalid_card_status_transition"
+	ErrorCardAlreadyReplaced            = "card_already_replaced"
+	ErrorFailedToUpdateCard             = "failed_to_update_card"
+	ErrorFailedToUpdateCardControls     = "failed_to_update_card_controls"
+	ErrorInvalidCardConstraints         = "invalid_card_constraints"
+	ErrorCardNotReplaceable             = "card_not_replaceable"
 )
 
 var errorMessages = map[string]map[string]string{
 	"en-us": {
-		ErrorInvalidRequestBody:        "Invalid request body",
-		ErrorMethodNotAllowed:          "Method not allowed",
-		ErrorInternalServerError:       "Internal server error",
-		ErrorValidationFailed:          "Validation failed",
-		ErrorNotFound:                  "Resource not found",
-		ErrorAccountNotFound:           "Account not found",
-		ErrorCardNotFound:              "Card not found",
-		ErrorTransactionNotFound:       "Transaction not found",
-		ErrorDuplicateKey:              "Duplicate key",
-		ErrorInsufficientFunds:         "Insufficient funds",
-		ErrorFailedToCreateAccount:     "Failed to create account",
-		ErrorFailedToCreateCard:        "Failed to create card",
-		ErrorFailedToCreateTransaction: "Failed to create transaction",
-		ErrorFailedToGetAccounts:       "Failed to get accounts",
-		PaginationLimitExceeded:        "Pagination limit exceeded",
-		ErrorToFindCards:               "Error to find cards",
-		ErrorCreatingTransaction:       "Error creating transaction",
-		ErrIdempotencyKeyExists:        "Idempotency key already exists",
-		ErrorFindAllTransaction:        "Error to find all transactions",
-		InfoBalanceProcessing:          "The account balance is being calculated. Please try again in a few moments.",
-		ErrorFetchingBalanceFromCache:  "Error fetching balance from cache",
-		ErrorFindTransactionById:       "Error finding transaction by ID",
+		ErrorInvalidRequestBody:             "Invalid request body",
+		ErrorMethodNotAllowed:               "Method not allowed",
+		ErrorInternalServerError:            "Internal server error",
+		ErrorValidationFailed:               "Validation failed",
+		ErrorNotFound:                       "Resource not found",
+		ErrorAccountNotFound:                "Account not found",
+		ErrorCardNotFound:                   "Card not found",
+		ErrorTransactionNotFound:            "Transaction not found",
+		ErrorDuplicateKey:                   "Duplicate key",
+		ErrorInsufficientFunds:              "Insufficient funds",
+		ErrorFailedToCreateAccount:          "Failed to create account",
+		ErrorFailedToCreateCard:             "Failed to create card",
+		ErrorFailedToCreateTransaction:      "Failed to create transaction",
+		ErrorFailedToGetAccounts:            "Failed to get accounts",
+		PaginationLimitExceeded:             "Pagination limit exceeded",
+		ErrorToFindCards:                    "Error to find cards",
+		ErrorCreatingTransaction:            "Error creating transaction",
+		ErrIdempotencyKeyExists:             "Idempotency key already exists",
+		ErrorFindAllTransaction:             "Error to find all transactions",
+		InfoBalanceProcessing:               "The account balance is being calculated. Please try again in a few moments.",
+		ErrorFetchingBalanceFromCache:       "Error fetching balance from cache",
+		ErrorFindTransactionById:            "Error finding transaction by ID",
+		ErrorInvalidIdempotencyKey:          "Idempotency key must be at most 255 characters",
+		ErrorIdempotencyKeyInProgress:       "A request with this idempotency key is still being processed",
+		ErrorIdempotencyKeyReused:           "Idempotency key was already used with a different request",
+		ErrorFetchingOutboxMetrics:          "Error fetching outbox metrics",
+		ErrorRefundTransactionIdRequired:    "refund_transaction_id is required for REFUND transactions",
+		ErrorOriginalTransactionNotFound:    "Original transaction for refund not found",
+		ErrorRefundAccountMismatch:          "Original transaction belongs to another account",
+		ErrorOriginalTransactionNotApproved: "Only approved transactions can be refunded",
+		ErrorTransactionNotRefundable:       "This transaction cannot be refunded",
+		ErrorRefundExceedsRefundableAmount:  "Refund amount exceeds the amount still refundable for this transaction",
+		ErrorDestinationAccountRequired:     "destination_account_id is required for TRANSFER transactions",
+		ErrorTransferToSameAccount:          "Source and destination accounts must be different",
+		ErrorDestinationAccountNotFound:     "Destination account not found",
+		ErrorCaptureOnlyForPurchase:         "capture=false is only supported for PURCHASE transactions",
+		ErrorTransactionNotAuthorized:       "Transaction is not an open authorization",
+		ErrorAuthorizationExpired:           "The authorization hold has expired",
+		ErrorCaptureExceedsAuthorizedAmount: "Capture amount exceeds the authorized amount",
+		ErrorReasonCodeRequired:             "reason_code is required for CHARGE transactions",
+		ErrorCardNotAllowedForCharge:        "card_token is not allowed for CHARGE transactions",
+		ErrorFetchingLedger:                 "Error fetching account ledger",
+		ErrorCalculatingBalance:             "Error calculating account balance",
+		ErrorInvalidTimestamp:               "Invalid timestamp, expected RFC3339 (e.g. 2025-10-01T00:00:00Z)",
+		ErrorInvalidHistoryRange:            "from must be before to",
+		ErrorInvalidHistoryInterval:         "interval must be one of hour, day, week, month",
+		ErrorHistoryTooManyPoints:           "The requested range has too many points, use a larger interval",
+		ErrorInvalidTransactionFilter:       "Invalid transaction filter",
+		ErrorInvalidCursor:                  "Invalid pagination cursor",
+		ErrorSearchRequiresOperator:         "Searching across accounts requires an operator API key",
+		ErrorInvalidStatementFormat:         "Unsupported statement format",
+		ErrorGeneratingStatement:            "Error generating account statement",
+		ErrorAccountFrozen:                  "Account is frozen",
+		ErrorAccountClosed:                  "Account is closed",
+		ErrorInvalidAccountStatusTransition: "Account status transition is not allowed",
+		ErrorEmptyAccountUpdate:             "At least one of username or metadata is required",
+		ErrorInvalidAccountMetadata:         "metadata must be a JSON object",
+		ErrorFailedToUpdateAccount:          "Failed to update account",
+		ErrorDestinationAccountInactive:     "Destination account is frozen or closed",
+		ErrorMissingCredentials:             "Missing credentials. Send an API key or access token as Authorization: Bearer <token>",
+		ErrorInvalidAPIKey:                  "Invalid API key",
+		ErrorAPIKeyRevoked:                  "API key has been revoked",
+		ErrorInsufficientScope:              "API key does not grant the scope required by this route",
+		ErrorAccountAccessDenied:            "API key cannot access this account",
+		ErrorOperatorKeyRequired:            "This route requires an operator API key",
+		ErrorAPIKeyNotFound:                 "API key not found",
+		ErrorInvalidAPIKeyScope:             "Invalid scope",
+		ErrorInvalidAPIKeyOwner:             "account_id is required for ACCOUNT keys and not allowed for OPERATOR keys",
+		ErrorOperatorScopeForAccountKey:     "Operator scopes such as admin can only be granted to OPERATOR keys",
+		ErrorInvalidAccessToken:             "Invalid or expired access token",
+		ErrorInvalidCredentials:             "Invalid username or password",
+		ErrorInvalidRefreshToken:            "Invalid or expired refresh token",
+		ErrorAccountRequired:                "This route requires credentials tied to an account",
+		ErrorPermissionDenied:               "Your role does not allow this action",
+		ErrorOperatorNotFound:               "Operator not found",
+		ErrorOperatorInactive:               "Operator is inactive",
+		ErrorInvalidOperatorRole:            "Unknown operator role",
+		ErrorEmptyOperatorUpdate:            "At least one of role or active is required",
+		ErrorOperatorForAccountKey:          "operator_id is only allowed for OPERATOR keys",
+		ErrorInvalidAuditLogFilter:          "Invalid audit log filter",
+		ErrorMerchantNotFound:               "Merchant not found",
+		ErrorMerchantRequired:               "merchant_id is required for platform operators",
+		ErrorPlatformOperatorRequired:       "Only platform operators can manage merchants",
+		ErrorMerchantForOwnedKey:            "merchant_id is only allowed for OPERATOR keys without an operator_id",
+		ErrorCardBrandNotIssued:             "No BIN range is configured for the requested card brand",
+		ErrorCardNotVaulted:                 "This card was issued before the vault and its number cannot be revealed",
+		ErrorFailedToDetokenizeCard:         "Failed to detokenize card",
+		ErrorCardBlocked:                    "Card is blocked",
+		ErrorCardCancelled:                  "Card is cancelled",
+		ErrorCardExpired:                    "Card is expired",
+		ErrorInvalidCardStatusTransition:    "Card status transition is not allowed",
+		ErrorCardAlreadyReplaced:            "Card was already replaced",
+		ErrorFailedToUpdateCard:             "Failed to update card",
+		ErrorFailedToUpdateCardControls:     "Failed to update card controls",
+		ErrorInvalidCardConstraints:         "locked_acceptor_id and amount_limit_cents are required on MERCHANT_LOCKED cards and not allowed on STANDARD cards",
+		ErrorCardNotReplaceable:             "Virtual cards cannot be replaced",
 	},
 	"pt-br": {
-		ErrorInvalidRequestBody:        "Corpo da requisição inválido",
-		ErrorMethodNotAllowed:          "Método não permitido",
-		ErrorInternalServerError:       "Erro interno do servidor",
-		ErrorValidationFailed:          "Falha na validação",
-		ErrorNotFound:                  "Recurso não encontrado",
-		ErrorAccountNotFound:           "Conta não encontrada",
-		ErrorCardNotFound:              "Cartão não encontrado",
-		ErrorTransactionNotFound:       "Transação não encontrada",
-		ErrorDuplicateKey:              "Chave duplicada",
-		ErrorInsufficientFunds:         "Saldo insuficiente",
-		ErrorFailedToCreateAccount:     "Falha ao criar a conta",
-		ErrorFailedToCreateCard:        "Falha ao criar o cartão",
-		ErrorFailedToCreateTransaction: "Falha ao criar a transação",
-		ErrorFailedToGetAccounts:       "Falha ao buscar contas",
-		PaginationLimitExceeded:        "Limite de paginação excedido",
-		ErrorToFindCards:               "Erro ao buscar cartões",
-		ErrorCreatingTransaction:       "Erro ao criar a transação",
-		ErrIdempotencyKeyExists:        "Chave de idempotência já existe",
-		ErrorFindAllTransaction:        "Erro ao buscar todas as transações",
-		InfoBalanceProcessing:          "O saldo da conta está sendo calculado. Por favor, tente novamente em alguns instantes.",
-		ErrorFetchingBalanceFromCache:  "Erro ao buscar saldo do cache",
-		ErrorFindTransactionById:       "Erro ao buscar transação pelo ID",
+		ErrorInvalidRequestBody:             "Corpo da requisição inválido",
+		ErrorMethodNotAllowed:               "Método não permitido",
+		ErrorInternalServerError:            "Erro interno do servidor",
+		ErrorValidationFailed:               "Falha na validação",
+		ErrorNotFound:                       "Recurso não encontrado",
+		ErrorAccountNotFound:                "Conta não encontrada",
+		ErrorCardNotFound:                   "Cartão não encontrado",
+		ErrorTransactionNotFound:            "Transação não encontrada",
+		ErrorDuplicateKey:                   "Chave duplicada",
+		ErrorInsufficientFunds:              "Saldo insuficiente",
+		ErrorFailedToCreateAccount:          "Falha ao criar a conta",
+		ErrorFailedToCreateCard:             "Falha ao criar o cartão",
+		ErrorFailedToCreateTransaction:      "Falha ao criar a transação",
+		ErrorFailedToGetAccounts:            "Falha ao buscar contas",
+		PaginationLimitExceeded:             "Limite de paginação excedido",
+		ErrorToFindCards:                    "Erro ao buscar cartões",
+		ErrorCreatingTransaction:            "Erro ao criar a transação",
+		ErrIdempotencyKeyExists:             "Chave de idempotência já existe",
+		ErrorFindAllTransaction:             "Erro ao buscar todas as transações",
+		InfoBalanceProcessing:               "O saldo da conta está sendo calculado. Por favor, tente novamente em alguns instantes.",
+		ErrorFetchingBalanceFromCache:       "Erro ao buscar saldo do cache",
+		ErrorFindTransactionById:            "Erro ao buscar transação pelo ID",
+		ErrorInvalidIdempotencyKey:          "A chave de idempotência deve ter no máximo 255 caracteres",
+		ErrorIdempotencyKeyInProgress:       "Uma requisição com esta chave de idempotência ainda está sendo processada",
+		ErrorIdempotencyKeyReused:           "A chave de idempotência já foi usada com uma requisição diferente",
+		ErrorFetchingOutboxMetrics:          "Erro ao buscar métricas do outbox",
+		ErrorRefundTransactionIdRequired:    "refund_transaction_id é obrigatório para transações REFUND",
+		ErrorOriginalTransactionNotFound:    "Transação original do estorno não encontrada",
+		ErrorRefundAccountMismatch:          "A transação original pertence a outra conta",
+		ErrorOriginalTransactionNotApproved: "Somente transações aprovadas podem ser estornadas",
+		ErrorTransactionNotRefundable:       "Esta transação não pode ser estornada",
+		ErrorRefundExceedsRefundableAmount:  "O valor do estorno excede o valor ainda estornável desta transação",
+		ErrorDestinationAccountRequired:     "destination_account_id é obrigatório para transações TRANSFER",
+		ErrorTransferToSameAccount:          "As contas de origem e destino devem ser diferentes",
+		ErrorDestinationAccountNotFound:     "Conta de destino não encontrada",
+		ErrorCaptureOnlyForPurchase:         "capture=false só é suportado em transações PURCHASE",
+		ErrorTransactionNotAuthorized:       "A transação não é uma autorização em aberto",
+		ErrorAuthorizationExpired:           "A pré-autorização expirou",
+		ErrorCaptureExceedsAuthorizedAmount: "O valor da captura excede o valor autorizado",
+		ErrorReasonCodeRequired:             "reason_code é obrigatório para transações CHARGE",
+		ErrorCardNotAllowedForCharge:        "card_token não é permitido em transações CHARGE",
+		ErrorFetchingLedger:                 "Erro ao buscar o razão da conta",
+		ErrorCalculatingBalance:             "Erro ao calcular o saldo da conta",
+		ErrorInvalidTimestamp:               "Data inválida, esperado RFC3339 (ex.: 2025-10-01T00:00:00Z)",
+		ErrorInvalidHistoryRange:            "from deve ser anterior a to",
+		ErrorInvalidHistoryInterval:         "interval deve ser hour, day, week ou month",
+		ErrorHistoryTooManyPoints:           "O intervalo solicitado tem pontos demais, use um interval maior",
+		ErrorInvalidTransactionFilter:       "Filtro de transações inválido",
+		ErrorInvalidCursor:                  "Cursor de paginação inválido",
+		ErrorSearchRequiresOperator:         "A busca entre contas exige uma chave de API de operador",
+		ErrorInvalidStatementFormat:         "Formato de extrato não suportado",
+		ErrorGeneratingStatement:            "Erro ao gerar o extrato da conta",
+		ErrorAccountFrozen:                  "A conta está congelada",
+		ErrorAccountClosed:                  "A conta está encerrada",
+		ErrorInvalidAccountStatusTransition: "Transição de status da conta não permitida",
+		ErrorEmptyAccountUpdate:             "Informe ao menos username ou metadata",
+		ErrorInvalidAccountMetadata:         "metadata deve ser um objeto JSON",
+		ErrorFailedToUpdateAccount:          "Falha ao atualizar a conta",
+		ErrorDestinationAccountInactive:     "A conta de destino está congelada ou encerrada",
+		ErrorMissingCredentials:             "Credenciais ausentes. Envie uma chave de API ou token de acesso como Authorization: Bearer <token>",
+		ErrorInvalidAPIKey:                  "Chave de API inválida",
+		ErrorAPIKeyRevoked:                  "A chave de API foi revogada",
+		ErrorInsufficientScope:              "A chave de API não concede o escopo exigido por esta rota",
+		ErrorAccountAccessDenied:            "A chave de API não tem acesso a esta conta",
+		ErrorOperatorKeyRequired:            "Esta rota exige uma chave de API de operador",
+		ErrorAPIKeyNotFound:                 "Chave de API não encontrada",
+		ErrorInvalidAPIKeyScope:             "Escopo inválido",
+		ErrorInvalidAPIKeyOwner:             "account_id é obrigatório para chaves ACCOUNT e não é permitido para chaves OPERATOR",
+		ErrorOperatorScopeForAccountKey:     "Escopos de operador como admin só podem ser concedidos a chaves OPERATOR",
+		ErrorInvalidAccessToken:             "Token de acesso inválido ou expirado",
+		ErrorInvalidCredentials:             "Usuário ou senha inválidos",
+		ErrorInvalidRefreshToken:            "Refresh token inválido ou expirado",
+		ErrorAccountRequired:                "Esta rota exige credenciais vinculadas a uma conta",
+		ErrorPermissionDenied:               "Seu perfil não permite esta ação",
+		ErrorOperatorNotFound:               "Operador não encontrado",
+		ErrorOperatorInactive:               "O operador está inativo",
+		ErrorInvalidOperatorRole:            "Perfil de operador desconhecido",
+		ErrorEmptyOperatorUpdate:            "Informe ao menos um entre role e active",
+		ErrorOperatorForAccountKey:          "operator_id só é permitido para chaves OPERATOR",
+		ErrorInvalidAuditLogFilter:          "Filtro de log de auditoria inválido",
+		ErrorMerchantNotFound:               "Lojista não encontrado",
+		ErrorMerchantRequired:               "merchant_id é obrigatório para operadores da plataforma",
+		ErrorPlatformOperatorRequired:       "Apenas operadores da plataforma podem gerenciar lojistas",
+		ErrorMerchantForOwnedKey:            "merchant_id só é permitido para chaves OPERATOR sem operator_id",
+		ErrorCardBrandNotIssued:             "Nenhuma faixa de BIN está configurada para a bandeira solicitada",
+		ErrorCardNotVaulted:                 "Este cartão foi emitido antes do cofre e seu número não pode ser revelado",
+		ErrorFailedToDetokenizeCard:         "Falha ao detokenizar o cartão",
+		ErrorCardBlocked:                    "Cartão está bloqueado",
+		ErrorCardCancelled:                  "Cartão está cancelado",
+		ErrorCardExpired:                    "Cartão está vencido",
+		ErrorInvalidCardStatusTransition:    "Transição de status do cartão não permitida",
+		ErrorCardAlreadyReplaced:            "Cartão já foi substituído",
+		ErrorFailedToUpdateCard:             "Falha ao atualizar o cartão",
+		ErrorFailedToUpdateCardControls:     "Falha ao atualizar os controles do cartão",
+		ErrorInvalidCardConstraints:         "locked_acceptor_id e amount_limit_cents são obrigatórios em cartões MERCHANT_LOCKED e não permitidos em cartões STANDARD",
+		ErrorCardNotReplaceable:             "Cartões virtuais não podem ser substituídos",
 	},
 }
 
@@ -0,0 +1,79 @@
+package i18n
+
+import "strings"
+
+const (
+	LabelStatementTitle   = "statement_title"
+	LabelAccount          = "account"
+	LabelPeriod           = "period"
+	LabelGeneratedAt      = "generated_at"
+	LabelOpeningBalance   = "opening_balance"
+	LabelClosingBalance   = "closing_balance"
+	LabelDate             = "date"
+	LabelTransactionId    = "transaction_id"
+	LabelType             = "type"
+	LabelDescription      = "description"
+	LabelAmount           = "amount"
+	LabelRunningBalance   = "running_balance"
+	LabelPage             = "page"
+	LabelTransactionTypes = "transaction_type_"
+)
+
+var labels = map[string]map[string]string{
+	"en-us": {
+		LabelStatementTitle:                    "Account statement",
+		LabelAccount:                           "Account",
+		LabelPeriod:                            "Period",
+		LabelGeneratedAt:                       "Generated at",
+		LabelOpeningBalance:                    "Opening balance",
+		LabelClosingBalance:                    "Closing balance",
+		LabelDate:                              "Date",
+		LabelTransactionId:                     "Transaction ID",
+		LabelType:                              "Type",
+		LabelDescription:                       "Description",
+		LabelAmount:                            "Amount",
+		LabelRunningBalance:                    "Balance",
+		LabelPage:                              "Page",
+		LabelTransactionTypes + "DEPOSIT":      "Deposit",
+		LabelTransactionTypes + "PURCHASE":     "Purchase",
+		LabelTransactionTypes + "REFUND":       "Refund",
+		LabelTransactionTypes + "CHARGE":       "Charge",
+		LabelTransactionTypes + "TRANSFER_OUT": "Transfer sent",
+		LabelTransactionTypes + "TRANSFER_IN":  "Transfer received",
+	},
+	"pt-br": {
+		LabelStatementTitle:                    "Extrato da conta",
+		LabelAccount:                           "Conta",
+		LabelPeriod:                            "Período",
+		LabelGeneratedAt:                       "Gerado em",
+		LabelOpeningBalance:                    "Saldo inicial",
+		LabelClosingBalance:                    "Saldo final",
+		LabelDate:                              "Data",
+		LabelTransactionId:                     "ID da transação",
+		LabelType:                              "Tipo",
+		LabelDescription:                       "Descrição",
+		LabelAmount:                            "Valor",
+		LabelRunningBalance:                    "Saldo",
+		LabelPage:                              "Página",
+		LabelTransactionTypes + "DEPOSIT":      "Depósito",
+		LabelTransactionTypes + "PURCHASE":     "Compra",
+		LabelTransactionTypes + "REFUND":       "Estorno",
+		LabelTransactionTypes + "CHARGE":       "Tarifa",
+		LabelTransactionTypes + "TRANSFER_OUT": "Transferência enviada",
+		LabelTransactionTypes + "TRANSFER_IN":  "Transferência recebida",
+	},
+}
+
+// GetLabel returns the text of a document label in lang, falling back to
+// English and then to the key itself.
+func GetLabel(lang, key string) string {
+	if messages, ok := labels[strings.ToLower(lang)]; ok {
+		if label, ok := messages[key]; ok {
+			return label
+		}
+	}
+	if label, ok := labels["en-us"][key]; ok {
+		return label
+	}
+	return key
+}
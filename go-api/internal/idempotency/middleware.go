@@ -0,0 +1,120 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"payment-gateway/go-api/internal/api"
+	"payment-gateway/go-api/internal/auth"
+	"payment-gateway/go-api/internal/i18n"
+)
+
+const (
+	HeaderKey      = "Idempotency-Key"
+	HeaderReplayed = "Idempotent-Replayed"
+	maxKeyLength   = 255
+)
+
+type Middleware struct {
+	service IdempotencyService
+}
+
+func NewMiddleware(service IdempotencyService) *Middleware {
+	return &Middleware{service: service}
+}
+
+// Wrap makes next idempotent for requests carrying an Idempotency-Key header.
+// The first response for a key is stored and replayed verbatim on retries;
+// server errors release the key so the client can try again. Keys belong to
+// the authenticated caller, so a response is only ever replayed to the
+// credentials that produced it. Wrap must run inside the auth middleware.
+func (m *Middleware) Wrap(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(HeaderKey)
+		if key == "" {
+			next(w, r)
+			return
+		}
+
+		lang := i18n.GetLangFromHeader(r)
+
+		if len(key) > maxKeyLength {
+			api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidIdempotencyKey))
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidRequestBody))
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		callerId := auth.CallerId(r.Context())
+		record, err := m.service.Begin(r.Context(), callerId, key, hashRequest(r, body))
+		switch {
+		case errors.Is(err, ErrKeyInProgress):
+			api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorIdempotencyKeyInProgress))
+			return
+		case errors.Is(err, ErrKeyReused):
+			api.WriteError(w, http.StatusUnprocessableEntity, i18n.GetErrorMessage(lang, i18n.ErrorIdempotencyKeyReused))
+			return
+		case err != nil:
+			api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorInternalServerError))
+			return
+		}
+
+		if record != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set(HeaderReplayed, "true")
+			w.WriteHeader(int(record.ResponseStatus.Int32))
+			w.Write(record.ResponseBody)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next(recorder, r)
+
+		// The response must be stored even if the client has already gone away.
+		ctx := context.WithoutCancel(r.Context())
+		if recorder.status >= http.StatusInternalServerError {
+			if err := m.service.Release(ctx, callerId, key); err != nil {
+				log.Printf("Failed to release idempotency key %s: %v", key, err)
+			}
+			return
+		}
+
+		if err := m.service.Complete(ctx, callerId, key, recorder.status, recorder.body.Bytes()); err != nil {
+			log.Printf("Failed to store response for idempotency key %s: %v", key, err)
+		}
+	}
+}
+
+func hashRequest(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,24 @@
+package idempotency
+
+import (
+	"payment-gateway/go-api/internal/repository"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type Module struct {
+	Middleware *Middleware
+	Service    IdempotencyService
+}
+
+func NewModule(db *sqlx.DB, ttl time.Duration) *Module {
+	repo := repository.NewIdempotencyKeyRepository(db)
+	service := NewIdempotencyService(repo, ttl)
+	middleware := NewMiddleware(service)
+
+	return &Module{
+		Middleware: middleware,
+		Service:    service,
+	}
+}
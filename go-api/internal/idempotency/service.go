@@ -0,0 +1,100 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"log"
+	"payment-gateway/go-api/internal/models"
+	"payment-gateway/go-api/internal/repository"
+	"time"
+)
+
+var (
+	// ErrKeyInProgress is returned when another request holding the same key has not finished yet.
+	ErrKeyInProgress = errors.New("idempotency key is being processed by another request")
+	// ErrKeyReused is returned when the key was already used with a different request payload.
+	ErrKeyReused = errors.New("idempotency key was used with a different request")
+)
+
+type IdempotencyService interface {
+	Begin(ctx context.Context, callerId, key, requestHash string) (*models.IdempotencyKey, error)
+	Complete(ctx context.Context, callerId, key string, status int, body []byte) error
+	Release(ctx context.Context, callerId, key string) error
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+type idempotencyServiceImpl struct {
+	repo repository.IdempotencyKeyRepository
+	ttl  time.Duration
+}
+
+func NewIdempotencyService(repo repository.IdempotencyKeyRepository, ttl time.Duration) *idempotencyServiceImpl {
+	return &idempotencyServiceImpl{repo: repo, ttl: ttl}
+}
+
+// Begin reserves the key of callerId for a new request. When the key has
+// already been completed with the same payload the stored record is returned
+// so the caller can replay it; a nil record means the caller owns the key and
+// must either Complete or Release it. Each caller has its own keys.
+func (s *idempotencyServiceImpl) Begin(ctx context.Context, callerId, key, requestHash string) (*models.IdempotencyKey, error) {
+	acquired, err := s.repo.Acquire(ctx, callerId, key, requestHash, s.ttl)
+	if err != nil {
+		return nil, err
+	}
+	if acquired {
+		return nil, nil
+	}
+
+	record, err := s.repo.GetByKey(ctx, callerId, key)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		// The owner released the key between our insert and select.
+		return nil, ErrKeyInProgress
+	}
+
+	if record.RequestHash != requestHash {
+		return nil, ErrKeyReused
+	}
+
+	if !record.IsCompleted() {
+		return nil, ErrKeyInProgress
+	}
+
+	return record, nil
+}
+
+func (s *idempotencyServiceImpl) Complete(ctx context.Context, callerId, key string, status int, body []byte) error {
+	return s.repo.SaveResponse(ctx, callerId, key, status, body)
+}
+
+func (s *idempotencyServiceImpl) Release(ctx context.Context, callerId, key string) error {
+	return s.repo.Delete(ctx, callerId, key)
+}
+
+func (s *idempotencyServiceImpl) PurgeExpired(ctx context.Context) (int64, error) {
+	return s.repo.DeleteExpired(ctx)
+}
+
+// RunJanitor periodically removes expired keys until ctx is cancelled.
+func RunJanitor(ctx context.Context, service IdempotencyService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := service.PurgeExpired(ctx)
+			if err != nil {
+				log.Printf("Failed to purge expired idempotency keys: %v", err)
+				continue
+			}
+			if purged > 0 {
+				log.Printf("Purged %d expired idempotency keys", purged)
+			}
+		}
+	}
+}
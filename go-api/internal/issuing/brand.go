@@ -0,0 +1,68 @@
+package issuing
+
+import (
+	"payment-gateway/go-api/internal/models"
+	"strconv"
+)
+
+// eloRanges are the six digit prefixes of Elo cards. Several of them start
+// with 4 or 5, so Elo is detected before Visa and Mastercard.
+var eloRanges = [][2]int{
+	{401178, 401179}, {431274, 431274}, {438935, 438935}, {451416, 451416},
+	{457393, 457393}, {457631, 457632}, {504175, 504175}, {506699, 506778},
+	{509000, 509999}, {627780, 627780}, {636297, 636297}, {636368, 636368},
+	{650031, 650033}, {650035, 650051}, {650405, 650439}, {650485, 650538},
+	{650541, 650598}, {650700, 650718}, {650720, 650727}, {650901, 650978},
+	{651652, 651679}, {655000, 655019}, {655021, 655058},
+}
+
+// DetectBrand returns the network of a card number from its first digits, or
+// models.CardBrandUnknown. Six digits are enough to tell every brand apart.
+func DetectBrand(number string) string {
+	if !isDigits(number) {
+		return models.CardBrandUnknown
+	}
+
+	if len(number) >= 6 {
+		bin := prefixNumber(number, 6)
+		for _, r := range eloRanges {
+			if bin >= r[0] && bin <= r[1] {
+				return models.CardBrandElo
+			}
+		}
+	}
+
+	switch {
+	case len(number) >= 2 && (number[:2] == "34" || number[:2] == "37"):
+		return models.CardBrandAmex
+	case len(number) >= 2 && prefixNumber(number, 2) >= 51 && prefixNumber(number, 2) <= 55:
+		return models.CardBrandMastercard
+	case len(number) >= 4 && prefixNumber(number, 4) >= 2221 && prefixNumber(number, 4) <= 2720:
+		return models.CardBrandMastercard
+	case number[0] == '4':
+		return models.CardBrandVisa
+	}
+	return models.CardBrandUnknown
+}
+
+// panLength is the length of the card numbers of brand.
+func panLength(brand string) int {
+	if brand == models.CardBrandAmex {
+		return 15
+	}
+	return 16
+}
+
+// cvcLength is the length of the security code of brand: Amex prints a four
+// digit code, the other networks three.
+func cvcLength(brand string) int {
+	if brand == models.CardBrandAmex {
+		return 4
+	}
+	return 3
+}
+
+func prefixNumber(number string, digits int) int {
+	value, _ := strconv.Atoi(number[:digits])
+	return value
+}
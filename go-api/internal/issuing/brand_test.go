@@ -0,0 +1,74 @@
+package issuing
+
+import (
+	"payment-gateway/go-api/internal/models"
+	"strconv"
+	"testing"
+)
+
+func TestDetectBrand(t *testing.T) {
+	tests := []struct {
+		number string
+		brand  string
+	}{
+		{"4111111111111111", models.CardBrandVisa},
+		{"401177", models.CardBrandVisa},
+		{"401180", models.CardBrandVisa},
+		{"4", models.CardBrandVisa},
+		{"510000", models.CardBrandMastercard},
+		{"559999", models.CardBrandMastercard},
+		{"500000", models.CardBrandUnknown},
+		{"560000", models.CardBrandUnknown},
+		{"2221000000000009", models.CardBrandMastercard},
+		{"2720990000000007", models.CardBrandMastercard},
+		{"2220990000000000", models.CardBrandUnknown},
+		{"2721000000000000", models.CardBrandUnknown},
+		{"340000", models.CardBrandAmex},
+		{"378282246310005", models.CardBrandAmex},
+		{"350000", models.CardBrandUnknown},
+		{"380000", models.CardBrandUnknown},
+		{"506722", models.CardBrandElo},
+		{"6362970000000000", models.CardBrandElo},
+		{"650034", models.CardBrandUnknown},
+		{"", models.CardBrandUnknown},
+		{"4111-1111", models.CardBrandUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			if got := DetectBrand(tt.number); got != tt.brand {
+				t.Errorf("DetectBrand(%q) = %s, want %s", tt.number, got, tt.brand)
+			}
+		})
+	}
+}
+
+// TestDetectBrandEloBoundaries checks both ends of every Elo range, and the
+// BINs just outside them, which fall back to the brand of their first digits.
+func TestDetectBrandEloBoundaries(t *testing.T) {
+	inElo := func(bin int) bool {
+		for _, r := range eloRanges {
+			if bin >= r[0] && bin <= r[1] {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, r := range eloRanges {
+		for _, bin := range []int{r[0], r[1]} {
+			if got := DetectBrand(strconv.Itoa(bin)); got != models.CardBrandElo {
+				t.Errorf("DetectBrand(%d) = %s, want %s", bin, got, models.CardBrandElo)
+			}
+		}
+
+		for _, bin := range []int{r[0] - 1, r[1] + 1} {
+			if inElo(bin) {
+				continue
+			}
+			if got := DetectBrand(strconv.Itoa(bin)); got == models.CardBrandElo {
+				t.Errorf("DetectBrand(%d) = %s, want a brand other than Elo", bin, got)
+			}
+		}
+	}
+}
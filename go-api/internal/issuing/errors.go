@@ -0,0 +1,8 @@
+package issuing
+
+import "errors"
+
+var (
+	ErrInvalidBINRange = errors.New("invalid bin range")
+	ErrBrandNotIssued  = errors.New("no bin range configured for brand")
+)
@@ -0,0 +1,144 @@
+// Package issuing generates the numbers of new cards: PANs drawn from the
+// configured BIN ranges with a valid Luhn check digit, a security code and an
+// expiry date.
+package issuing
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"payment-gateway/go-api/internal/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BINRange is an issuer prefix new card numbers are drawn from.
+type BINRange struct {
+	Prefix string
+	Length int
+	Brand  string
+}
+
+// ParseBINRanges reads ranges written as "<prefix>:<length>", such as
+// "453211:16". Prefixes are BINs of six to eight digits; the brand is detected
+// from the prefix and the length must be the one of that brand.
+func ParseBINRanges(entries []string) ([]BINRange, error) {
+	ranges := make([]BINRange, 0, len(entries))
+	for _, entry := range entries {
+		prefix, lengthValue, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok {
+			return nil, fmt.Errorf("%w %q: expected <prefix>:<length>", ErrInvalidBINRange, entry)
+		}
+		if !isDigits(prefix) || len(prefix) < 6 || len(prefix) > 8 {
+			return nil, fmt.Errorf("%w %q: prefix must be 6 to 8 digits", ErrInvalidBINRange, entry)
+		}
+
+		brand := DetectBrand(prefix)
+		if brand == models.CardBrandUnknown {
+			return nil, fmt.Errorf("%w %q: prefix is not a Visa, Mastercard, Elo or Amex BIN", ErrInvalidBINRange, entry)
+		}
+		length, err := strconv.Atoi(lengthValue)
+		if err != nil || length != panLength(brand) {
+			return nil, fmt.Errorf("%w %q: %s cards have %d digits", ErrInvalidBINRange, entry, brand, panLength(brand))
+		}
+
+		ranges = append(ranges, BINRange{Prefix: prefix, Length: length, Brand: brand})
+	}
+
+	if len(ranges) == 0 {
+		return nil, fmt.Errorf("%w: no ranges configured", ErrInvalidBINRange)
+	}
+	return ranges, nil
+}
+
+// IssuedCard holds the details of a new card. The PAN and CVC are only known
+// at issue time.
+type IssuedCard struct {
+	PAN         string
+	CVC         string
+	Brand       string
+	ExpiryMonth int
+	ExpiryYear  int
+}
+
+// LastFour returns the last four digits of the card number.
+func (c *IssuedCard) LastFour() string {
+	return c.PAN[len(c.PAN)-4:]
+}
+
+type Issuer struct {
+	ranges        []BINRange
+	validityYears int
+}
+
+func NewIssuer(ranges []BINRange, validityYears int) *Issuer {
+	return &Issuer{ranges: ranges, validityYears: validityYears}
+}
+
+// Issue generates a card of brand, or of the first configured range when brand
+// is empty. Cards expire at the end of the current month validityYears from
+// now.
+func (i *Issuer) Issue(brand string) (*IssuedCard, error) {
+	binRange, err := i.rangeFor(brand)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := randomDigits(binRange.Length - len(binRange.Prefix) - 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate card number: %w", err)
+	}
+	payload := binRange.Prefix + body
+
+	cvc, err := randomDigits(cvcLength(binRange.Brand))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate CVC: %w", err)
+	}
+
+	now := time.Now().UTC()
+	return &IssuedCard{
+		PAN:         payload + string(LuhnCheckDigit(payload)),
+		CVC:         cvc,
+		Brand:       binRange.Brand,
+		ExpiryMonth: int(now.Month()),
+		ExpiryYear:  now.Year() + i.validityYears,
+	}, nil
+}
+
+// rangeFor picks the range to issue brand from. When several ranges share the
+// brand one is chosen at random, spreading cards over the BINs.
+func (i *Issuer) rangeFor(brand string) (BINRange, error) {
+	if brand == "" {
+		return i.ranges[0], nil
+	}
+
+	var candidates []BINRange
+	for _, r := range i.ranges {
+		if r.Brand == brand {
+			candidates = append(candidates, r)
+		}
+	}
+	if len(candidates) == 0 {
+		return BINRange{}, ErrBrandNotIssued
+	}
+
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(candidates))))
+	if err != nil {
+		return BINRange{}, fmt.Errorf("failed to pick bin range: %w", err)
+	}
+	return candidates[n.Int64()], nil
+}
+
+// randomDigits returns n uniformly random decimal digits.
+func randomDigits(n int) (string, error) {
+	digits := make([]byte, n)
+	for j := range digits {
+		d, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		digits[j] = byte('0' + d.Int64())
+	}
+	return string(digits), nil
+}
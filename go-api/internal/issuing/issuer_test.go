@@ -0,0 +1,133 @@
+package issuing
+
+import (
+	"errors"
+	"payment-gateway/go-api/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+// defaultBINRanges are the ranges used when CARD_BIN_RANGES is not set.
+var defaultBINRanges = []string{"453211:16", "545454:16", "506722:16", "378282:15"}
+
+func TestParseBINRanges(t *testing.T) {
+	ranges, err := ParseBINRanges(defaultBINRanges)
+	if err != nil {
+		t.Fatalf("ParseBINRanges(%v) failed: %v", defaultBINRanges, err)
+	}
+
+	want := []BINRange{
+		{Prefix: "453211", Length: 16, Brand: models.CardBrandVisa},
+		{Prefix: "545454", Length: 16, Brand: models.CardBrandMastercard},
+		{Prefix: "506722", Length: 16, Brand: models.CardBrandElo},
+		{Prefix: "378282", Length: 15, Brand: models.CardBrandAmex},
+	}
+	if len(ranges) != len(want) {
+		t.Fatalf("ParseBINRanges returned %d ranges, want %d", len(ranges), len(want))
+	}
+	for i := range want {
+		if ranges[i] != want[i] {
+			t.Errorf("range %d = %+v, want %+v", i, ranges[i], want[i])
+		}
+	}
+}
+
+func TestParseBINRangesRejectsInvalidEntries(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []string
+	}{
+		{"no ranges", nil},
+		{"missing length", []string{"453211"}},
+		{"short prefix", []string{"45321:16"}},
+		{"long prefix", []string{"453211000:16"}},
+		{"non digit prefix", []string{"4532ab:16"}},
+		{"unknown brand", []string{"123456:16"}},
+		{"non numeric length", []string{"453211:sixteen"}},
+		{"visa with amex length", []string{"453211:15"}},
+		{"amex with sixteen digits", []string{"378282:16"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseBINRanges(tt.entries); !errors.Is(err, ErrInvalidBINRange) {
+				t.Errorf("ParseBINRanges(%v) error = %v, want %v", tt.entries, err, ErrInvalidBINRange)
+			}
+		})
+	}
+}
+
+func TestIssueFromEveryRange(t *testing.T) {
+	ranges, err := ParseBINRanges(defaultBINRanges)
+	if err != nil {
+		t.Fatalf("ParseBINRanges(%v) failed: %v", defaultBINRanges, err)
+	}
+
+	for _, binRange := range ranges {
+		t.Run(binRange.Prefix, func(t *testing.T) {
+			issuer := NewIssuer([]BINRange{binRange}, 3)
+			card, err := issuer.Issue(binRange.Brand)
+			if err != nil {
+				t.Fatalf("Issue(%s) failed: %v", binRange.Brand, err)
+			}
+
+			if !strings.HasPrefix(card.PAN, binRange.Prefix) {
+				t.Errorf("PAN %s does not start with %s", card.PAN, binRange.Prefix)
+			}
+			if len(card.PAN) != binRange.Length {
+				t.Errorf("PAN %s has %d digits, want %d", card.PAN, len(card.PAN), binRange.Length)
+			}
+			if !ValidLuhn(card.PAN) {
+				t.Errorf("PAN %s does not pass the Luhn check", card.PAN)
+			}
+			if got := DetectBrand(card.PAN); got != binRange.Brand {
+				t.Errorf("DetectBrand(%s) = %s, want %s", card.PAN, got, binRange.Brand)
+			}
+			if card.Brand != binRange.Brand {
+				t.Errorf("card brand = %s, want %s", card.Brand, binRange.Brand)
+			}
+			if len(card.CVC) != cvcLength(binRange.Brand) || !isDigits(card.CVC) {
+				t.Errorf("CVC %q is not %d digits", card.CVC, cvcLength(binRange.Brand))
+			}
+			if card.LastFour() != card.PAN[len(card.PAN)-4:] {
+				t.Errorf("LastFour() = %s, want the last digits of %s", card.LastFour(), card.PAN)
+			}
+
+			now := time.Now().UTC()
+			if card.ExpiryMonth != int(now.Month()) || card.ExpiryYear != now.Year()+3 {
+				t.Errorf("expiry = %02d/%d, want %02d/%d", card.ExpiryMonth, card.ExpiryYear, int(now.Month()), now.Year()+3)
+			}
+		})
+	}
+}
+
+func TestIssueBrandSelection(t *testing.T) {
+	ranges, err := ParseBINRanges([]string{"453211:16", "453212:16", "378282:15"})
+	if err != nil {
+		t.Fatalf("ParseBINRanges failed: %v", err)
+	}
+	issuer := NewIssuer(ranges, 3)
+
+	card, err := issuer.Issue("")
+	if err != nil {
+		t.Fatalf("Issue without brand failed: %v", err)
+	}
+	if !strings.HasPrefix(card.PAN, "453211") {
+		t.Errorf("Issue without brand drew %s, want a number of the first range", card.PAN)
+	}
+
+	for i := 0; i < 20; i++ {
+		card, err := issuer.Issue(models.CardBrandVisa)
+		if err != nil {
+			t.Fatalf("Issue(%s) failed: %v", models.CardBrandVisa, err)
+		}
+		if !strings.HasPrefix(card.PAN, "453211") && !strings.HasPrefix(card.PAN, "453212") {
+			t.Errorf("Issue(%s) drew %s outside the Visa ranges", models.CardBrandVisa, card.PAN)
+		}
+	}
+
+	if _, err := issuer.Issue(models.CardBrandElo); !errors.Is(err, ErrBrandNotIssued) {
+		t.Errorf("Issue(%s) error = %v, want %v", models.CardBrandElo, err, ErrBrandNotIssued)
+	}
+}
@@ -0,0 +1,38 @@
+package issuing
+
+// LuhnCheckDigit returns the digit that, appended to payload, makes the number
+// pass the Luhn check. payload must only contain digits.
+func LuhnCheckDigit(payload string) byte {
+	sum := 0
+	// Walking right to left, the digit next to the check digit is doubled
+	// first.
+	for i := len(payload) - 1; i >= 0; i -= 2 {
+		doubled := int(payload[i]-'0') * 2
+		if doubled > 9 {
+			doubled -= 9
+		}
+		sum += doubled
+		if i > 0 {
+			sum += int(payload[i-1] - '0')
+		}
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// ValidLuhn reports whether number is made of digits and ends with a valid
+// Luhn check digit.
+func ValidLuhn(number string) bool {
+	if len(number) < 2 || !isDigits(number) {
+		return false
+	}
+	return LuhnCheckDigit(number[:len(number)-1]) == number[len(number)-1]
+}
+
+func isDigits(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
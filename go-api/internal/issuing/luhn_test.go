@@ -0,0 +1,60 @@
+package issuing
+
+import "testing"
+
+func TestValidLuhn(t *testing.T) {
+	tests := []struct {
+		number string
+		valid  bool
+	}{
+		{"4111111111111111", true},
+		{"4012888888881881", true},
+		{"5555555555554444", true},
+		{"5105105105105100", true},
+		{"378282246310005", true},
+		{"6011111111111117", true},
+		{"79927398713", true},
+		{"4111111111111112", false},
+		{"5555555555554445", false},
+		{"378282246310006", false},
+		{"79927398710", false},
+		{"4111 1111 1111 1111", false},
+		{"4111-1111-1111-1111", false},
+		{"abcdefghijklmnop", false},
+		{"0", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			if got := ValidLuhn(tt.number); got != tt.valid {
+				t.Errorf("ValidLuhn(%q) = %v, want %v", tt.number, got, tt.valid)
+			}
+		})
+	}
+}
+
+func TestLuhnCheckDigit(t *testing.T) {
+	tests := []struct {
+		payload string
+		digit   byte
+	}{
+		{"411111111111111", '1'},
+		{"555555555555444", '4'},
+		{"37828224631000", '5'},
+		{"7992739871", '3'},
+		{"0", '0'},
+		{"1", '8'},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.payload, func(t *testing.T) {
+			if got := LuhnCheckDigit(tt.payload); got != tt.digit {
+				t.Errorf("LuhnCheckDigit(%q) = %c, want %c", tt.payload, got, tt.digit)
+			}
+			if !ValidLuhn(tt.payload + string(tt.digit)) {
+				t.Errorf("%q with its check digit does not pass the Luhn check", tt.payload)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package ledger
+
+import "errors"
+
+var (
+	ErrAccountNotFound    = errors.New("account not found")
+	ErrUnbalancedJournal  = errors.New("journal entry debits and credits do not balance")
+	ErrEmptyJournal       = errors.New("journal entry has no postings")
+	ErrNonPositivePosting = errors.New("posting amount must be positive")
+)
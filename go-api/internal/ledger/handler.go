@@ -0,0 +1,71 @@
+package ledger
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"payment-gateway/go-api/internal/api"
+	"payment-gateway/go-api/internal/i18n"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+const maxLedgerPageSize = 100
+
+type LedgerHandler struct {
+	service LedgerService
+}
+
+func NewLedgerHandler(service LedgerService) *LedgerHandler {
+	return &LedgerHandler{service: service}
+}
+
+// @ID get-account-ledger
+// @Summary Get the ledger postings of an account
+// @Description Returns the postings written against an account, newest first, with pagination support.
+// @Description Every transaction writes a balanced journal entry when it is created. When the processor rejects it, a REVERSAL entry cancels those postings, so the postings of an account add up to its balance plus its PENDING transactions.
+// @Tags accounts
+// @Produce json
+// @Param accountId path string true "Account ID (UUID)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page (max 100)" default(20)
+// @Success 200 {array} models.AccountPosting
+// @Failure 400 {object} api.APIError "Pagination limit exceeded"
+// @Failure 404 {object} api.APIError "Account not found"
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Security BearerAuth
+// @Router /accounts/{accountId}/ledger [get]
+func (h *LedgerHandler) GetAccountLedger(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+	accountId := mux.Vars(r)["accountId"]
+
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit < 1 {
+		limit = 20
+	}
+
+	if limit > maxLedgerPageSize {
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.PaginationLimitExceeded))
+		return
+	}
+
+	postings, err := h.service.GetAccountPostings(r.Context(), accountId, page, limit)
+	if err != nil {
+		if errors.Is(err, ErrAccountNotFound) {
+			api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorAccountNotFound))
+			return
+		}
+		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorFetchingLedger))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(postings)
+}
@@ -0,0 +1,79 @@
+package ledger
+
+import (
+	"database/sql"
+	"payment-gateway/go-api/internal/models"
+)
+
+// journal is a journal entry being built. Postings are added with debit and
+// credit and checked by validate before anything is written.
+type journal struct {
+	Entry    models.JournalEntry
+	Postings []*models.Posting
+}
+
+func newJournal(transactionId, kind string) *journal {
+	return &journal{Entry: models.JournalEntry{TransactionId: transactionId, Kind: kind}}
+}
+
+// customerAccount and systemAccount build the owner of a posting.
+type owner struct {
+	accountId     sql.NullString
+	systemAccount sql.NullString
+}
+
+func customerAccount(id string) owner {
+	return owner{accountId: sql.NullString{String: id, Valid: true}}
+}
+
+func systemAccount(name string) owner {
+	return owner{systemAccount: sql.NullString{String: name, Valid: true}}
+}
+
+func (j *journal) add(o owner, direction string, amountCents int64) *journal {
+	j.Postings = append(j.Postings, &models.Posting{
+		AccountId:     o.accountId,
+		SystemAccount: o.systemAccount,
+		Direction:     direction,
+		AmountCents:   amountCents,
+	})
+	return j
+}
+
+func (j *journal) debit(o owner, amountCents int64) *journal {
+	return j.add(o, models.PostingDirectionDebit, amountCents)
+}
+
+func (j *journal) credit(o owner, amountCents int64) *journal {
+	return j.add(o, models.PostingDirectionCredit, amountCents)
+}
+
+// move debits from and credits to with the same amount.
+func (j *journal) move(from, to owner, amountCents int64) *journal {
+	return j.debit(from, amountCents).credit(to, amountCents)
+}
+
+// validate enforces the double-entry invariant: every posting is positive and
+// the debits of the entry equal its credits.
+func (j *journal) validate() error {
+	if len(j.Postings) == 0 {
+		return ErrEmptyJournal
+	}
+
+	var total int64
+	for _, posting := range j.Postings {
+		if posting.AmountCents <= 0 {
+			return ErrNonPositivePosting
+		}
+		if posting.Direction == models.PostingDirectionDebit {
+			total += posting.AmountCents
+		} else {
+			total -= posting.AmountCents
+		}
+	}
+
+	if total != 0 {
+		return ErrUnbalancedJournal
+	}
+	return nil
+}
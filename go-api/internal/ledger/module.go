@@ -0,0 +1,24 @@
+package ledger
+
+import (
+	"payment-gateway/go-api/internal/account"
+	"payment-gateway/go-api/internal/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type Module struct {
+	Handler *LedgerHandler
+	Service LedgerService
+}
+
+func NewModule(db *sqlx.DB, accountService account.AccountService) *Module {
+	repo := repository.NewLedgerRepository(db)
+	service := NewLedgerService(repo, accountService)
+	handler := NewLedgerHandler(service)
+
+	return &Module{
+		Handler: handler,
+		Service: service,
+	}
+}
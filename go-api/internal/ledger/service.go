@@ -0,0 +1,125 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"payment-gateway/go-api/internal/account"
+	"payment-gateway/go-api/internal/models"
+	"payment-gateway/go-api/internal/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type LedgerService interface {
+	RecordTransaction(ctx context.Context, dbTx *sqlx.Tx, transaction *models.Transaction, original *models.Transaction) error
+	RecordTransfer(ctx context.Context, dbTx *sqlx.Tx, debit *models.Transaction, destinationAccountId string) error
+	RecordCaptureRelease(ctx context.Context, dbTx *sqlx.Tx, transaction *models.Transaction, releasedCents int64) error
+	RecordHoldRelease(ctx context.Context, dbTx *sqlx.Tx, transaction *models.Transaction) error
+	GetAccountPostings(ctx context.Context, accountId string, page, limit int) ([]*models.AccountPosting, error)
+}
+
+type ledgerServiceImpl struct {
+	repo           repository.LedgerRepository
+	accountService account.AccountService
+}
+
+func NewLedgerService(repo repository.LedgerRepository, accountService account.AccountService) *ledgerServiceImpl {
+	return &ledgerServiceImpl{repo: repo, accountService: accountService}
+}
+
+// RecordTransaction writes the journal entry of a newly created transaction in
+// dbTx. original is the refunded transaction and is only used for REFUND.
+// Transfers are recorded with RecordTransfer.
+func (s *ledgerServiceImpl) RecordTransaction(ctx context.Context, dbTx *sqlx.Tx, transaction *models.Transaction, original *models.Transaction) error {
+	customer := customerAccount(transaction.AccountId)
+	amount := transaction.AmountCents
+	entry := newJournal(transaction.ID, transaction.Type)
+
+	switch transaction.Type {
+	case models.TransactionTypeDeposit:
+		entry.move(systemAccount(models.SystemAccountSettlement), customer, amount)
+	case models.TransactionTypePurchase:
+		entry.move(customer, systemAccount(models.SystemAccountSettlement), amount)
+	case models.TransactionTypeCharge:
+		entry.move(customer, systemAccount(models.SystemAccountFees), amount)
+	case models.TransactionTypeRefund:
+		if original == nil {
+			return fmt.Errorf("refund %s recorded without its original transaction", transaction.ID)
+		}
+		switch original.Type {
+		case models.TransactionTypeDeposit:
+			entry.move(customer, systemAccount(models.SystemAccountSettlement), amount)
+		case models.TransactionTypeCharge:
+			entry.move(systemAccount(models.SystemAccountFees), customer, amount)
+		default:
+			entry.move(systemAccount(models.SystemAccountSettlement), customer, amount)
+		}
+	default:
+		return fmt.Errorf("no ledger mapping for transaction type %s", transaction.Type)
+	}
+
+	return s.write(ctx, dbTx, entry)
+}
+
+// RecordTransfer writes a single entry for both legs of a transfer, attached to
+// the TRANSFER_OUT leg.
+func (s *ledgerServiceImpl) RecordTransfer(ctx context.Context, dbTx *sqlx.Tx, debit *models.Transaction, destinationAccountId string) error {
+	entry := newJournal(debit.ID, debit.Type).
+		move(customerAccount(debit.AccountId), customerAccount(destinationAccountId), debit.AmountCents)
+
+	return s.write(ctx, dbTx, entry)
+}
+
+// RecordCaptureRelease gives back to the customer the part of an authorization
+// that was not captured.
+func (s *ledgerServiceImpl) RecordCaptureRelease(ctx context.Context, dbTx *sqlx.Tx, transaction *models.Transaction, releasedCents int64) error {
+	if releasedCents <= 0 {
+		return nil
+	}
+
+	entry := newJournal(transaction.ID, models.JournalKindCaptureRelease).
+		move(systemAccount(models.SystemAccountSettlement), customerAccount(transaction.AccountId), releasedCents)
+
+	return s.write(ctx, dbTx, entry)
+}
+
+// RecordHoldRelease gives back to the customer the whole authorization of a
+// purchase that was voided or expired without being captured.
+func (s *ledgerServiceImpl) RecordHoldRelease(ctx context.Context, dbTx *sqlx.Tx, transaction *models.Transaction) error {
+	if !transaction.AuthorizedAmountCents.Valid || transaction.AuthorizedAmountCents.Int64 <= 0 {
+		return nil
+	}
+
+	entry := newJournal(transaction.ID, models.JournalKindHoldRelease).
+		move(systemAccount(models.SystemAccountSettlement), customerAccount(transaction.AccountId), transaction.AuthorizedAmountCents.Int64)
+
+	return s.write(ctx, dbTx, entry)
+}
+
+func (s *ledgerServiceImpl) write(ctx context.Context, dbTx *sqlx.Tx, entry *journal) error {
+	if err := entry.validate(); err != nil {
+		return fmt.Errorf("invalid journal entry for transaction %s: %w", entry.Entry.TransactionId, err)
+	}
+
+	return s.repo.CreateJournalEntry(ctx, dbTx, &entry.Entry, entry.Postings)
+}
+
+func (s *ledgerServiceImpl) GetAccountPostings(ctx context.Context, accountId string, page, limit int) ([]*models.AccountPosting, error) {
+	existing, err := s.accountService.GetAccountById(ctx, accountId)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrAccountNotFound
+	}
+
+	postings, err := s.repo.GetPostingsByAccountId(ctx, accountId, page, limit)
+	if err != nil {
+		return nil, err
+	}
+	if postings == nil {
+		postings = make([]*models.AccountPosting, 0)
+	}
+
+	return postings, nil
+}
@@ -0,0 +1,7 @@
+package dto
+
+type CreateMerchantRequest struct {
+	// @Description Display name of the merchant.
+	// @Example Acme Store
+	Name string `json:"name" validate:"required,min=1,max=100"`
+}
@@ -0,0 +1,8 @@
+package merchant
+
+import "errors"
+
+var (
+	ErrMerchantNotFound         = errors.New("merchant not found")
+	ErrPlatformOperatorRequired = errors.New("only platform operators can manage merchants")
+)
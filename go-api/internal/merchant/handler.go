@@ -0,0 +1,133 @@
+package merchant
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"payment-gateway/go-api/internal/api"
+	"payment-gateway/go-api/internal/i18n"
+	"payment-gateway/go-api/internal/merchant/dto"
+	"payment-gateway/go-api/internal/models"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+type MerchantHandler struct {
+	service  MerchantService
+	validate *validator.Validate
+}
+
+func NewMerchantHandler(service MerchantService) *MerchantHandler {
+	return &MerchantHandler{
+		service:  service,
+		validate: validator.New(),
+	}
+}
+
+// @Summary Create a merchant
+// @Description Onboards a new merchant. Create its first operator or API key with the returned merchant id. Only platform operators, whose credentials are not bound to a merchant, can create merchants.
+// @Tags merchants
+// @Accept json
+// @Produce json
+// @Param merchant body dto.CreateMerchantRequest true "Merchant data"
+// @Success 201 {object} models.Merchant
+// @Failure 400 {object} api.APIError "Invalid request body"
+// @Failure 401 {object} api.APIError "Missing or invalid credentials"
+// @Failure 403 {object} api.APIError "Caller is not a platform operator"
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Security BearerAuth
+// @Router /merchants [post]
+func (h *MerchantHandler) CreateMerchant(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+
+	var req dto.CreateMerchantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidRequestBody))
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		api.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	merchant, err := h.service.CreateMerchant(r.Context(), req)
+	if err != nil {
+		writeMerchantError(w, lang, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(merchant)
+}
+
+// @Summary List merchants
+// @Description Lists the merchants the caller can reach, oldest first: every merchant for platform operators, otherwise only the caller's own.
+// @Tags merchants
+// @Produce json
+// @Success 200 {array} models.Merchant
+// @Failure 401 {object} api.APIError "Missing or invalid credentials"
+// @Failure 403 {object} api.APIError "Insufficient permissions"
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Security BearerAuth
+// @Router /merchants [get]
+func (h *MerchantHandler) ListMerchants(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+
+	merchants, err := h.service.ListMerchants(r.Context())
+	if err != nil {
+		writeMerchantError(w, lang, err)
+		return
+	}
+	if merchants == nil {
+		merchants = make([]*models.Merchant, 0)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(merchants)
+}
+
+// @Summary Get a merchant
+// @Description Returns a merchant the caller can reach.
+// @Tags merchants
+// @Produce json
+// @Param merchantId path string true "Merchant ID"
+// @Success 200 {object} models.Merchant
+// @Failure 400 {object} api.APIError "Invalid merchant ID"
+// @Failure 401 {object} api.APIError "Missing or invalid credentials"
+// @Failure 403 {object} api.APIError "Insufficient permissions"
+// @Failure 404 {object} api.APIError "Merchant not found"
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Security BearerAuth
+// @Router /merchants/{merchantId} [get]
+func (h *MerchantHandler) GetMerchantById(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+
+	merchantId := mux.Vars(r)["merchantId"]
+	if err := h.validate.Var(merchantId, "uuid"); err != nil {
+		api.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	merchant, err := h.service.GetMerchantById(r.Context(), merchantId)
+	if err != nil {
+		writeMerchantError(w, lang, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(merchant)
+}
+
+func writeMerchantError(w http.ResponseWriter, lang string, err error) {
+	switch {
+	case errors.Is(err, ErrMerchantNotFound):
+		api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorMerchantNotFound))
+	case errors.Is(err, ErrPlatformOperatorRequired):
+		api.WriteError(w, http.StatusForbidden, i18n.GetErrorMessage(lang, i18n.ErrorPlatformOperatorRequired))
+	default:
+		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorInternalServerError))
+	}
+}
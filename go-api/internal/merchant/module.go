@@ -0,0 +1,23 @@
+package merchant
+
+import (
+	"payment-gateway/go-api/internal/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type Module struct {
+	Handler *MerchantHandler
+	Service MerchantService
+}
+
+func NewModule(db *sqlx.DB) *Module {
+	repo := repository.NewMerchantRepository(db)
+	service := NewMerchantService(repo)
+	handler := NewMerchantHandler(service)
+
+	return &Module{
+		Handler: handler,
+		Service: service,
+	}
+}
@@ -0,0 +1,55 @@
+package merchant
+
+import (
+	"context"
+	"payment-gateway/go-api/internal/merchant/dto"
+	"payment-gateway/go-api/internal/models"
+	"payment-gateway/go-api/internal/repository"
+	"payment-gateway/go-api/internal/tenant"
+)
+
+type MerchantService interface {
+	CreateMerchant(ctx context.Context, req dto.CreateMerchantRequest) (*models.Merchant, error)
+	GetMerchantById(ctx context.Context, id string) (*models.Merchant, error)
+	ListMerchants(ctx context.Context) ([]*models.Merchant, error)
+}
+
+type merchantServiceImpl struct {
+	repo repository.MerchantRepository
+}
+
+func NewMerchantService(repo repository.MerchantRepository) *merchantServiceImpl {
+	return &merchantServiceImpl{repo: repo}
+}
+
+// CreateMerchant onboards a new tenant. Only callers reaching every merchant,
+// that is platform operators, may create one.
+func (s *merchantServiceImpl) CreateMerchant(ctx context.Context, req dto.CreateMerchantRequest) (*models.Merchant, error) {
+	if scope, _ := tenant.FromContext(ctx); !scope.All {
+		return nil, ErrPlatformOperatorRequired
+	}
+
+	merchant := &models.Merchant{Name: req.Name}
+	if err := s.repo.CreateMerchant(ctx, merchant); err != nil {
+		return nil, err
+	}
+
+	return merchant, nil
+}
+
+// GetMerchantById returns ErrMerchantNotFound for merchants that do not exist
+// or that the caller cannot reach.
+func (s *merchantServiceImpl) GetMerchantById(ctx context.Context, id string) (*models.Merchant, error) {
+	merchant, err := s.repo.GetMerchantById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if merchant == nil {
+		return nil, ErrMerchantNotFound
+	}
+	return merchant, nil
+}
+
+func (s *merchantServiceImpl) ListMerchants(ctx context.Context) ([]*models.Merchant, error) {
+	return s.repo.ListMerchants(ctx)
+}
@@ -1,5 +1,17 @@
 package models
 
+import (
+	"database/sql"
+
+	"github.com/jmoiron/sqlx/types"
+)
+
+const (
+	AccountStatusActive = "ACTIVE"
+	AccountStatusFrozen = "FROZEN"
+	AccountStatusClosed = "CLOSED"
+)
+
 // Account represents a user account in the system.
 type Account struct {
 	// @Description Unique identifier of the account (UUID).
@@ -7,12 +19,35 @@ type Account struct {
 	// @Example 550e8400-e29b-41d4-a716-446655440000
 	ID string `json:"id" db:"id"`
 
-	// @Description Unique username chosen by the account owner. Must be unique across the system.
+	// @Description Merchant the account belongs to (UUID).
+	// @Format uuid
+	MerchantId string `json:"merchant_id" db:"merchant_id"`
+
+	// @Description Unique username chosen by the account owner. Unique among the merchant's accounts that are not deleted, compared case-insensitively.
 	// @MinLength 3
 	// @MaxLength 32
 	// @Example charlie
 	Username string `json:"username" db:"username"`
 
+	PasswordHash sql.NullString `json:"-" db:"password_hash"`
+
+	// @Description Lifecycle status of the account. Only ACTIVE accounts can transact or issue cards.
+	// @Enum ACTIVE,FROZEN,CLOSED
+	// @Example ACTIVE
+	Status string `json:"status" db:"status"`
+
+	// @Description Reason given for the last status change, if any.
+	// @Example Suspicious activity reported by the owner
+	StatusReason sql.NullString `json:"status_reason" db:"status_reason" swaggertype:"string" extensions:"x-nullable"`
+
+	// @Description Free-form JSON object attached to the account by the client.
+	Metadata types.JSONText `json:"metadata" db:"metadata" swaggertype:"object"`
+
+	// @Description Timestamp when the account was soft deleted (UTC, RFC3339 format). Null while the account is not deleted.
+	// @Format date-time
+	// @Example 2025-09-22T19:15:24.526505Z
+	DeletedAt *string `json:"deleted_at" db:"deleted_at" extensions:"x-nullable"`
+
 	// @Description Timestamp when the account was created (UTC, RFC3339 format).
 	// @Format date-time
 	// @Example 2025-09-22T19:15:24.526505Z
@@ -23,3 +58,9 @@ type Account struct {
 	// @Example 2025-09-22T19:15:24.526505Z
 	UpdatedAt string `json:"updated_at" db:"updated_at"`
 }
+
+// IsClosed reports whether the account was closed or deleted. Its API keys and
+// sessions are refused from then on.
+func (a *Account) IsClosed() bool {
+	return a.DeletedAt != nil || a.Status == AccountStatusClosed
+}
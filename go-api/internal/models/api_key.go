@@ -0,0 +1,138 @@
+package models
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+const (
+	APIKeyOwnerAccount  = "ACCOUNT"
+	APIKeyOwnerOperator = "OPERATOR"
+)
+
+// Scopes grant access to groups of routes. ScopeAdmin grants every scope.
+// ScopeCardsDetokenize reveals full card numbers and is not implied by
+// ScopeCardsRead.
+const (
+	ScopeAccountsRead      = "accounts:read"
+	ScopeAccountsWrite     = "accounts:write"
+	ScopeCardsRead         = "cards:read"
+	ScopeCardsWrite        = "cards:write"
+	ScopeCardsDetokenize   = "cards:detokenize"
+	ScopeTransactionsRead  = "transactions:read"
+	ScopeTransactionsWrite = "transactions:write"
+	ScopeAdmin             = "admin"
+)
+
+// Operator-only scopes. Operator roles grant them through the policy table;
+// keys tied to an account can never hold them.
+const (
+	ScopeTransactionsRefund = "transactions:refund"
+	ScopeAccountsFreeze     = "accounts:freeze"
+	ScopeAccountsClose      = "accounts:close"
+	ScopeOperatorsManage    = "operators:manage"
+	ScopeAuditRead          = "audit:read"
+)
+
+var scopes = []string{
+	ScopeAccountsRead,
+	ScopeAccountsWrite,
+	ScopeCardsRead,
+	ScopeCardsWrite,
+	ScopeCardsDetokenize,
+	ScopeTransactionsRead,
+	ScopeTransactionsWrite,
+	ScopeAdmin,
+}
+
+var operatorScopes = []string{
+	ScopeTransactionsRefund,
+	ScopeAccountsFreeze,
+	ScopeAccountsClose,
+	ScopeOperatorsManage,
+	ScopeAuditRead,
+	ScopeAdmin,
+}
+
+func IsScope(scope string) bool {
+	return contains(scopes, scope) || contains(operatorScopes, scope)
+}
+
+// IsOperatorScope reports whether scope may only be granted to operators.
+func IsOperatorScope(scope string) bool {
+	return contains(operatorScopes, scope)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey is a credential tied to an account or to an operator. Only the hash
+// of the key is stored; the prefix identifies it in listings and logs.
+type APIKey struct {
+	// @Description Unique identifier of the key (UUID).
+	// @Format uuid
+	ID string `json:"id" db:"id"`
+
+	// @Description Who the key acts for. ACCOUNT keys can only reach their own account.
+	// @Enum ACCOUNT,OPERATOR
+	OwnerType string `json:"owner_type" db:"owner_type"`
+
+	// @Description Account the key is tied to. Null for OPERATOR keys.
+	// @Format uuid
+	AccountId sql.NullString `json:"account_id" db:"account_id" swaggertype:"string" extensions:"x-nullable"`
+
+	// @Description Merchant the key is limited to. Null for platform keys, which reach every merchant.
+	// @Format uuid
+	MerchantId sql.NullString `json:"merchant_id" db:"merchant_id" swaggertype:"string" extensions:"x-nullable"`
+
+	// @Description Operator the key is tied to. Null for ACCOUNT keys and for operator keys not tied to a person, such as the bootstrap key.
+	// @Format uuid
+	OperatorId sql.NullString `json:"operator_id" db:"operator_id" swaggertype:"string" extensions:"x-nullable"`
+
+	// @Description Human readable label for the key.
+	// @Example checkout backend
+	Name string `json:"name" db:"name"`
+
+	// @Description Non-secret start of the key, shown to tell keys apart.
+	// @Example pgk_3f9a1c2e
+	Prefix string `json:"prefix" db:"prefix"`
+
+	KeyHash string `json:"-" db:"key_hash"`
+
+	// @Description Scopes granted to the key.
+	// @Example ["transactions:write","cards:read"]
+	Scopes pq.StringArray `json:"scopes" db:"scopes" swaggertype:"array,string"`
+
+	// @Description Timestamp when the key was created (UTC, RFC3339 format).
+	// @Format date-time
+	CreatedAt string `json:"created_at" db:"created_at"`
+
+	// @Description Timestamp of the last rotation, if any.
+	// @Format date-time
+	RotatedAt *string `json:"rotated_at" db:"rotated_at" extensions:"x-nullable"`
+
+	// @Description Timestamp of the last authenticated request made with the key.
+	// @Format date-time
+	LastUsedAt *string `json:"last_used_at" db:"last_used_at" extensions:"x-nullable"`
+
+	// @Description Timestamp when the key was revoked. Revoked keys are rejected.
+	// @Format date-time
+	RevokedAt *string `json:"revoked_at" db:"revoked_at" extensions:"x-nullable"`
+}
+
+// HasScope reports whether the key grants scope, directly or through admin.
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Intervals accepted by the balance history.
+const (
+	BalanceIntervalHour  = "hour"
+	BalanceIntervalDay   = "day"
+	BalanceIntervalWeek  = "week"
+	BalanceIntervalMonth = "month"
+)
+
+func IsBalanceInterval(interval string) bool {
+	switch interval {
+	case BalanceIntervalHour, BalanceIntervalDay, BalanceIntervalWeek, BalanceIntervalMonth:
+		return true
+	}
+	return false
+}
+
+// BalanceMovement is the net effect of the approved transactions of one
+// history period on the account balance.
+type BalanceMovement struct {
+	PeriodStart    time.Time `db:"period_start"`
+	NetChangeCents int64     `db:"net_change_cents"`
+}
@@ -1,5 +1,39 @@
 package models
 
+import "database/sql"
+
+// Card brands, detected from the first digits of the card number. Cards issued
+// before brands were recorded are UNKNOWN.
+const (
+	CardBrandVisa       = "VISA"
+	CardBrandMastercard = "MASTERCARD"
+	CardBrandElo        = "ELO"
+	CardBrandAmex       = "AMEX"
+	CardBrandUnknown    = "UNKNOWN"
+)
+
+// Card kinds. SINGLE_USE and MERCHANT_LOCKED are virtual cards that only make
+// purchases: a single-use card is cancelled once its purchase is approved, and a
+// merchant-locked card only buys from one merchant, up to an amount ceiling.
+const (
+	CardKindStandard       = "STANDARD"
+	CardKindSingleUse      = "SINGLE_USE"
+	CardKindMerchantLocked = "MERCHANT_LOCKED"
+)
+
+// Card statuses. ACTIVE and BLOCKED cards can change status; CANCELLED and
+// EXPIRED are final. Only ACTIVE cards can transact.
+const (
+	CardStatusActive    = "ACTIVE"
+	CardStatusBlocked   = "BLOCKED"
+	CardStatusCancelled = "CANCELLED"
+	CardStatusExpired   = "EXPIRED"
+)
+
+// CardStatusReasonSingleUseCardUsed is the status_reason of a SINGLE_USE card
+// cancelled because its purchase was approved.
+const CardStatusReasonSingleUseCardUsed = "Single-use card used"
+
 // Card represents a payment card linked to an account.
 type Card struct {
 	// @Description Unique identifier of the card (UUID).
@@ -12,9 +46,12 @@ type Card struct {
 	// @Example 550e8400-e29b-41d4-a716-446655440000
 	AccountId string `json:"account_id" db:"account_id"`
 
-	// @Description Irreversible token representing the card. Used internally instead of raw card numbers.
-	// @Format hash
-	// @Example 5b7c16af7278094cd14bd041079111ed00fa832c8a460d8e3f40156408d99475
+	// @Description Merchant of the card's account (UUID).
+	// @Format uuid
+	MerchantId string `json:"merchant_id" db:"merchant_id"`
+
+	// @Description Opaque token the card number is held under in the card vault. Used instead of raw card numbers. Cards issued before the vault carry a one-way hash instead.
+	// @Example tok_Xb3kQ9vN2mR7tL1pW8cF5hJ0aZ4yE6sD
 	CardToken string `json:"card_token" db:"card_token"`
 
 	// @Description Last four digits of the card number, useful for display/identification.
@@ -23,8 +60,60 @@ type Card struct {
 	// @Example 8995
 	LastFourDigits string `json:"last_four_digits" db:"last_four_digits"`
 
+	// @Description Card network, detected from the card number.
+	// @Enum VISA,MASTERCARD,ELO,AMEX,UNKNOWN
+	// @Example VISA
+	Brand string `json:"brand" db:"brand"`
+
+	// @Description Expiry month of the card (1-12). The card is valid until the end of this month.
+	// @Example 9
+	ExpiryMonth int `json:"expiry_month" db:"expiry_month"`
+
+	// @Description Expiry year of the card (four digits).
+	// @Example 2030
+	ExpiryYear int `json:"expiry_year" db:"expiry_year"`
+
+	// @Description Kind of card. SINGLE_USE and MERCHANT_LOCKED are virtual cards that can only make purchases and refunds.
+	// @Enum STANDARD,SINGLE_USE,MERCHANT_LOCKED
+	// @Example STANDARD
+	Kind string `json:"kind" db:"kind"`
+
+	// @Description Merchant (card acceptor) the card may buy from, matched against acceptor_id of purchases. Nullable.
+	// @Example ACQ-000123
+	LockedAcceptorId sql.NullString `json:"locked_acceptor_id" db:"locked_acceptor_id" swaggertype:"string" extensions:"x-nullable"`
+
+	// @Description Most the card may spend on purchases over its lifetime, in cents. Refunds do not restore it. Nullable.
+	// @Example 250000
+	AmountLimitCents sql.NullInt64 `json:"amount_limit_cents" db:"amount_limit_cents" swaggertype:"integer" extensions:"x-nullable"`
+
+	// @Description Purchases a SINGLE_USE card can still make (1 or 0). Null for other kinds.
+	// @Example 1
+	RemainingUses sql.NullInt64 `json:"remaining_uses" db:"remaining_uses" swaggertype:"integer" extensions:"x-nullable"`
+
+	// @Description Part of amount_limit_cents not spent yet, in cents. Null when the card has no amount ceiling.
+	// @Example 180000
+	RemainingAmountCents sql.NullInt64 `json:"remaining_amount_cents" db:"remaining_amount_cents" swaggertype:"integer" extensions:"x-nullable"`
+
+	// @Description Lifecycle status of the card. Only ACTIVE cards can transact.
+	// @Enum ACTIVE,BLOCKED,CANCELLED,EXPIRED
+	// @Example ACTIVE
+	Status string `json:"status" db:"status"`
+
+	// @Description Reason given for the last status change, if any.
+	// @Example Card reported lost
+	StatusReason sql.NullString `json:"status_reason" db:"status_reason" swaggertype:"string" extensions:"x-nullable"`
+
+	// @Description Card this card replaced, if it was issued as a replacement (UUID).
+	// @Format uuid
+	ReplacesCardId sql.NullString `json:"replaces_card_id" db:"replaces_card_id" swaggertype:"string" extensions:"x-nullable"`
+
 	// @Description Timestamp when the card was created (UTC, RFC3339 format).
 	// @Format date-time
 	// @Example 2025-09-22T19:15:24.526505Z
 	CreatedAt string `json:"created_at" db:"created_at"`
+
+	// @Description Timestamp of the last status change (UTC, RFC3339 format).
+	// @Format date-time
+	// @Example 2025-09-22T19:15:24.526505Z
+	UpdatedAt string `json:"updated_at" db:"updated_at"`
 }
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+// Reasons a card transaction is declined by the card's controls or, for
+// virtual cards, by the constraints of its kind. They are stored on the
+// REJECTED transaction.
+const (
+	DeclineReasonTransactionTypeNotAllowed = "TRANSACTION_TYPE_NOT_ALLOWED"
+	DeclineReasonMerchantCategoryBlocked   = "MERCHANT_CATEGORY_BLOCKED"
+	DeclineReasonMaxTransactionExceeded    = "MAX_TRANSACTION_AMOUNT_EXCEEDED"
+	DeclineReasonDailyLimitExceeded        = "DAILY_LIMIT_EXCEEDED"
+	DeclineReasonMonthlyLimitExceeded      = "MONTHLY_LIMIT_EXCEEDED"
+	DeclineReasonVelocityLimitExceeded     = "VELOCITY_LIMIT_EXCEEDED"
+	DeclineReasonSingleUseCardUsed         = "SINGLE_USE_CARD_USED"
+	DeclineReasonAcceptorNotAllowed        = "ACCEPTOR_NOT_ALLOWED"
+	DeclineReasonAmountLimitExceeded       = "AMOUNT_LIMIT_EXCEEDED"
+)
+
+// CardControls restricts how a card can be used. A null or empty control does
+// not restrict the card.
+type CardControls struct {
+	// @Description Card the controls apply to (UUID).
+	// @Format uuid
+	CardId string `json:"card_id" db:"card_id"`
+
+	// @Description Merchant of the card's account (UUID).
+	// @Format uuid
+	MerchantId string `json:"merchant_id" db:"merchant_id"`
+
+	// @Description Largest amount a single PURCHASE or TRANSFER may have, in cents. Nullable.
+	// @Example 50000
+	MaxTransactionCents sql.NullInt64 `json:"max_transaction_cents" db:"max_transaction_cents" swaggertype:"integer" extensions:"x-nullable"`
+
+	// @Description Most the card may spend on PURCHASE and TRANSFER per UTC day, in cents. Nullable.
+	// @Example 100000
+	DailyLimitCents sql.NullInt64 `json:"daily_limit_cents" db:"daily_limit_cents" swaggertype:"integer" extensions:"x-nullable"`
+
+	// @Description Most the card may spend on PURCHASE and TRANSFER per UTC calendar month, in cents. Nullable.
+	// @Example 1000000
+	MonthlyLimitCents sql.NullInt64 `json:"monthly_limit_cents" db:"monthly_limit_cents" swaggertype:"integer" extensions:"x-nullable"`
+
+	// @Description Transaction types the card may be used for. Empty allows every type.
+	// @Example ["PURCHASE"]
+	AllowedTransactionTypes pq.StringArray `json:"allowed_transaction_types" db:"allowed_transaction_types" swaggertype:"array,string"`
+
+	// @Description Merchant category codes (ISO 18245) the card may not be used at.
+	// @Example ["7995"]
+	BlockedMerchantCategories pq.StringArray `json:"blocked_merchant_categories" db:"blocked_merchant_categories" swaggertype:"array,string"`
+
+	// @Description Most transactions the card may make within velocity_window_seconds. Nullable.
+	// @Example 5
+	VelocityLimit sql.NullInt64 `json:"velocity_limit" db:"velocity_limit" swaggertype:"integer" extensions:"x-nullable"`
+
+	// @Description Length of the velocity window, in seconds. Nullable.
+	// @Example 3600
+	VelocityWindowSeconds sql.NullInt64 `json:"velocity_window_seconds" db:"velocity_window_seconds" swaggertype:"integer" extensions:"x-nullable"`
+
+	// @Description Timestamp of the last change (UTC, RFC3339 format).
+	// @Format date-time
+	UpdatedAt string `json:"updated_at" db:"updated_at"`
+}
+
+// CardSpend is what a card spent in the current UTC day and month.
+type CardSpend struct {
+	DailyCents   int64 `db:"daily_cents"`
+	MonthlyCents int64 `db:"monthly_cents"`
+}
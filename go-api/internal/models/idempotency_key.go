@@ -0,0 +1,40 @@
+package models
+
+import "database/sql"
+
+// IdempotencyKey stores a client supplied Idempotency-Key together with the
+// fingerprint of the request that first used it and the response returned.
+type IdempotencyKey struct {
+	// @Description Key sent by the client in the Idempotency-Key header.
+	// @Example 5f0c0a7e-8d8f-4a6b-9d6e-0a6a3f1c2b4d
+	Key string `json:"key" db:"key"`
+
+	// @Description Merchant of the client that sent the key, or empty for platform operators. Keys only collide within a merchant.
+	MerchantScope string `json:"merchant_scope" db:"merchant_scope"`
+
+	// @Description Credentials that first used the key: key:<api key id> or account:<account id> for sessions. Responses are only replayed to the same caller.
+	CallerId string `json:"caller_id" db:"caller_id"`
+
+	// @Description SHA-256 of the method, path and body of the original request.
+	// @Format hash
+	RequestHash string `json:"request_hash" db:"request_hash"`
+
+	// @Description HTTP status of the stored response. Null while the request is in progress.
+	ResponseStatus sql.NullInt32 `json:"response_status" db:"response_status" swaggertype:"integer" extensions:"x-nullable"`
+
+	// @Description Raw body of the stored response. Null while the request is in progress.
+	ResponseBody []byte `json:"response_body" db:"response_body"`
+
+	// @Description Timestamp when the key was first used (UTC, RFC3339 format).
+	// @Format date-time
+	CreatedAt string `json:"created_at" db:"created_at"`
+
+	// @Description Timestamp after which the key may be reused (UTC, RFC3339 format).
+	// @Format date-time
+	ExpiresAt string `json:"expires_at" db:"expires_at"`
+}
+
+// IsCompleted reports whether a response has already been stored for the key.
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.ResponseStatus.Valid
+}
@@ -0,0 +1,92 @@
+package models
+
+import "database/sql"
+
+const (
+	PostingDirectionDebit  = "DEBIT"
+	PostingDirectionCredit = "CREDIT"
+)
+
+// System accounts are the platform side of every journal entry.
+const (
+	SystemAccountSettlement = "SETTLEMENT"
+	SystemAccountFees       = "FEES"
+)
+
+// Journal entry kinds that are not a transaction type. REVERSAL entries are
+// written by the database when a transaction is rejected and undo everything
+// posted for it.
+const (
+	JournalKindCaptureRelease = "CAPTURE_RELEASE"
+	JournalKindHoldRelease    = "HOLD_RELEASE"
+	JournalKindReversal       = "REVERSAL"
+)
+
+// JournalEntry groups the postings written for one movement of money. The
+// debits and credits of an entry always sum to the same amount.
+type JournalEntry struct {
+	// @Description Unique identifier of the journal entry (UUID).
+	// @Format uuid
+	ID string `json:"id" db:"id"`
+
+	// @Description Transaction that produced the entry (UUID).
+	// @Format uuid
+	TransactionId string `json:"transaction_id" db:"transaction_id"`
+
+	// @Description What the entry records: the transaction type, CAPTURE_RELEASE for the uncaptured part of an authorization, HOLD_RELEASE for an authorization voided or expired, or REVERSAL for a transaction that was rejected.
+	// @Example PURCHASE
+	Kind string `json:"kind" db:"kind"`
+
+	// @Description Timestamp when the entry was written (UTC, RFC3339 format).
+	// @Format date-time
+	CreatedAt string `json:"created_at" db:"created_at"`
+}
+
+// Posting is one side of a journal entry against a customer or system account.
+type Posting struct {
+	// @Description Unique identifier of the posting (UUID).
+	// @Format uuid
+	ID string `json:"id" db:"id"`
+
+	// @Description Journal entry the posting belongs to (UUID).
+	// @Format uuid
+	JournalEntryId string `json:"journal_entry_id" db:"journal_entry_id"`
+
+	// @Description Customer account of the posting (UUID). Null for system accounts.
+	// @Format uuid
+	AccountId sql.NullString `json:"account_id" db:"account_id" swaggertype:"string" extensions:"x-nullable"`
+
+	// @Description System account of the posting. Null for customer accounts.
+	// @Enum SETTLEMENT FEES
+	SystemAccount sql.NullString `json:"system_account" db:"system_account" swaggertype:"string" extensions:"x-nullable"`
+
+	// @Description Side of the posting. A CREDIT increases a customer balance, a DEBIT decreases it.
+	// @Enum DEBIT CREDIT
+	Direction string `json:"direction" db:"direction"`
+
+	// @Description Amount posted in cents. Always positive.
+	// @Example 1500
+	AmountCents int64 `json:"amount_cents" db:"amount_cents"`
+
+	// @Description Timestamp when the posting was written (UTC, RFC3339 format).
+	// @Format date-time
+	CreatedAt string `json:"created_at" db:"created_at"`
+}
+
+// AccountPosting is a posting listed in an account ledger together with the
+// journal entry and transaction it comes from.
+type AccountPosting struct {
+	Posting
+
+	// @Description Kind of the journal entry.
+	// @Example PURCHASE
+	Kind string `json:"kind" db:"kind"`
+
+	// @Description Transaction that produced the posting (UUID).
+	// @Format uuid
+	TransactionId string `json:"transaction_id" db:"transaction_id"`
+
+	// @Description Current status of that transaction. Rejected transactions also carry a REVERSAL entry that cancels their postings.
+	// @Example APPROVED
+	TransactionStatus string `json:"transaction_status" db:"transaction_status"`
+}
@@ -0,0 +1,26 @@
+package models
+
+// DefaultMerchantId is the merchant of accounts created before merchants
+// existed and of dashboard sign-ups that do not name a merchant.
+const DefaultMerchantId = "00000000-0000-0000-0000-000000000001"
+
+// Merchant is a tenant of the platform. Accounts, and through them cards and
+// transactions, belong to exactly one merchant.
+type Merchant struct {
+	// @Description Unique identifier of the merchant (UUID).
+	// @Format uuid
+	// @Example 00000000-0000-0000-0000-000000000001
+	ID string `json:"id" db:"id"`
+
+	// @Description Display name of the merchant.
+	// @Example Acme Store
+	Name string `json:"name" db:"name"`
+
+	// @Description Timestamp when the merchant was created (UTC, RFC3339 format).
+	// @Format date-time
+	CreatedAt string `json:"created_at" db:"created_at"`
+
+	// @Description Timestamp of the last merchant update (UTC, RFC3339 format).
+	// @Format date-time
+	UpdatedAt string `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	OperatorRoleSupport = "SUPPORT"
+	OperatorRoleFinance = "FINANCE"
+	OperatorRoleAdmin   = "ADMIN"
+)
+
+// Operator is a member of staff (support agent, finance, admin) acting across
+// accounts. What an operator may do is decided by their role.
+type Operator struct {
+	// @Description Unique identifier of the operator (UUID).
+	// @Format uuid
+	ID string `json:"id" db:"id"`
+
+	// @Description Merchant whose staff the operator belongs to. Null for platform staff, who reach every merchant.
+	// @Format uuid
+	MerchantId sql.NullString `json:"merchant_id" db:"merchant_id" swaggertype:"string" extensions:"x-nullable"`
+
+	// @Description Login name of the operator. Unique, compared case-insensitively.
+	// @Example jane.support
+	Username string `json:"username" db:"username"`
+
+	// @Description Display name of the operator.
+	// @Example Jane Doe
+	Name string `json:"name" db:"name"`
+
+	// @Description Role of the operator. Its permissions are listed by GET /roles.
+	// @Enum SUPPORT,FINANCE,ADMIN
+	// @Example SUPPORT
+	Role string `json:"role" db:"role"`
+
+	// @Description Inactive operators are rejected on every request, whatever key they use.
+	Active bool `json:"active" db:"active"`
+
+	// @Description Timestamp when the operator was created (UTC, RFC3339 format).
+	// @Format date-time
+	CreatedAt string `json:"created_at" db:"created_at"`
+
+	// @Description Timestamp of the last operator update (UTC, RFC3339 format).
+	// @Format date-time
+	UpdatedAt string `json:"updated_at" db:"updated_at"`
+}
+
+// Role is an entry of the policy table: the permissions granted to every
+// operator holding it. Permissions use the API key scope names.
+type Role struct {
+	// @Description Name of the role.
+	// @Example FINANCE
+	Name string `json:"name" db:"name"`
+
+	// @Description What the role is meant for.
+	Description string `json:"description" db:"description"`
+
+	// @Description Permissions granted by the role. admin grants every permission.
+	// @Example ["accounts:read","transactions:read","transactions:refund"]
+	Permissions pq.StringArray `json:"permissions" db:"permissions" swaggertype:"array,string"`
+}
+
+// AuditLog records a request made by an operator.
+type AuditLog struct {
+	// @Description Unique identifier of the entry (UUID).
+	// @Format uuid
+	ID string `json:"id" db:"id"`
+
+	// @Description Operator who made the request. Null for operator keys not tied to a person.
+	// @Format uuid
+	OperatorId sql.NullString `json:"operator_id" db:"operator_id" swaggertype:"string" extensions:"x-nullable"`
+
+	// @Description Merchant the operator was acting for. Null for platform operators.
+	// @Format uuid
+	MerchantId sql.NullString `json:"merchant_id" db:"merchant_id" swaggertype:"string" extensions:"x-nullable"`
+
+	// @Description API key the request was made with. Null for entries recorded before operators needed an API key.
+	// @Format uuid
+	APIKeyId sql.NullString `json:"api_key_id" db:"api_key_id" swaggertype:"string" extensions:"x-nullable"`
+
+	// @Description Role the operator held at the time of the request.
+	Role sql.NullString `json:"role" db:"role" swaggertype:"string" extensions:"x-nullable"`
+
+	// @Description HTTP method of the request.
+	// @Example POST
+	Method string `json:"method" db:"method"`
+
+	// @Description Route template matched by the request.
+	// @Example /accounts/{accountId}/freeze
+	Route string `json:"route" db:"route"`
+
+	// @Description Path of the request, including the query string.
+	// @Example /accounts/550e8400-e29b-41d4-a716-446655440000/freeze
+	Path string `json:"path" db:"path"`
+
+	// @Description Account named in the route, if any.
+	// @Format uuid
+	AccountId sql.NullString `json:"account_id" db:"account_id" swaggertype:"string" extensions:"x-nullable"`
+
+	// @Description HTTP status code of the response.
+	// @Example 200
+	StatusCode int `json:"status_code" db:"status_code"`
+
+	// @Description Timestamp of the request (UTC, RFC3339 format).
+	// @Format date-time
+	CreatedAt string `json:"created_at" db:"created_at"`
+}
+
+// AuditLogFilter narrows an audit log listing. Zero values mean "no filter"
+// except Limit, which is always set.
+type AuditLogFilter struct {
+	OperatorId  string
+	AccountId   string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Limit       int
+}
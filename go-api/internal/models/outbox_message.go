@@ -0,0 +1,47 @@
+package models
+
+import "database/sql"
+
+// OutboxMessage is a message written in the same database transaction as the
+// change it describes and published to RabbitMQ afterwards by the outbox relay.
+type OutboxMessage struct {
+	// @Description Unique identifier of the message (UUID).
+	// @Format uuid
+	ID string `json:"id" db:"id"`
+
+	// @Description Identifier of the entity the message refers to, e.g. the transaction ID (UUID).
+	// @Format uuid
+	AggregateId string `json:"aggregate_id" db:"aggregate_id"`
+
+	// @Description Name of the RabbitMQ queue the message is published to.
+	// @Example transactions_queue
+	QueueName string `json:"queue_name" db:"queue_name"`
+
+	// @Description JSON payload published to the queue.
+	Payload []byte `json:"payload" db:"payload"`
+
+	// @Description Number of failed publish attempts.
+	Attempts int `json:"attempts" db:"attempts"`
+
+	// @Description Error returned by the last failed publish attempt. Nullable.
+	LastError sql.NullString `json:"last_error" db:"last_error" swaggertype:"string" extensions:"x-nullable"`
+
+	// @Description Earliest time the relay will try to publish the message again (UTC, RFC3339 format).
+	// @Format date-time
+	NextAttemptAt string `json:"next_attempt_at" db:"next_attempt_at"`
+
+	// @Description Timestamp when the message was published. Null while pending.
+	// @Format date-time
+	DispatchedAt sql.NullString `json:"dispatched_at" db:"dispatched_at" swaggertype:"string" extensions:"x-nullable"`
+
+	// @Description Timestamp when the message was written (UTC, RFC3339 format).
+	// @Format date-time
+	CreatedAt string `json:"created_at" db:"created_at"`
+}
+
+// OutboxBacklog summarizes the messages still waiting to be published.
+type OutboxBacklog struct {
+	Pending            int64   `db:"pending"`
+	Retrying           int64   `db:"retrying"`
+	OldestPendingAgeMs float64 `db:"oldest_pending_age_ms"`
+}
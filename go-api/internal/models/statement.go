@@ -0,0 +1,10 @@
+package models
+
+// StatementEntry is an approved transaction listed in an account statement
+// together with its signed effect on the balance.
+type StatementEntry struct {
+	Transaction
+
+	// @Description Effect of the transaction on the balance, in cents. Negative for debits.
+	SignedAmountCents int64 `json:"signed_amount_cents" db:"signed_amount_cents"`
+}
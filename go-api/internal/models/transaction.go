@@ -2,6 +2,35 @@ package models
 
 import "database/sql"
 
+const (
+	TransactionTypeDeposit     = "DEPOSIT"
+	TransactionTypePurchase    = "PURCHASE"
+	TransactionTypeRefund      = "REFUND"
+	TransactionTypeCharge      = "CHARGE"
+	TransactionTypeTransfer    = "TRANSFER"
+	TransactionTypeTransferOut = "TRANSFER_OUT"
+	TransactionTypeTransferIn  = "TRANSFER_IN"
+)
+
+// Reason codes accepted for CHARGE transactions.
+const (
+	ChargeReasonServiceFee     = "SERVICE_FEE"
+	ChargeReasonMaintenanceFee = "MAINTENANCE_FEE"
+	ChargeReasonChargeback     = "CHARGEBACK"
+	ChargeReasonAdjustment     = "ADJUSTMENT"
+	ChargeReasonPenalty        = "PENALTY"
+)
+
+const (
+	TransactionStatusPending    = "PENDING"
+	TransactionStatusApproved   = "APPROVED"
+	TransactionStatusRejected   = "REJECTED"
+	TransactionStatusAuthorized = "AUTHORIZED"
+	TransactionStatusVoided     = "VOIDED"
+	TransactionStatusExpired    = "EXPIRED"
+	TransactionStatusError      = "ERROR"
+)
+
 // NullableString represents a string value that may be null.
 // Commonly used in database fields where null values are allowed.
 type NullableString struct {
@@ -26,6 +55,10 @@ type Transaction struct {
 	// @Example e8b4d4c2-f9b6-4b1e-8e5e-9a9c2c1a1a9e
 	AccountId string `json:"account_id" db:"account_id"`
 
+	// @Description Merchant of the transaction's account (UUID).
+	// @Format uuid
+	MerchantId string `json:"merchant_id" db:"merchant_id"`
+
 	// @Description Identifier of the card used for the transaction. Nullable.
 	// @Format uuid
 	// @Example f0c3a2a6-0b3c-4a3e-8c7a-5b12bf7e4e1a
@@ -36,21 +69,53 @@ type Transaction struct {
 	// @Example c7a3c3b1-a2e4-4a25-8c7a-5b12bf7e4e1a
 	RefundTransactionId sql.NullString `json:"refund_transaction_id" db:"refund_transaction_id" swaggertype:"string" extensions:"x-nullable"`
 
+	// @Description Identifier of the other leg when this is a TRANSFER_OUT or TRANSFER_IN. Nullable.
+	// @Format uuid
+	// @Example 9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d
+	CounterpartTransactionId sql.NullString `json:"counterpart_transaction_id" db:"counterpart_transaction_id" swaggertype:"string" extensions:"x-nullable"`
+
 	// @Description Transaction amount in the smallest currency unit (e.g., cents). Must be positive.
 	// @Minimum 1
 	// @Example 5000
 	AmountCents int64 `json:"amount_cents" db:"amount_cents"`
 
-	// @Description Current status of the transaction.
-	// @Enum PENDING APPROVED REJECTED
+	// @Description Current status of the transaction. Purchases created with capture=false become AUTHORIZED and later APPROVED (captured), VOIDED or EXPIRED.
+	// @Enum PENDING APPROVED REJECTED AUTHORIZED VOIDED EXPIRED
 	// @Example PENDING
 	Status string `json:"status" db:"status"`
 
-	// @Description Type of the transaction.
-	// @Enum DEPOSIT PURCHASE REFUND
+	// @Description Type of the transaction. CHARGE is a fee or merchant initiated debit that may overdraw the account up to the configured limit. A TRANSFER request is stored as a TRANSFER_OUT leg on the source account and a TRANSFER_IN leg on the destination account.
+	// @Enum DEPOSIT PURCHASE REFUND CHARGE TRANSFER_OUT TRANSFER_IN
 	// @Example DEPOSIT
 	Type string `json:"type" db:"type"`
 
+	// @Description Why the account was charged. Only set for CHARGE transactions. Nullable.
+	// @Enum SERVICE_FEE MAINTENANCE_FEE CHARGEBACK ADJUSTMENT PENALTY
+	// @Example SERVICE_FEE
+	ReasonCode sql.NullString `json:"reason_code" db:"reason_code" swaggertype:"string" extensions:"x-nullable"`
+
+	// @Description Merchant category code (ISO 18245) of the merchant a card transaction was made at. Nullable.
+	// @Example 5411
+	MerchantCategoryCode sql.NullString `json:"merchant_category_code" db:"merchant_category_code" swaggertype:"string" extensions:"x-nullable"`
+
+	// @Description Merchant (card acceptor) a card transaction was made at. Checked against the merchant a MERCHANT_LOCKED card is locked to. Nullable.
+	// @Example ACQ-000123
+	AcceptorId sql.NullString `json:"acceptor_id" db:"acceptor_id" swaggertype:"string" extensions:"x-nullable"`
+
+	// @Description Card control or virtual card constraint that declined the transaction. Only set on transactions REJECTED when they were created. Nullable.
+	// @Enum TRANSACTION_TYPE_NOT_ALLOWED MERCHANT_CATEGORY_BLOCKED MAX_TRANSACTION_AMOUNT_EXCEEDED DAILY_LIMIT_EXCEEDED MONTHLY_LIMIT_EXCEEDED VELOCITY_LIMIT_EXCEEDED SINGLE_USE_CARD_USED ACCEPTOR_NOT_ALLOWED AMOUNT_LIMIT_EXCEEDED
+	// @Example DAILY_LIMIT_EXCEEDED
+	DeclineReason sql.NullString `json:"decline_reason" db:"decline_reason" swaggertype:"string" extensions:"x-nullable"`
+
+	// @Description Amount reserved by the authorization hold, in cents. Only set for purchases created with capture=false. Nullable.
+	// @Example 10000
+	AuthorizedAmountCents sql.NullInt64 `json:"authorized_amount_cents" db:"authorized_amount_cents" swaggertype:"integer" extensions:"x-nullable"`
+
+	// @Description Time at which an uncaptured authorization hold expires (UTC, RFC3339 format). Nullable.
+	// @Format date-time
+	// @Example 2025-10-10T20:30:00.123Z
+	HoldExpiresAt sql.NullString `json:"hold_expires_at" db:"hold_expires_at" swaggertype:"string" extensions:"x-nullable"`
+
 	// @Description Unique key to guarantee idempotency of the transaction.
 	// @Example 2025-10-03-17:30:00:e8b4d4c2:DEPOSIT:5000
 	IdempotencyKey string `json:"idempotency_key" db:"idempotency_key"`
@@ -60,3 +125,50 @@ type Transaction struct {
 	// @Example 2025-10-03T20:30:00.123Z
 	CreatedAt string `json:"created_at" db:"created_at"`
 }
+
+// RefundTotals aggregates the refunds issued against a single transaction.
+type RefundTotals struct {
+	// @Description Sum of approved refunds, in cents.
+	RefundedCents int64 `json:"refunded_cents" db:"refunded_cents"`
+
+	// @Description Sum of refunds still waiting for approval, in cents.
+	PendingRefundCents int64 `json:"pending_refund_cents" db:"pending_refund_cents"`
+}
+
+// IsRefundableType reports whether transactions of txType can be refunded.
+func IsRefundableType(txType string) bool {
+	switch txType {
+	case TransactionTypeDeposit, TransactionTypePurchase, TransactionTypeCharge:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsStoredType reports whether txType can appear in the type column. TRANSFER
+// requests are stored as their TRANSFER_OUT and TRANSFER_IN legs.
+func IsStoredType(txType string) bool {
+	switch txType {
+	case TransactionTypeDeposit, TransactionTypePurchase, TransactionTypeRefund, TransactionTypeCharge,
+		TransactionTypeTransferOut, TransactionTypeTransferIn:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsTransactionStatus reports whether status is a known transaction status.
+func IsTransactionStatus(status string) bool {
+	switch status {
+	case TransactionStatusPending, TransactionStatusApproved, TransactionStatusRejected, TransactionStatusError,
+		TransactionStatusAuthorized, TransactionStatusVoided, TransactionStatusExpired:
+		return true
+	default:
+		return false
+	}
+}
+
+// RefundableCents returns how much of amountCents can still be refunded.
+func (t *RefundTotals) RefundableCents(amountCents int64) int64 {
+	return max(amountCents-t.RefundedCents-t.PendingRefundCents, 0)
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// Sort orders accepted by transaction listings.
+const (
+	TransactionSortCreatedAtDesc = "created_at_desc"
+	TransactionSortCreatedAtAsc  = "created_at_asc"
+)
+
+// TransactionCursor is the position of the last row of a page. The next page
+// starts right after it in the requested sort order.
+type TransactionCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// TransactionFilter narrows and pages a transaction listing. Zero values mean
+// "no filter" except Limit, which is always set.
+type TransactionFilter struct {
+	AccountId      string
+	CardId         string
+	Types          []string
+	Statuses       []string
+	MinAmountCents *int64
+	MaxAmountCents *int64
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+
+	// Search only: substring of the idempotency key and last four digits of
+	// the card used.
+	IdempotencyKeyContains string
+	CardLastFour           string
+
+	Sort   string
+	Cursor *TransactionCursor
+	Limit  int
+}
@@ -0,0 +1,13 @@
+package models
+
+// VaultEntry is a secret held by the card vault under an opaque token. The
+// secret is encrypted with its own data key, which is stored wrapped by the
+// key-encryption key of version KEKVersion.
+type VaultEntry struct {
+	Token      string  `db:"token"`
+	KEKVersion int     `db:"kek_version"`
+	WrappedKey []byte  `db:"wrapped_key"`
+	Ciphertext []byte  `db:"ciphertext"`
+	CreatedAt  string  `db:"created_at"`
+	RotatedAt  *string `db:"rotated_at"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+type CreateOperatorRequest struct {
+	// @Description Login name of the operator.
+	// @Example jane.support
+	Username string `json:"username" validate:"required,min=3,max=100"`
+
+	// @Description Display name of the operator.
+	// @Example Jane Doe
+	Name string `json:"name" validate:"required,min=1,max=100"`
+
+	// @Description Role of the operator. See GET /roles.
+	// @Example SUPPORT
+	Role string `json:"role" validate:"required"`
+
+	// @Description Merchant the operator works for. Only platform operators may set it; omitted, the operator belongs to the caller's merchant, or works across merchants when created by a platform operator.
+	// @Format uuid
+	MerchantId *string `json:"merchant_id" validate:"omitempty,uuid"`
+}
+
+type UpdateOperatorRequest struct {
+	// @Description New role for the operator. Omit to keep the current one.
+	// @Example FINANCE
+	Role *string `json:"role" validate:"omitempty,min=1"`
+
+	// @Description Set to false to lock the operator out. Their keys stop working until reactivated.
+	Active *bool `json:"active"`
+}
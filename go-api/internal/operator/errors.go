@@ -0,0 +1,10 @@
+package operator
+
+import "errors"
+
+var (
+	ErrOperatorNotFound      = errors.New("operator not found")
+	ErrRoleNotFound          = errors.New("role not found")
+	ErrEmptyOperatorUpdate   = errors.New("at least one of role or active is required")
+	ErrInvalidAuditLogFilter = errors.New("invalid audit log filter")
+)
@@ -0,0 +1,267 @@
+package operator
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"payment-gateway/go-api/internal/api"
+	"payment-gateway/go-api/internal/i18n"
+	"payment-gateway/go-api/internal/merchant"
+	"payment-gateway/go-api/internal/models"
+	"payment-gateway/go-api/internal/operator/dto"
+	"payment-gateway/go-api/internal/repository"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+const (
+	defaultAuditLogLimit = 50
+	maxAuditLogLimit     = 200
+)
+
+type OperatorHandler struct {
+	service  OperatorService
+	validate *validator.Validate
+}
+
+func NewOperatorHandler(service OperatorService) *OperatorHandler {
+	return &OperatorHandler{
+		service:  service,
+		validate: validator.New(),
+	}
+}
+
+// @Summary Create an operator
+// @Description Registers a support agent, finance or admin operator in the caller's merchant. Platform operators choose the merchant with merchant_id, or omit it to create another platform operator. Give them access by creating an OPERATOR API key with their operator_id. Requires the operators:manage permission.
+// @Tags operators
+// @Accept json
+// @Produce json
+// @Param operator body dto.CreateOperatorRequest true "Operator data"
+// @Success 201 {object} models.Operator
+// @Failure 400 {object} api.APIError "Invalid request body or unknown role"
+// @Failure 401 {object} api.APIError "Missing or invalid credentials"
+// @Failure 403 {object} api.APIError "Caller's role does not allow managing operators"
+// @Failure 404 {object} api.APIError "Merchant not found"
+// @Failure 409 {object} api.APIError "Username already taken"
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Security BearerAuth
+// @Router /operators [post]
+func (h *OperatorHandler) CreateOperator(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+
+	var req dto.CreateOperatorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidRequestBody))
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		api.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	operator, err := h.service.CreateOperator(r.Context(), req)
+	if err != nil {
+		writeOperatorError(w, lang, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(operator)
+}
+
+// @Summary List operators
+// @Description Lists the operators of the caller's merchant, every operator for platform operators, active or not, by username. Requires the operators:manage permission.
+// @Tags operators
+// @Produce json
+// @Success 200 {array} models.Operator
+// @Failure 401 {object} api.APIError "Missing or invalid credentials"
+// @Failure 403 {object} api.APIError "Caller's role does not allow managing operators"
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Security BearerAuth
+// @Router /operators [get]
+func (h *OperatorHandler) ListOperators(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+
+	operators, err := h.service.ListOperators(r.Context())
+	if err != nil {
+		writeOperatorError(w, lang, err)
+		return
+	}
+	if operators == nil {
+		operators = make([]*models.Operator, 0)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(operators)
+}
+
+// @Summary Update an operator
+// @Description Changes the role of an operator or deactivates them. Changes apply to the operator's next request. Requires the operators:manage permission.
+// @Tags operators
+// @Accept json
+// @Produce json
+// @Param operatorId path string true "Operator ID"
+// @Param operator body dto.UpdateOperatorRequest true "Fields to change"
+// @Success 200 {object} models.Operator
+// @Failure 400 {object} api.APIError "Invalid request body, unknown role or nothing to update"
+// @Failure 401 {object} api.APIError "Missing or invalid credentials"
+// @Failure 403 {object} api.APIError "Caller's role does not allow managing operators"
+// @Failure 404 {object} api.APIError "Operator not found"
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Security BearerAuth
+// @Router /operators/{operatorId} [patch]
+func (h *OperatorHandler) UpdateOperator(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+
+	operatorId := mux.Vars(r)["operatorId"]
+	if err := h.validate.Var(operatorId, "uuid"); err != nil {
+		api.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req dto.UpdateOperatorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidRequestBody))
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		api.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	operator, err := h.service.UpdateOperator(r.Context(), operatorId, req)
+	if err != nil {
+		writeOperatorError(w, lang, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(operator)
+}
+
+// @Summary List roles
+// @Description Returns the policy table: each operator role with the permissions it grants. Requires the operators:manage permission.
+// @Tags operators
+// @Produce json
+// @Success 200 {array} models.Role
+// @Failure 401 {object} api.APIError "Missing or invalid credentials"
+// @Failure 403 {object} api.APIError "Caller's role does not allow managing operators"
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Security BearerAuth
+// @Router /roles [get]
+func (h *OperatorHandler) ListRoles(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+
+	roles, err := h.service.ListRoles(r.Context())
+	if err != nil {
+		writeOperatorError(w, lang, err)
+		return
+	}
+	if roles == nil {
+		roles = make([]*models.Role, 0)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(roles)
+}
+
+// @Summary List audit logs
+// @Description Lists requests made by operators of the caller's merchant, or by every operator for platform operators, newest first. To page, pass the created_at of the last entry as created_to. Requires the audit:read permission.
+// @Tags operators
+// @Produce json
+// @Param operator_id query string false "Only requests made by this operator"
+// @Param account_id query string false "Only requests on this account"
+// @Param created_from query string false "Made at or after (RFC3339)"
+// @Param created_to query string false "Made before (RFC3339)"
+// @Param limit query int false "Maximum number of entries (max 200)" default(50)
+// @Success 200 {array} models.AuditLog
+// @Failure 400 {object} api.APIError "Invalid filter"
+// @Failure 401 {object} api.APIError "Missing or invalid credentials"
+// @Failure 403 {object} api.APIError "Caller's role does not allow reading audit logs"
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Security BearerAuth
+// @Router /audit-logs [get]
+func (h *OperatorHandler) ListAuditLogs(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+
+	filter, err := h.parseAuditLogFilter(r)
+	if err != nil {
+		writeOperatorError(w, lang, err)
+		return
+	}
+
+	entries, err := h.service.ListAuditLogs(r.Context(), filter)
+	if err != nil {
+		writeOperatorError(w, lang, err)
+		return
+	}
+	if entries == nil {
+		entries = make([]*models.AuditLog, 0)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
+func (h *OperatorHandler) parseAuditLogFilter(r *http.Request) (models.AuditLogFilter, error) {
+	query := r.URL.Query()
+	filter := models.AuditLogFilter{
+		OperatorId: query.Get("operator_id"),
+		AccountId:  query.Get("account_id"),
+		Limit:      defaultAuditLogLimit,
+	}
+
+	for _, id := range []string{filter.OperatorId, filter.AccountId} {
+		if id != "" && h.validate.Var(id, "uuid") != nil {
+			return filter, ErrInvalidAuditLogFilter
+		}
+	}
+
+	for param, target := range map[string]**time.Time{
+		"created_from": &filter.CreatedFrom,
+		"created_to":   &filter.CreatedTo,
+	} {
+		if value := query.Get(param); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return filter, ErrInvalidAuditLogFilter
+			}
+			*target = &parsed
+		}
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxAuditLogLimit {
+			return filter, ErrInvalidAuditLogFilter
+		}
+		filter.Limit = limit
+	}
+
+	return filter, nil
+}
+
+func writeOperatorError(w http.ResponseWriter, lang string, err error) {
+	switch {
+	case errors.Is(err, ErrOperatorNotFound):
+		api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorOperatorNotFound))
+	case errors.Is(err, merchant.ErrMerchantNotFound):
+		api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorMerchantNotFound))
+	case errors.Is(err, ErrRoleNotFound):
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidOperatorRole))
+	case errors.Is(err, ErrEmptyOperatorUpdate):
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorEmptyOperatorUpdate))
+	case errors.Is(err, ErrInvalidAuditLogFilter):
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidAuditLogFilter))
+	case errors.Is(err, repository.ErrDuplicateKey):
+		api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorDuplicateKey))
+	default:
+		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorInternalServerError))
+	}
+}
@@ -0,0 +1,28 @@
+package operator
+
+import (
+	"payment-gateway/go-api/internal/merchant"
+	"payment-gateway/go-api/internal/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type Module struct {
+	Handler *OperatorHandler
+	Auditor *Auditor
+	Service OperatorService
+}
+
+func NewModule(db *sqlx.DB, merchantService merchant.MerchantService) *Module {
+	repo := repository.NewOperatorRepository(db)
+	auditRepo := repository.NewAuditLogRepository(db)
+	service := NewOperatorService(repo, auditRepo, merchantService)
+	handler := NewOperatorHandler(service)
+	auditor := NewAuditor(service)
+
+	return &Module{
+		Handler: handler,
+		Auditor: auditor,
+		Service: service,
+	}
+}
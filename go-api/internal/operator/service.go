@@ -0,0 +1,130 @@
+package operator
+
+import (
+	"context"
+	"database/sql"
+	"payment-gateway/go-api/internal/merchant"
+	"payment-gateway/go-api/internal/models"
+	"payment-gateway/go-api/internal/operator/dto"
+	"payment-gateway/go-api/internal/repository"
+	"payment-gateway/go-api/internal/tenant"
+)
+
+type OperatorService interface {
+	CreateOperator(ctx context.Context, req dto.CreateOperatorRequest) (*models.Operator, error)
+	GetOperatorById(ctx context.Context, id string) (*models.Operator, error)
+	ListOperators(ctx context.Context) ([]*models.Operator, error)
+	UpdateOperator(ctx context.Context, id string, req dto.UpdateOperatorRequest) (*models.Operator, error)
+	GetPermissions(ctx context.Context, operator *models.Operator) ([]string, error)
+	ListRoles(ctx context.Context) ([]*models.Role, error)
+	RecordAudit(ctx context.Context, entry *models.AuditLog) error
+	ListAuditLogs(ctx context.Context, filter models.AuditLogFilter) ([]*models.AuditLog, error)
+}
+
+type operatorServiceImpl struct {
+	repo            repository.OperatorRepository
+	auditRepo       repository.AuditLogRepository
+	merchantService merchant.MerchantService
+}
+
+func NewOperatorService(repo repository.OperatorRepository, auditRepo repository.AuditLogRepository, merchantService merchant.MerchantService) *operatorServiceImpl {
+	return &operatorServiceImpl{repo: repo, auditRepo: auditRepo, merchantService: merchantService}
+}
+
+// CreateOperator creates the operator in the merchant named by the request,
+// which must be within the caller's reach, or else in the caller's own.
+// Platform callers naming none create a platform operator.
+func (s *operatorServiceImpl) CreateOperator(ctx context.Context, req dto.CreateOperatorRequest) (*models.Operator, error) {
+	if err := s.checkRole(ctx, req.Role); err != nil {
+		return nil, err
+	}
+
+	own, _ := tenant.MerchantId(ctx)
+	merchantId := sql.NullString{String: own, Valid: own != ""}
+	if req.MerchantId != nil {
+		scoped, ok := tenant.Narrow(ctx, *req.MerchantId)
+		if !ok {
+			return nil, merchant.ErrMerchantNotFound
+		}
+		if _, err := s.merchantService.GetMerchantById(scoped, *req.MerchantId); err != nil {
+			return nil, err
+		}
+		merchantId = sql.NullString{String: *req.MerchantId, Valid: true}
+	}
+
+	operator := &models.Operator{
+		MerchantId: merchantId,
+		Username:   req.Username,
+		Name:       req.Name,
+		Role:       req.Role,
+	}
+	if err := s.repo.CreateOperator(ctx, operator); err != nil {
+		return nil, err
+	}
+
+	return operator, nil
+}
+
+// GetOperatorById returns nil, without error, when the operator does not exist.
+func (s *operatorServiceImpl) GetOperatorById(ctx context.Context, id string) (*models.Operator, error) {
+	return s.repo.GetOperatorById(ctx, id)
+}
+
+func (s *operatorServiceImpl) ListOperators(ctx context.Context) ([]*models.Operator, error) {
+	return s.repo.ListOperators(ctx)
+}
+
+func (s *operatorServiceImpl) UpdateOperator(ctx context.Context, id string, req dto.UpdateOperatorRequest) (*models.Operator, error) {
+	if req.Role == nil && req.Active == nil {
+		return nil, ErrEmptyOperatorUpdate
+	}
+	if req.Role != nil {
+		if err := s.checkRole(ctx, *req.Role); err != nil {
+			return nil, err
+		}
+	}
+
+	operator, err := s.repo.UpdateOperator(ctx, id, req.Role, req.Active)
+	if err != nil {
+		return nil, err
+	}
+	if operator == nil {
+		return nil, ErrOperatorNotFound
+	}
+	return operator, nil
+}
+
+// GetPermissions returns what the policy table grants the role of operator.
+func (s *operatorServiceImpl) GetPermissions(ctx context.Context, operator *models.Operator) ([]string, error) {
+	role, err := s.repo.GetRole(ctx, operator.Role)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, ErrRoleNotFound
+	}
+	return role.Permissions, nil
+}
+
+func (s *operatorServiceImpl) ListRoles(ctx context.Context) ([]*models.Role, error) {
+	return s.repo.ListRoles(ctx)
+}
+
+func (s *operatorServiceImpl) RecordAudit(ctx context.Context, entry *models.AuditLog) error {
+	return s.auditRepo.CreateAuditLog(ctx, entry)
+}
+
+func (s *operatorServiceImpl) ListAuditLogs(ctx context.Context, filter models.AuditLogFilter) ([]*models.AuditLog, error) {
+	return s.auditRepo.ListAuditLogs(ctx, filter)
+}
+
+func (s *operatorServiceImpl) checkRole(ctx context.Context, name string) error {
+	role, err := s.repo.GetRole(ctx, name)
+	if err != nil {
+		return err
+	}
+	if role == nil {
+		return ErrRoleNotFound
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package dto
+
+// @Description Outbox relay metrics
+type ResponseOutboxMetrics struct {
+	// @Description Messages written but not yet published.
+	PendingMessages int64 `json:"pending_messages" example:"3"`
+
+	// @Description Pending messages that already failed at least once.
+	RetryingMessages int64 `json:"retrying_messages" example:"1"`
+
+	// @Description Age of the oldest pending message in seconds.
+	OldestPendingAgeSeconds float64 `json:"oldest_pending_age_seconds" example:"1.5"`
+
+	// @Description Messages published by this instance since startup.
+	PublishedTotal int64 `json:"published_total" example:"1024"`
+
+	// @Description Failed publish attempts by this instance since startup.
+	FailedAttemptsTotal int64 `json:"failed_attempts_total" example:"2"`
+}
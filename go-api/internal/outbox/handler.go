@@ -0,0 +1,46 @@
+package outbox
+
+import (
+	"encoding/json"
+	"net/http"
+	"payment-gateway/go-api/internal/api"
+	"payment-gateway/go-api/internal/i18n"
+	"payment-gateway/go-api/internal/outbox/dto"
+)
+
+type OutboxHandler struct {
+	relay *Relay
+}
+
+func NewOutboxHandler(relay *Relay) *OutboxHandler {
+	return &OutboxHandler{relay: relay}
+}
+
+// @ID get-outbox-metrics
+// @Summary Get outbox metrics
+// @Description Returns the size of the outbox backlog and the relay publish counters.
+// @Tags outbox
+// @Produce json
+// @Success 200 {object} dto.ResponseOutboxMetrics
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Security BearerAuth
+// @Router /outbox/metrics [get]
+func (h *OutboxHandler) GetMetrics(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+
+	backlog, err := h.relay.Backlog(r.Context())
+	if err != nil {
+		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorFetchingOutboxMetrics))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(dto.ResponseOutboxMetrics{
+		PendingMessages:         backlog.Pending,
+		RetryingMessages:        backlog.Retrying,
+		OldestPendingAgeSeconds: backlog.OldestPendingAgeMs / 1000,
+		PublishedTotal:          h.relay.PublishedTotal(),
+		FailedAttemptsTotal:     h.relay.FailedTotal(),
+	})
+}
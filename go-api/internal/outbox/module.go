@@ -0,0 +1,26 @@
+package outbox
+
+import (
+	"payment-gateway/go-api/internal/connection"
+	"payment-gateway/go-api/internal/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type Module struct {
+	Handler    *OutboxHandler
+	Relay      *Relay
+	Repository repository.OutboxRepository
+}
+
+func NewModule(db *sqlx.DB, mqClient connection.RabbitMQClient, options RelayOptions) *Module {
+	repo := repository.NewOutboxRepository(db)
+	relay := NewRelay(repo, mqClient, options)
+	handler := NewOutboxHandler(relay)
+
+	return &Module{
+		Handler:    handler,
+		Relay:      relay,
+		Repository: repo,
+	}
+}
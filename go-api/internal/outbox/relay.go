@@ -0,0 +1,118 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"payment-gateway/go-api/internal/connection"
+	"payment-gateway/go-api/internal/models"
+	"payment-gateway/go-api/internal/repository"
+	"sync/atomic"
+	"time"
+)
+
+type RelayOptions struct {
+	PollInterval time.Duration
+	BatchSize    int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+}
+
+// Relay publishes pending outbox messages to RabbitMQ. A message is marked
+// dispatched only once the broker confirmed it; messages that fail to publish
+// or are not confirmed are retried with exponential backoff until they succeed.
+type Relay struct {
+	repo     repository.OutboxRepository
+	mqClient connection.RabbitMQClient
+	options  RelayOptions
+
+	published atomic.Int64
+	failed    atomic.Int64
+}
+
+func NewRelay(repo repository.OutboxRepository, mqClient connection.RabbitMQClient, options RelayOptions) *Relay {
+	return &Relay{repo: repo, mqClient: mqClient, options: options}
+}
+
+// Run polls the outbox until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.options.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				processed, err := r.dispatchBatch(ctx)
+				if err != nil {
+					log.Printf("Outbox relay failed to dispatch batch: %v", err)
+					break
+				}
+				if processed < r.options.BatchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+func (r *Relay) dispatchBatch(ctx context.Context) (int, error) {
+	dbTx, err := r.repo.BeginTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer dbTx.Rollback()
+
+	messages, err := r.repo.FetchPending(ctx, dbTx, r.options.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, msg := range messages {
+		if err := r.mqClient.Publish(ctx, msg.QueueName, msg.Payload); err != nil {
+			r.failed.Add(1)
+			nextAttemptAt := time.Now().UTC().Add(r.backoff(msg.Attempts))
+			if err := r.repo.MarkFailed(ctx, dbTx, msg.ID, err.Error(), nextAttemptAt); err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		r.published.Add(1)
+		if err := r.repo.MarkDispatched(ctx, dbTx, msg.ID); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit outbox batch: %w", err)
+	}
+
+	return len(messages), nil
+}
+
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.options.BaseBackoff
+	for i := 0; i < attempts && delay < r.options.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, r.options.MaxBackoff)
+}
+
+// Backlog returns the current size of the outbox backlog.
+func (r *Relay) Backlog(ctx context.Context) (*models.OutboxBacklog, error) {
+	return r.repo.GetBacklog(ctx)
+}
+
+// PublishedTotal returns the number of messages published since startup.
+func (r *Relay) PublishedTotal() int64 {
+	return r.published.Load()
+}
+
+// FailedTotal returns the number of failed publish attempts since startup.
+func (r *Relay) FailedTotal() int64 {
+	return r.failed.Load()
+}
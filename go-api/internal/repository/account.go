@@ -5,14 +5,21 @@ import (
 	"database/sql"
 	"fmt"
 	"payment-gateway/go-api/internal/models"
+	"payment-gateway/go-api/internal/tenant"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx/types"
 )
 
 type AccountRepository interface {
 	CreateAccount(ctx context.Context, account *models.Account) error
 	GetAllAccounts(ctx context.Context, page, limit int) ([]*models.Account, error)
 	GetAccountById(ctx context.Context, id string) (*models.Account, error)
+	GetAccountByUsername(ctx context.Context, username string) (*models.Account, error)
+	SetPasswordHash(ctx context.Context, id, passwordHash string) error
+	UpdateAccount(ctx context.Context, id string, username *string, metadata types.JSONText) (*models.Account, error)
+	UpdateAccountStatus(ctx context.Context, id, fromStatus, toStatus string, reason *string) (*models.Account, error)
+	SoftDeleteAccount(ctx context.Context, id, fromStatus string, reason *string) (*models.Account, error)
 }
 
 type accountRepositoryImpl struct {
@@ -23,16 +30,29 @@ func NewAccountRepository(db *sqlx.DB) AccountRepository {
 	return &accountRepositoryImpl{db: db}
 }
 
+// CreateAccount creates account under account.MerchantId, which must be within
+// the merchant scope of ctx.
 func (r *accountRepositoryImpl) CreateAccount(ctx context.Context, account *models.Account) error {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return err
+	}
+	if merchantId != "" && account.MerchantId != merchantId {
+		return fmt.Errorf("failed to create account: %w", ErrOutOfScope)
+	}
+
 	query := `
-        INSERT INTO accounts (username)
-        VALUES ($1)
-        RETURNING id, created_at, updated_at;
+        INSERT INTO accounts (merchant_id, username, password_hash)
+        VALUES ($1, $2, $3)
+        RETURNING id, status, metadata, created_at, updated_at;
     `
 
-	err := r.db.QueryRowContext(ctx, query, account.Username).Scan(&account.ID, &account.CreatedAt, &account.UpdatedAt)
+	err = r.db.QueryRowContext(ctx, query, account.MerchantId, account.Username, account.PasswordHash).Scan(&account.ID, &account.Status, &account.Metadata, &account.CreatedAt, &account.UpdatedAt)
 
 	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to create account: %w", ErrDuplicateKey)
+		}
 		return fmt.Errorf("failed to create account: %w", err)
 	}
 
@@ -40,14 +60,19 @@ func (r *accountRepositoryImpl) CreateAccount(ctx context.Context, account *mode
 }
 
 func (r *accountRepositoryImpl) GetAllAccounts(ctx context.Context, page, limit int) ([]*models.Account, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
 	offset := (page - 1) * limit
 
-	query := `SELECT id, username, created_at, updated_at FROM accounts
+	query := `SELECT * FROM accounts
+        WHERE deleted_at IS NULL AND ` + merchantFilter("merchant_id", 3) + `
         ORDER BY created_at DESC
 		LIMIT $1 OFFSET $2; `
 
 	var accounts []*models.Account
-	err := r.db.SelectContext(ctx, &accounts, query, limit, offset)
+	err = r.db.SelectContext(ctx, &accounts, query, limit, offset, merchantId)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get accounts: %w", err)
 	}
@@ -55,9 +80,14 @@ func (r *accountRepositoryImpl) GetAllAccounts(ctx context.Context, page, limit
 }
 
 func (r *accountRepositoryImpl) GetAccountById(ctx context.Context, accountID string) (*models.Account, error) {
-	query := `SELECT * FROM accounts WHERE id = $1`
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT * FROM accounts WHERE id = $1 AND ` + merchantFilter("merchant_id", 2)
 	var account models.Account
-	err := r.db.GetContext(ctx, &account, query, accountID)
+	err = r.db.GetContext(ctx, &account, query, accountID, merchantId)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -67,3 +97,135 @@ func (r *accountRepositoryImpl) GetAccountById(ctx context.Context, accountID st
 
 	return &account, nil
 }
+
+// GetAccountByUsername matches the username case-insensitively among accounts
+// of the merchant that are not deleted, mirroring the unique index on
+// (merchant_id, LOWER(username)). Usernames are only unique per merchant, so
+// ctx must be limited to a single merchant.
+func (r *accountRepositoryImpl) GetAccountByUsername(ctx context.Context, username string) (*models.Account, error) {
+	merchantId, ok := tenant.MerchantId(ctx)
+	if !ok {
+		return nil, tenant.ErrMissingScope
+	}
+
+	query := `SELECT * FROM accounts WHERE merchant_id = $1 AND LOWER(username) = LOWER($2) AND deleted_at IS NULL`
+	var account models.Account
+	err := r.db.GetContext(ctx, &account, query, merchantId, username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get account by username: %w", err)
+	}
+
+	return &account, nil
+}
+
+func (r *accountRepositoryImpl) SetPasswordHash(ctx context.Context, id, passwordHash string) error {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE accounts SET password_hash = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND ` + merchantFilter("merchant_id", 3)
+	if _, err := r.db.ExecContext(ctx, query, id, passwordHash, merchantId); err != nil {
+		return fmt.Errorf("failed to set account password: %w", err)
+	}
+	return nil
+}
+
+// UpdateAccount changes the username and/or merges metadata into the existing
+// object. Top-level metadata keys set to null are removed. Closed accounts are
+// left untouched and yield a nil account.
+func (r *accountRepositoryImpl) UpdateAccount(ctx context.Context, id string, username *string, metadata types.JSONText) (*models.Account, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        UPDATE accounts
+        SET username = COALESCE($2, username),
+            metadata = CASE WHEN $3::jsonb IS NULL THEN metadata ELSE jsonb_strip_nulls(metadata || $3::jsonb) END,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND status <> 'CLOSED' AND ` + merchantFilter("merchant_id", 4) + `
+        RETURNING *;
+    `
+
+	var metadataArg interface{}
+	if len(metadata) > 0 {
+		metadataArg = metadata
+	}
+
+	var account models.Account
+	err = r.db.GetContext(ctx, &account, query, id, username, metadataArg, merchantId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("failed to update account: %w", ErrDuplicateKey)
+		}
+		return nil, fmt.Errorf("failed to update account: %w", err)
+	}
+
+	return &account, nil
+}
+
+// UpdateAccountStatus moves the account from fromStatus to toStatus. It returns
+// a nil account when the account is no longer in fromStatus, so concurrent
+// transitions cannot both succeed.
+func (r *accountRepositoryImpl) UpdateAccountStatus(ctx context.Context, id, fromStatus, toStatus string, reason *string) (*models.Account, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        UPDATE accounts
+        SET status = $3, status_reason = $4, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND status = $2 AND deleted_at IS NULL AND ` + merchantFilter("merchant_id", 5) + `
+        RETURNING *;
+    `
+
+	var account models.Account
+	err = r.db.GetContext(ctx, &account, query, id, fromStatus, toStatus, reason, merchantId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to update account status: %w", err)
+	}
+
+	return &account, nil
+}
+
+// SoftDeleteAccount closes the account and stamps deleted_at. The row and its
+// cards and transactions are kept for history.
+func (r *accountRepositoryImpl) SoftDeleteAccount(ctx context.Context, id, fromStatus string, reason *string) (*models.Account, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        UPDATE accounts
+        SET status = 'CLOSED',
+            status_reason = COALESCE($3, status_reason),
+            deleted_at = CURRENT_TIMESTAMP,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND status = $2 AND deleted_at IS NULL AND ` + merchantFilter("merchant_id", 4) + `
+        RETURNING *;
+    `
+
+	var account models.Account
+	err = r.db.GetContext(ctx, &account, query, id, fromStatus, reason, merchantId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to delete account: %w", err)
+	}
+
+	return &account, nil
+}
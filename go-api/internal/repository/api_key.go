@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"payment-gateway/go-api/internal/models"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type APIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, key *models.APIKey) error
+	GetAPIKeyById(ctx context.Context, id string) (*models.APIKey, error)
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (*models.APIKey, error)
+	ListAPIKeys(ctx context.Context, accountId string) ([]*models.APIKey, error)
+	RotateAPIKey(ctx context.Context, id, prefix, keyHash string) (*models.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id string) (*models.APIKey, error)
+	TouchAPIKey(ctx context.Context, id string) error
+}
+
+type apiKeyRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewAPIKeyRepository(db *sqlx.DB) APIKeyRepository {
+	return &apiKeyRepositoryImpl{db: db}
+}
+
+// CreateAPIKey creates key under key.MerchantId. Keys without a merchant reach
+// every merchant and can only be created from the platform scope.
+func (r *apiKeyRepositoryImpl) CreateAPIKey(ctx context.Context, key *models.APIKey) error {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return err
+	}
+	if merchantId != "" && key.MerchantId.String != merchantId {
+		return fmt.Errorf("failed to create api key: %w", ErrOutOfScope)
+	}
+
+	query := `
+		INSERT INTO api_keys (owner_type, merchant_id, account_id, operator_id, name, prefix, key_hash, scopes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at;
+	`
+
+	err = r.db.QueryRowContext(ctx, query, key.OwnerType, key.MerchantId, key.AccountId, key.OperatorId, key.Name, key.Prefix, key.KeyHash, key.Scopes).Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to create api key: %w", ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return nil
+}
+
+func (r *apiKeyRepositoryImpl) GetAPIKeyById(ctx context.Context, id string) (*models.APIKey, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.getAPIKey(ctx, `SELECT * FROM api_keys WHERE id = $1 AND `+merchantFilter("merchant_id", 2), id, merchantId)
+}
+
+// GetAPIKeyByPrefix looks a key up by its public prefix. It runs before the
+// caller is known and is therefore not limited to a merchant.
+func (r *apiKeyRepositoryImpl) GetAPIKeyByPrefix(ctx context.Context, prefix string) (*models.APIKey, error) {
+	return r.getAPIKey(ctx, `SELECT * FROM api_keys WHERE prefix = $1`, prefix)
+}
+
+func (r *apiKeyRepositoryImpl) getAPIKey(ctx context.Context, query string, args ...interface{}) (*models.APIKey, error) {
+	var key models.APIKey
+	err := r.db.GetContext(ctx, &key, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return &key, nil
+}
+
+// ListAPIKeys returns the keys of accountId, or every key in the merchant scope
+// of ctx when accountId is empty, newest first. Revoked keys are included.
+func (r *apiKeyRepositoryImpl) ListAPIKeys(ctx context.Context, accountId string) ([]*models.APIKey, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT * FROM api_keys
+		WHERE ($1 = '' OR account_id = NULLIF($1, '')::uuid) AND ` + merchantFilter("merchant_id", 2) + `
+		ORDER BY created_at DESC;
+	`
+
+	var keys []*models.APIKey
+	if err := r.db.SelectContext(ctx, &keys, query, accountId, merchantId); err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	return keys, nil
+}
+
+// RotateAPIKey replaces the secret of an active key. The previous secret stops
+// working as soon as the update commits.
+func (r *apiKeyRepositoryImpl) RotateAPIKey(ctx context.Context, id, prefix, keyHash string) (*models.APIKey, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE api_keys
+		SET prefix = $2, key_hash = $3, rotated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND revoked_at IS NULL AND ` + merchantFilter("merchant_id", 4) + `
+		RETURNING *;
+	`
+
+	var key models.APIKey
+	err = r.db.GetContext(ctx, &key, query, id, prefix, keyHash, merchantId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("failed to rotate api key: %w", ErrDuplicateKey)
+		}
+		return nil, fmt.Errorf("failed to rotate api key: %w", err)
+	}
+
+	return &key, nil
+}
+
+// RevokeAPIKey revokes the key. Revoking an already revoked key keeps the
+// original revocation time.
+func (r *apiKeyRepositoryImpl) RevokeAPIKey(ctx context.Context, id string) (*models.APIKey, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE api_keys
+		SET revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP)
+		WHERE id = $1 AND ` + merchantFilter("merchant_id", 2) + `
+		RETURNING *;
+	`
+
+	var key models.APIKey
+	err = r.db.GetContext(ctx, &key, query, id, merchantId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	return &key, nil
+}
+
+// TouchAPIKey records a use of the key. It writes at most once a minute per key
+// so busy keys do not turn every request into a row update. Like
+// GetAPIKeyByPrefix it runs while authenticating and is not merchant scoped.
+func (r *apiKeyRepositoryImpl) TouchAPIKey(ctx context.Context, id string) error {
+	query := `
+		UPDATE api_keys SET last_used_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < CURRENT_TIMESTAMP - INTERVAL '1 minute');
+	`
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to update api key last use: %w", err)
+	}
+	return nil
+}
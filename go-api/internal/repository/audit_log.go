@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"payment-gateway/go-api/internal/models"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type AuditLogRepository interface {
+	CreateAuditLog(ctx context.Context, entry *models.AuditLog) error
+	ListAuditLogs(ctx context.Context, filter models.AuditLogFilter) ([]*models.AuditLog, error)
+}
+
+type auditLogRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewAuditLogRepository(db *sqlx.DB) AuditLogRepository {
+	return &auditLogRepositoryImpl{db: db}
+}
+
+func (r *auditLogRepositoryImpl) CreateAuditLog(ctx context.Context, entry *models.AuditLog) error {
+	query := `
+		INSERT INTO audit_logs (merchant_id, operator_id, api_key_id, role, method, route, path, account_id, status_code)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at;
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		entry.MerchantId,
+		entry.OperatorId,
+		entry.APIKeyId,
+		entry.Role,
+		entry.Method,
+		entry.Route,
+		entry.Path,
+		entry.AccountId,
+		entry.StatusCode,
+	).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create audit log: %w", err)
+	}
+
+	return nil
+}
+
+// ListAuditLogs returns up to filter.Limit entries matching filter within the
+// merchant scope of ctx, newest first.
+func (r *auditLogRepositoryImpl) ListAuditLogs(ctx context.Context, filter models.AuditLogFilter) ([]*models.AuditLog, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var conditions []string
+	var args []interface{}
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	arg(merchantId)
+	conditions = append(conditions, merchantFilter("merchant_id", len(args)))
+
+	if filter.OperatorId != "" {
+		conditions = append(conditions, "operator_id = "+arg(filter.OperatorId))
+	}
+	if filter.AccountId != "" {
+		conditions = append(conditions, "account_id = "+arg(filter.AccountId))
+	}
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, "created_at >= "+arg(filter.CreatedFrom.UTC()))
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, "created_at < "+arg(filter.CreatedTo.UTC()))
+	}
+
+	query := "SELECT * FROM audit_logs WHERE " + strings.Join(conditions, " AND ")
+	query += " ORDER BY created_at DESC, id DESC LIMIT " + arg(filter.Limit)
+
+	var entries []*models.AuditLog
+	if err := r.db.SelectContext(ctx, &entries, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"payment-gateway/go-api/internal/models"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func TestBalanceSnapshotsFollowLateStatusChanges(t *testing.T) {
+	db := testDB(t)
+	r := newRepositories(db)
+	f := seedMerchant(t, r, "Merchant")
+
+	// The pending refund of 300 was created long before the snapshot job
+	// last rewrote its day and is only approved now.
+	var refundId string
+	if err := db.Get(&refundId, `SELECT id FROM transactions WHERE idempotency_key = 'order-refund' AND merchant_id = $1`, f.merchantId); err != nil {
+		t.Fatalf("failed to find refund: %v", err)
+	}
+	if _, err := db.Exec(`UPDATE accounts SET created_at = NOW() - INTERVAL '30 days' WHERE id = $1`, f.accountId); err != nil {
+		t.Fatalf("failed to backdate account: %v", err)
+	}
+	if _, err := db.Exec(`UPDATE transactions SET created_at = NOW() - INTERVAL '10 days' WHERE id = $1`, refundId); err != nil {
+		t.Fatalf("failed to backdate refund: %v", err)
+	}
+
+	now := time.Now().UTC()
+	before, after := now.AddDate(0, 0, -20), now.AddDate(0, 0, -5)
+	for _, day := range []time.Time{before, after, now} {
+		if _, err := r.transactions.RefreshBalanceSnapshots(f.ctx, day); err != nil {
+			t.Fatalf("RefreshBalanceSnapshots failed: %v", err)
+		}
+	}
+
+	snapshots := func(t *testing.T, want map[time.Time]int64) {
+		t.Helper()
+		for day, cents := range want {
+			var balance int64
+			if err := db.Get(&balance, `SELECT balance_cents FROM balance_snapshots WHERE account_id = $1 AND snapshot_date = $2::date`, f.accountId, day.Format("2006-01-02")); err != nil {
+				t.Fatalf("failed to read snapshot of %s: %v", day.Format("2006-01-02"), err)
+			}
+			if balance != cents {
+				t.Errorf("snapshot of %s = %d, want %d", day.Format("2006-01-02"), balance, cents)
+			}
+		}
+	}
+	setStatus := func(t *testing.T, status string) {
+		t.Helper()
+		inTx(t, r, func(dbTx *sqlx.Tx) {
+			if err := r.transactions.UpdateStatus(f.ctx, dbTx, refundId, status); err != nil {
+				t.Fatalf("UpdateStatus failed: %v", err)
+			}
+		})
+	}
+
+	snapshots(t, map[time.Time]int64{before: 0, after: 0, now: fixtureBalanceCents})
+
+	setStatus(t, models.TransactionStatusApproved)
+	snapshots(t, map[time.Time]int64{before: 0, after: 300, now: fixtureBalanceCents + 300})
+
+	setStatus(t, models.TransactionStatusRejected)
+	snapshots(t, map[time.Time]int64{before: 0, after: 0, now: fixtureBalanceCents})
+}
@@ -11,10 +11,28 @@ import (
 
 type CardRepository interface {
 	CreateCard(ctx context.Context, card *models.Card) error
+	GetCardById(ctx context.Context, id string) (*models.Card, error)
+	GetCardForUpdate(ctx context.Context, dbTx *sqlx.Tx, id string) (*models.Card, error)
 	GetAllCardsByAccountId(ctx context.Context, accountId string) ([]*models.Card, error)
-	GetCardByTokenAndAccountId(ctx context.Context, cardToken, accountId string) (string, error)
+	GetCardByTokenAndAccountId(ctx context.Context, cardToken, accountId string) (*models.Card, error)
+	UpdateCardStatus(ctx context.Context, id, fromStatus, toStatus string, reason *string) (*models.Card, error)
+	ReplaceCard(ctx context.Context, replacedId, fromStatus string, reason *string, card *models.Card) (bool, error)
+	ExpireCards(ctx context.Context) (int64, error)
 }
 
+// cardColumns selects card c with the usage left on virtual cards. A purchase
+// uses the card from the moment it is created until it is rejected, voided or
+// expired. The transactions of standard cards are never scanned.
+const cardColumns = `c.*,
+        CASE WHEN c.kind = 'SINGLE_USE' THEN GREATEST(1 - (
+            SELECT COUNT(*) FROM transactions t
+            WHERE t.card_id = c.id AND t.type = 'PURCHASE' AND t.status IN ('PENDING', 'AUTHORIZED', 'APPROVED')
+        ), 0) END AS remaining_uses,
+        CASE WHEN c.amount_limit_cents IS NOT NULL THEN GREATEST(c.amount_limit_cents - (
+            SELECT COALESCE(SUM(t.amount_cents), 0) FROM transactions t
+            WHERE t.card_id = c.id AND t.type = 'PURCHASE' AND t.status IN ('PENDING', 'AUTHORIZED', 'APPROVED')
+        ), 0) END AS remaining_amount_cents`
+
 type cardRepositoryImpl struct {
 	db *sqlx.DB
 }
@@ -23,33 +41,98 @@ func NewCardRepository(db *sqlx.DB) CardRepository {
 	return &cardRepositoryImpl{db: db}
 }
 
+// CreateCard issues card on card.AccountId under the account's merchant. The
+// account must be within the merchant scope of ctx.
 func (r *cardRepositoryImpl) CreateCard(ctx context.Context, card *models.Card) error {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return err
+	}
+
 	query := `
-        INSERT INTO cards (account_id, card_token, last_four_digits)
-        VALUES ($1, $2, $3)
-        RETURNING id, card_token, last_four_digits, created_at;
+        WITH c AS (
+            INSERT INTO cards (account_id, merchant_id, card_token, last_four_digits, brand, expiry_month, expiry_year, kind, locked_acceptor_id, amount_limit_cents)
+            SELECT a.id, a.merchant_id, $2, $3, $4, $5, $6, $7, $8, $9
+            FROM accounts a
+            WHERE a.id = $1 AND ` + merchantFilter("a.merchant_id", 10) + `
+            RETURNING *
+        )
+        SELECT ` + cardColumns + ` FROM c;
     `
-	err := r.db.QueryRowContext(ctx, query, card.AccountId, card.CardToken, card.LastFourDigits).Scan(
-		&card.ID,
-		&card.CardToken,
-		&card.LastFourDigits,
-		&card.CreatedAt,
-	)
+	err = r.db.GetContext(ctx, card, query, card.AccountId, card.CardToken, card.LastFourDigits, card.Brand, card.ExpiryMonth, card.ExpiryYear, card.Kind, card.LockedAcceptorId, card.AmountLimitCents, merchantId)
 
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("failed to create card: %w", ErrOutOfScope)
+		}
 		return fmt.Errorf("failed to create card: %w", err)
 	}
 
 	return nil
 }
 
+func (r *cardRepositoryImpl) GetCardById(ctx context.Context, id string) (*models.Card, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT ` + cardColumns + ` FROM cards c WHERE c.id = $1 AND ` + merchantFilter("c.merchant_id", 2) + `;
+    `
+	var card models.Card
+
+	err = r.db.GetContext(ctx, &card, query, id, merchantId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get card: %w", err)
+	}
+
+	return &card, nil
+}
+
+// GetCardForUpdate locks the card until dbTx ends and returns it. Its usage is
+// read after the lock is taken, so it includes the purchases committed by
+// whoever held the lock before.
+func (r *cardRepositoryImpl) GetCardForUpdate(ctx context.Context, dbTx *sqlx.Tx, id string) (*models.Card, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lock := `SELECT id FROM cards WHERE id = $1 AND ` + merchantFilter("merchant_id", 2) + ` FOR UPDATE`
+	if _, err := dbTx.ExecContext(ctx, lock, id, merchantId); err != nil {
+		return nil, fmt.Errorf("failed to lock card: %w", err)
+	}
+
+	query := `SELECT ` + cardColumns + ` FROM cards c WHERE c.id = $1 AND ` + merchantFilter("c.merchant_id", 2)
+
+	var card models.Card
+	err = dbTx.GetContext(ctx, &card, query, id, merchantId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get card: %w", err)
+	}
+
+	return &card, nil
+}
+
 func (r *cardRepositoryImpl) GetAllCardsByAccountId(ctx context.Context, accountId string) ([]*models.Card, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-        SELECT * FROM cards WHERE account_id = $1;
+        SELECT ` + cardColumns + ` FROM cards c WHERE c.account_id = $1 AND ` + merchantFilter("c.merchant_id", 2) + `;
     `
 	var cards []*models.Card
 
-	err := r.db.SelectContext(ctx, &cards, query, accountId)
+	err = r.db.SelectContext(ctx, &cards, query, accountId, merchantId)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cards: %w", err)
@@ -62,19 +145,125 @@ func (r *cardRepositoryImpl) GetAllCardsByAccountId(ctx context.Context, account
 	return cards, nil
 }
 
-func (r *cardRepositoryImpl) GetCardByTokenAndAccountId(ctx context.Context, cardToken, accountId string) (string, error) {
+// GetCardByTokenAndAccountId returns the card with cardToken on accountId,
+// whatever its status, or nil when there is none.
+func (r *cardRepositoryImpl) GetCardByTokenAndAccountId(ctx context.Context, cardToken, accountId string) (*models.Card, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT ` + cardColumns + ` FROM cards c WHERE c.card_token = $1 AND c.account_id = $2 AND ` + merchantFilter("c.merchant_id", 3) + `;
+    `
+	var card models.Card
+
+	err = r.db.GetContext(ctx, &card, query, cardToken, accountId, merchantId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get card by token and account id: %w", err)
+	}
+
+	return &card, nil
+}
+
+// UpdateCardStatus moves the card from fromStatus to toStatus. It returns a nil
+// card when the card is no longer in fromStatus, so concurrent transitions
+// cannot both succeed.
+func (r *cardRepositoryImpl) UpdateCardStatus(ctx context.Context, id, fromStatus, toStatus string, reason *string) (*models.Card, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        WITH c AS (
+            UPDATE cards
+            SET status = $3, status_reason = $4, updated_at = CURRENT_TIMESTAMP
+            WHERE id = $1 AND status = $2 AND ` + merchantFilter("merchant_id", 5) + `
+            RETURNING *
+        )
+        SELECT ` + cardColumns + ` FROM c;
+    `
+
+	var card models.Card
+	err = r.db.GetContext(ctx, &card, query, id, fromStatus, toStatus, reason, merchantId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to update card status: %w", err)
+	}
+
+	return &card, nil
+}
+
+// ReplaceCard issues card as the replacement of replacedId and, unless the
+// replaced card already expired, cancels it, in a single statement. It reports
+// false when the replaced card is no longer in fromStatus. A card that was
+// already replaced fails with ErrDuplicateKey.
+func (r *cardRepositoryImpl) ReplaceCard(ctx context.Context, replacedId, fromStatus string, reason *string, card *models.Card) (bool, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return false, err
+	}
+
 	query := `
-        SELECT id FROM cards WHERE card_token = $1 AND account_id = $2;
+        WITH replaced AS (
+            UPDATE cards
+            SET status = CASE WHEN status = 'EXPIRED' THEN status ELSE 'CANCELLED' END,
+                status_reason = CASE WHEN status = 'EXPIRED' THEN status_reason ELSE $3 END,
+                updated_at = CURRENT_TIMESTAMP
+            WHERE id = $1 AND status = $2 AND ` + merchantFilter("merchant_id", 9) + `
+            RETURNING id, account_id, merchant_id
+        ), c AS (
+            INSERT INTO cards (account_id, merchant_id, card_token, last_four_digits, brand, expiry_month, expiry_year, replaces_card_id)
+            SELECT replaced.account_id, replaced.merchant_id, $4, $5, $6, $7, $8, replaced.id
+            FROM replaced
+            RETURNING *
+        )
+        SELECT ` + cardColumns + ` FROM c;
     `
-	var cardId string
 
-	err := r.db.GetContext(ctx, &cardId, query, cardToken, accountId)
+	err = r.db.GetContext(ctx, card, query, replacedId, fromStatus, reason, card.CardToken, card.LastFourDigits, card.Brand, card.ExpiryMonth, card.ExpiryYear, merchantId)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return "", fmt.Errorf("card not found for given token and account id: %w", err)
+			return false, nil
+		}
+		if isUniqueViolation(err) {
+			return false, fmt.Errorf("failed to replace card: %w", ErrDuplicateKey)
 		}
-		return "", fmt.Errorf("failed to get card by token and account id: %w", err)
+		return false, fmt.Errorf("failed to replace card: %w", err)
+	}
+
+	return true, nil
+}
+
+// ExpireCards marks ACTIVE and BLOCKED cards past the end of their expiry month
+// as EXPIRED and returns how many were updated.
+func (r *cardRepositoryImpl) ExpireCards(ctx context.Context) (int64, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+        UPDATE cards
+        SET status = 'EXPIRED', updated_at = CURRENT_TIMESTAMP
+        WHERE status IN ('ACTIVE', 'BLOCKED')
+            AND (expiry_year, expiry_month) < (
+                EXTRACT(YEAR FROM CURRENT_TIMESTAMP AT TIME ZONE 'UTC')::int,
+                EXTRACT(MONTH FROM CURRENT_TIMESTAMP AT TIME ZONE 'UTC')::int
+            )
+            AND ` + merchantFilter("merchant_id", 1) + `;
+    `
+
+	result, err := r.db.ExecContext(ctx, query, merchantId)
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire cards: %w", err)
 	}
 
-	return cardId, nil
+	return result.RowsAffected()
 }
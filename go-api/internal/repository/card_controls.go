@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"payment-gateway/go-api/internal/models"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type CardControlsRepository interface {
+	GetCardControls(ctx context.Context, cardId string) (*models.CardControls, error)
+	GetCardControlsForUpdate(ctx context.Context, dbTx *sqlx.Tx, cardId string) (*models.CardControls, error)
+	SaveCardControls(ctx context.Context, controls *models.CardControls) error
+}
+
+type cardControlsRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewCardControlsRepository(db *sqlx.DB) CardControlsRepository {
+	return &cardControlsRepositoryImpl{db: db}
+}
+
+func (r *cardControlsRepositoryImpl) GetCardControls(ctx context.Context, cardId string) (*models.CardControls, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT * FROM card_controls WHERE card_id = $1 AND ` + merchantFilter("merchant_id", 2)
+
+	var controls models.CardControls
+	err = r.db.GetContext(ctx, &controls, query, cardId, merchantId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get card controls: %w", err)
+	}
+
+	return &controls, nil
+}
+
+// GetCardControlsForUpdate reads the controls of cardId and locks them until
+// dbTx ends, so concurrent transactions on the card are checked one at a time.
+func (r *cardControlsRepositoryImpl) GetCardControlsForUpdate(ctx context.Context, dbTx *sqlx.Tx, cardId string) (*models.CardControls, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT * FROM card_controls WHERE card_id = $1 AND ` + merchantFilter("merchant_id", 2) + ` FOR UPDATE`
+
+	var controls models.CardControls
+	err = dbTx.GetContext(ctx, &controls, query, cardId, merchantId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get card controls: %w", err)
+	}
+
+	return &controls, nil
+}
+
+// SaveCardControls replaces the controls of controls.CardId. The card must be
+// within the merchant scope of ctx.
+func (r *cardControlsRepositoryImpl) SaveCardControls(ctx context.Context, controls *models.CardControls) error {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+        INSERT INTO card_controls (card_id, merchant_id, max_transaction_cents, daily_limit_cents, monthly_limit_cents,
+            allowed_transaction_types, blocked_merchant_categories, velocity_limit, velocity_window_seconds)
+        SELECT c.id, c.merchant_id, $2, $3, $4, $5, $6, $7, $8
+        FROM cards c
+        WHERE c.id = $1 AND ` + merchantFilter("c.merchant_id", 9) + `
+        ON CONFLICT (card_id) DO UPDATE
+        SET max_transaction_cents = EXCLUDED.max_transaction_cents,
+            daily_limit_cents = EXCLUDED.daily_limit_cents,
+            monthly_limit_cents = EXCLUDED.monthly_limit_cents,
+            allowed_transaction_types = EXCLUDED.allowed_transaction_types,
+            blocked_merchant_categories = EXCLUDED.blocked_merchant_categories,
+            velocity_limit = EXCLUDED.velocity_limit,
+            velocity_window_seconds = EXCLUDED.velocity_window_seconds,
+            updated_at = CURRENT_TIMESTAMP
+        RETURNING *;
+    `
+
+	err = r.db.GetContext(ctx, controls, query,
+		controls.CardId,
+		controls.MaxTransactionCents,
+		controls.DailyLimitCents,
+		controls.MonthlyLimitCents,
+		controls.AllowedTransactionTypes,
+		controls.BlockedMerchantCategories,
+		controls.VelocityLimit,
+		controls.VelocityWindowSeconds,
+		merchantId,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("failed to save card controls: %w", ErrOutOfScope)
+		}
+		return fmt.Errorf("failed to save card controls: %w", err)
+	}
+
+	return nil
+}
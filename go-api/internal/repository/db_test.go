@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// testDB connects to TEST_DATABASE_URL and migrates a schema of its own, which
+// is dropped when the test ends. Tests using it are skipped when the variable
+// is not set, except in CI, where they fail instead of passing unrun.
+func testDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		if os.Getenv("CI") != "" {
+			t.Fatal("TEST_DATABASE_URL must be set in CI")
+		}
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	admin, err := sqlx.Connect("postgres", url)
+	if err != nil {
+		t.Fatalf("failed to connect to the test database: %v", err)
+	}
+	defer admin.Close()
+
+	schema := "test_" + randomHex(t, 8)
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		admin, err := sqlx.Connect("postgres", url)
+		if err != nil {
+			t.Errorf("failed to connect to drop schema %s: %v", schema, err)
+			return
+		}
+		defer admin.Close()
+		if _, err := admin.Exec("DROP SCHEMA " + schema + " CASCADE"); err != nil {
+			t.Errorf("failed to drop schema %s: %v", schema, err)
+		}
+	})
+
+	// Unknown connection settings are sent to the server as run-time
+	// parameters, so every pooled connection works in the new schema.
+	dsn := url
+	if strings.HasPrefix(url, "postgres://") || strings.HasPrefix(url, "postgresql://") {
+		if dsn, err = pq.ParseURL(url); err != nil {
+			t.Fatalf("failed to parse TEST_DATABASE_URL: %v", err)
+		}
+	}
+	db, err := sqlx.Connect("postgres", dsn+" search_path="+schema+",public")
+	if err != nil {
+		t.Fatalf("failed to connect to schema %s: %v", schema, err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrate(t, db)
+	return db
+}
+
+// migrate applies go-api/migrations in version order. Files are run without
+// arguments so they may hold several statements.
+func migrate(t *testing.T, db *sqlx.DB) {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join("..", "..", "migrations", "*.sql"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("failed to find migrations: %v", err)
+	}
+	version := func(file string) int {
+		n, err := strconv.Atoi(strings.SplitN(filepath.Base(file), "_", 2)[0])
+		if err != nil {
+			t.Fatalf("migration %s has no version: %v", file, err)
+		}
+		return n
+	}
+	sort.Slice(files, func(i, j int) bool { return version(files[i]) < version(files[j]) })
+
+	for _, file := range files {
+		sql, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("failed to read migration %s: %v", file, err)
+		}
+		if _, err := db.ExecContext(context.Background(), string(sql)); err != nil {
+			t.Fatalf("failed to apply migration %s: %v", filepath.Base(file), err)
+		}
+	}
+}
+
+func randomHex(t *testing.T, n int) string {
+	t.Helper()
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatalf("failed to read random bytes: %v", err)
+	}
+	return hex.EncodeToString(b)
+}
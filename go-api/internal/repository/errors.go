@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// ErrDuplicateKey is returned when an insert or update violates a unique constraint.
+var ErrDuplicateKey = errors.New("duplicate key")
+
+const uniqueViolationCode = "23505"
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"payment-gateway/go-api/internal/models"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type IdempotencyKeyRepository interface {
+	Acquire(ctx context.Context, callerId, key, requestHash string, ttl time.Duration) (bool, error)
+	GetByKey(ctx context.Context, callerId, key string) (*models.IdempotencyKey, error)
+	SaveResponse(ctx context.Context, callerId, key string, status int, body []byte) error
+	Delete(ctx context.Context, callerId, key string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type idempotencyKeyRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewIdempotencyKeyRepository(db *sqlx.DB) IdempotencyKeyRepository {
+	return &idempotencyKeyRepositoryImpl{db: db}
+}
+
+// Acquire reserves the key for callerId. It returns false when the key is
+// already held by another request of the same caller that has not expired yet.
+func (r *idempotencyKeyRepositoryImpl) Acquire(ctx context.Context, callerId, key, requestHash string, ttl time.Duration) (bool, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	deleteExpired := `DELETE FROM idempotency_keys WHERE merchant_scope = $1 AND caller_id = $2 AND key = $3 AND expires_at <= NOW();`
+	if _, err := r.db.ExecContext(ctx, deleteExpired, merchantId, callerId, key); err != nil {
+		return false, fmt.Errorf("failed to release expired idempotency key: %w", err)
+	}
+
+	query := `
+        INSERT INTO idempotency_keys (merchant_scope, caller_id, key, request_hash, expires_at)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (merchant_scope, caller_id, key) DO NOTHING;
+    `
+	result, err := r.db.ExecContext(ctx, query, merchantId, callerId, key, requestHash, time.Now().UTC().Add(ttl))
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire idempotency key: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire idempotency key: %w", err)
+	}
+
+	return rows == 1, nil
+}
+
+func (r *idempotencyKeyRepositoryImpl) GetByKey(ctx context.Context, callerId, key string) (*models.IdempotencyKey, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT * FROM idempotency_keys WHERE merchant_scope = $1 AND caller_id = $2 AND key = $3`
+	var record models.IdempotencyKey
+
+	err = r.db.GetContext(ctx, &record, query, merchantId, callerId, key)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	return &record, nil
+}
+
+func (r *idempotencyKeyRepositoryImpl) SaveResponse(ctx context.Context, callerId, key string, status int, body []byte) error {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+        UPDATE idempotency_keys
+        SET response_status = $1, response_body = $2
+        WHERE merchant_scope = $3 AND caller_id = $4 AND key = $5;
+    `
+	if _, err := r.db.ExecContext(ctx, query, status, body, merchantId, callerId, key); err != nil {
+		return fmt.Errorf("failed to save idempotent response: %w", err)
+	}
+
+	return nil
+}
+
+func (r *idempotencyKeyRepositoryImpl) Delete(ctx context.Context, callerId, key string) error {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `DELETE FROM idempotency_keys WHERE merchant_scope = $1 AND caller_id = $2 AND key = $3;`
+	if _, err := r.db.ExecContext(ctx, query, merchantId, callerId, key); err != nil {
+		return fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpired purges expired keys of every merchant. It returns no rows, so
+// it runs without a merchant scope.
+func (r *idempotencyKeyRepositoryImpl) DeleteExpired(ctx context.Context) (int64, error) {
+	query := `DELETE FROM idempotency_keys WHERE expires_at <= NOW();`
+	result, err := r.db.ExecContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	return result.RowsAffected()
+}
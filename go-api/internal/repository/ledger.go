@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"payment-gateway/go-api/internal/models"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type LedgerRepository interface {
+	CreateJournalEntry(ctx context.Context, dbTx *sqlx.Tx, entry *models.JournalEntry, postings []*models.Posting) error
+	GetPostingsByAccountId(ctx context.Context, accountId string, page, limit int) ([]*models.AccountPosting, error)
+}
+
+type ledgerRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewLedgerRepository(db *sqlx.DB) LedgerRepository {
+	return &ledgerRepositoryImpl{db: db}
+}
+
+// CreateJournalEntry writes entry and its postings in dbTx. The database
+// rejects the commit if the postings do not balance.
+func (r *ledgerRepositoryImpl) CreateJournalEntry(ctx context.Context, dbTx *sqlx.Tx, entry *models.JournalEntry, postings []*models.Posting) error {
+	entryQuery := `
+		INSERT INTO journal_entries (transaction_id, kind)
+		VALUES ($1, $2)
+		RETURNING id, created_at;
+	`
+	if err := dbTx.QueryRowContext(ctx, entryQuery, entry.TransactionId, entry.Kind).Scan(&entry.ID, &entry.CreatedAt); err != nil {
+		return fmt.Errorf("failed to create journal entry: %w", err)
+	}
+
+	postingQuery := `
+		INSERT INTO postings (journal_entry_id, account_id, system_account, direction, amount_cents)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at;
+	`
+	for _, posting := range postings {
+		posting.JournalEntryId = entry.ID
+		err := dbTx.QueryRowContext(
+			ctx,
+			postingQuery,
+			posting.JournalEntryId,
+			posting.AccountId,
+			posting.SystemAccount,
+			posting.Direction,
+			posting.AmountCents,
+		).Scan(&posting.ID, &posting.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create posting: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *ledgerRepositoryImpl) GetPostingsByAccountId(ctx context.Context, accountId string, page, limit int) ([]*models.AccountPosting, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := (page - 1) * limit
+	query := `
+		SELECT p.*, je.kind, je.transaction_id, t.status AS transaction_status
+		FROM postings p
+		JOIN journal_entries je ON je.id = p.journal_entry_id
+		JOIN transactions t ON t.id = je.transaction_id
+		WHERE p.account_id = $1 AND ` + merchantFilter("t.merchant_id", 4) + `
+		ORDER BY p.created_at DESC, p.id DESC
+		LIMIT $2 OFFSET $3
+	`
+	var postings []*models.AccountPosting
+
+	if err := r.db.SelectContext(ctx, &postings, query, accountId, limit, offset, merchantId); err != nil {
+		return nil, fmt.Errorf("failed to get postings by account id: %w", err)
+	}
+
+	return postings, nil
+}
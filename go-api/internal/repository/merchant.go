@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"payment-gateway/go-api/internal/models"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type MerchantRepository interface {
+	CreateMerchant(ctx context.Context, merchant *models.Merchant) error
+	GetMerchantById(ctx context.Context, id string) (*models.Merchant, error)
+	ListMerchants(ctx context.Context) ([]*models.Merchant, error)
+}
+
+type merchantRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewMerchantRepository(db *sqlx.DB) MerchantRepository {
+	return &merchantRepositoryImpl{db: db}
+}
+
+// CreateMerchant creates a new tenant. Only the platform scope may create
+// merchants.
+func (r *merchantRepositoryImpl) CreateMerchant(ctx context.Context, merchant *models.Merchant) error {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return err
+	}
+	if merchantId != "" {
+		return fmt.Errorf("failed to create merchant: %w", ErrOutOfScope)
+	}
+
+	query := `
+		INSERT INTO merchants (name)
+		VALUES ($1)
+		RETURNING id, created_at, updated_at;
+	`
+
+	err = r.db.QueryRowContext(ctx, query, merchant.Name).Scan(&merchant.ID, &merchant.CreatedAt, &merchant.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create merchant: %w", err)
+	}
+
+	return nil
+}
+
+func (r *merchantRepositoryImpl) GetMerchantById(ctx context.Context, id string) (*models.Merchant, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var merchant models.Merchant
+	err = r.db.GetContext(ctx, &merchant, `SELECT * FROM merchants WHERE id = $1 AND `+merchantFilter("id", 2), id, merchantId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get merchant: %w", err)
+	}
+
+	return &merchant, nil
+}
+
+// ListMerchants returns the merchants within the scope of ctx: every merchant
+// for the platform scope, otherwise only the caller's own.
+func (r *merchantRepositoryImpl) ListMerchants(ctx context.Context) ([]*models.Merchant, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT * FROM merchants WHERE ` + merchantFilter("id", 1) + ` ORDER BY created_at`
+
+	var merchants []*models.Merchant
+	if err := r.db.SelectContext(ctx, &merchants, query, merchantId); err != nil {
+		return nil, fmt.Errorf("failed to list merchants: %w", err)
+	}
+	return merchants, nil
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"payment-gateway/go-api/internal/models"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type OperatorRepository interface {
+	CreateOperator(ctx context.Context, operator *models.Operator) error
+	GetOperatorById(ctx context.Context, id string) (*models.Operator, error)
+	ListOperators(ctx context.Context) ([]*models.Operator, error)
+	UpdateOperator(ctx context.Context, id string, role *string, active *bool) (*models.Operator, error)
+	GetRole(ctx context.Context, name string) (*models.Role, error)
+	ListRoles(ctx context.Context) ([]*models.Role, error)
+}
+
+type operatorRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewOperatorRepository(db *sqlx.DB) OperatorRepository {
+	return &operatorRepositoryImpl{db: db}
+}
+
+// CreateOperator creates operator under operator.MerchantId. Operators without
+// a merchant work across merchants and can only be created from the platform
+// scope.
+func (r *operatorRepositoryImpl) CreateOperator(ctx context.Context, operator *models.Operator) error {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return err
+	}
+	if merchantId != "" && operator.MerchantId.String != merchantId {
+		return fmt.Errorf("failed to create operator: %w", ErrOutOfScope)
+	}
+
+	query := `
+		INSERT INTO operators (merchant_id, username, name, role)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, active, created_at, updated_at;
+	`
+
+	err = r.db.QueryRowContext(ctx, query, operator.MerchantId, operator.Username, operator.Name, operator.Role).
+		Scan(&operator.ID, &operator.Active, &operator.CreatedAt, &operator.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to create operator: %w", ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to create operator: %w", err)
+	}
+
+	return nil
+}
+
+func (r *operatorRepositoryImpl) GetOperatorById(ctx context.Context, id string) (*models.Operator, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var operator models.Operator
+	err = r.db.GetContext(ctx, &operator, `SELECT * FROM operators WHERE id = $1 AND `+merchantFilter("merchant_id", 2), id, merchantId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get operator: %w", err)
+	}
+
+	return &operator, nil
+}
+
+func (r *operatorRepositoryImpl) ListOperators(ctx context.Context) ([]*models.Operator, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT * FROM operators WHERE ` + merchantFilter("merchant_id", 1) + ` ORDER BY LOWER(username)`
+
+	var operators []*models.Operator
+	if err := r.db.SelectContext(ctx, &operators, query, merchantId); err != nil {
+		return nil, fmt.Errorf("failed to list operators: %w", err)
+	}
+	return operators, nil
+}
+
+// UpdateOperator changes the role and active flag of the operator. Nil fields
+// are left unchanged.
+func (r *operatorRepositoryImpl) UpdateOperator(ctx context.Context, id string, role *string, active *bool) (*models.Operator, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE operators
+		SET role = COALESCE($2, role), active = COALESCE($3, active), updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND ` + merchantFilter("merchant_id", 4) + `
+		RETURNING *;
+	`
+
+	var operator models.Operator
+	err = r.db.GetContext(ctx, &operator, query, id, role, active, merchantId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to update operator: %w", err)
+	}
+
+	return &operator, nil
+}
+
+// rolesQuery selects roles together with the permissions the policy table
+// grants them.
+const rolesQuery = `
+	SELECT r.name, r.description, COALESCE(array_agg(p.permission ORDER BY p.permission) FILTER (WHERE p.permission IS NOT NULL), '{}') AS permissions
+	FROM roles r
+	LEFT JOIN role_permissions p ON p.role = r.name
+`
+
+func (r *operatorRepositoryImpl) GetRole(ctx context.Context, name string) (*models.Role, error) {
+	var role models.Role
+	err := r.db.GetContext(ctx, &role, rolesQuery+` WHERE r.name = $1 GROUP BY r.name`, name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+
+	return &role, nil
+}
+
+func (r *operatorRepositoryImpl) ListRoles(ctx context.Context) ([]*models.Role, error) {
+	var roles []*models.Role
+	if err := r.db.SelectContext(ctx, &roles, rolesQuery+` GROUP BY r.name ORDER BY r.name`); err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	return roles, nil
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"payment-gateway/go-api/internal/models"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type OutboxRepository interface {
+	BeginTx(ctx context.Context) (*sqlx.Tx, error)
+	Enqueue(ctx context.Context, dbTx *sqlx.Tx, msg *models.OutboxMessage) error
+	FetchPending(ctx context.Context, dbTx *sqlx.Tx, limit int) ([]*models.OutboxMessage, error)
+	MarkDispatched(ctx context.Context, dbTx *sqlx.Tx, id string) error
+	MarkFailed(ctx context.Context, dbTx *sqlx.Tx, id, lastError string, nextAttemptAt time.Time) error
+	GetBacklog(ctx context.Context) (*models.OutboxBacklog, error)
+}
+
+type outboxRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewOutboxRepository(db *sqlx.DB) OutboxRepository {
+	return &outboxRepositoryImpl{db: db}
+}
+
+func (r *outboxRepositoryImpl) BeginTx(ctx context.Context) (*sqlx.Tx, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin outbox transaction: %w", err)
+	}
+	return tx, nil
+}
+
+func (r *outboxRepositoryImpl) Enqueue(ctx context.Context, dbTx *sqlx.Tx, msg *models.OutboxMessage) error {
+	query := `
+        INSERT INTO outbox_messages (aggregate_id, queue_name, payload)
+        VALUES ($1, $2, $3)
+        RETURNING id, attempts, next_attempt_at, created_at;
+    `
+	err := dbTx.QueryRowContext(ctx, query, msg.AggregateId, msg.QueueName, msg.Payload).Scan(
+		&msg.ID,
+		&msg.Attempts,
+		&msg.NextAttemptAt,
+		&msg.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue outbox message: %w", err)
+	}
+
+	return nil
+}
+
+// FetchPending locks up to limit messages that are due for publishing. Rows
+// locked by another relay instance are skipped.
+func (r *outboxRepositoryImpl) FetchPending(ctx context.Context, dbTx *sqlx.Tx, limit int) ([]*models.OutboxMessage, error) {
+	query := `
+        SELECT * FROM outbox_messages
+        WHERE dispatched_at IS NULL AND next_attempt_at <= NOW()
+        ORDER BY created_at
+        LIMIT $1
+        FOR UPDATE SKIP LOCKED;
+    `
+	var messages []*models.OutboxMessage
+
+	if err := dbTx.SelectContext(ctx, &messages, query, limit); err != nil {
+		return nil, fmt.Errorf("failed to fetch pending outbox messages: %w", err)
+	}
+
+	return messages, nil
+}
+
+func (r *outboxRepositoryImpl) MarkDispatched(ctx context.Context, dbTx *sqlx.Tx, id string) error {
+	query := `UPDATE outbox_messages SET dispatched_at = NOW(), last_error = NULL WHERE id = $1;`
+	if _, err := dbTx.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to mark outbox message as dispatched: %w", err)
+	}
+
+	return nil
+}
+
+func (r *outboxRepositoryImpl) MarkFailed(ctx context.Context, dbTx *sqlx.Tx, id, lastError string, nextAttemptAt time.Time) error {
+	query := `
+        UPDATE outbox_messages
+        SET attempts = attempts + 1, last_error = $1, next_attempt_at = $2
+        WHERE id = $3;
+    `
+	if _, err := dbTx.ExecContext(ctx, query, lastError, nextAttemptAt, id); err != nil {
+		return fmt.Errorf("failed to mark outbox message as failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *outboxRepositoryImpl) GetBacklog(ctx context.Context) (*models.OutboxBacklog, error) {
+	query := `
+        SELECT
+            COUNT(*) AS pending,
+            COUNT(*) FILTER (WHERE attempts > 0) AS retrying,
+            COALESCE(EXTRACT(EPOCH FROM NOW() - MIN(created_at)) * 1000, 0)::DOUBLE PRECISION AS oldest_pending_age_ms
+        FROM outbox_messages
+        WHERE dispatched_at IS NULL;
+    `
+	var backlog models.OutboxBacklog
+
+	if err := r.db.GetContext(ctx, &backlog, query); err != nil {
+		return nil, fmt.Errorf("failed to get outbox backlog: %w", err)
+	}
+
+	return &backlog, nil
+}
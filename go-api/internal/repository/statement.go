@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"payment-gateway/go-api/internal/models"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type StatementRepository interface {
+	BeginSnapshot(ctx context.Context) (*sqlx.Tx, error)
+	GetBalanceAt(ctx context.Context, dbTx *sqlx.Tx, accountId string, at time.Time) (int64, error)
+	StreamEntries(ctx context.Context, dbTx *sqlx.Tx, accountId string, from, to time.Time, fn func(*models.StatementEntry) error) error
+}
+
+type statementRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewStatementRepository(db *sqlx.DB) StatementRepository {
+	return &statementRepositoryImpl{db: db}
+}
+
+// BeginSnapshot starts a read-only transaction in which the balances and the
+// entries of a statement are read from the same snapshot of the database.
+func (r *statementRepositoryImpl) BeginSnapshot(ctx context.Context) (*sqlx.Tx, error) {
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin statement transaction: %w", err)
+	}
+	return tx, nil
+}
+
+func (r *statementRepositoryImpl) GetBalanceAt(ctx context.Context, dbTx *sqlx.Tx, accountId string, at time.Time) (int64, error) {
+	return balanceAt(ctx, dbTx, accountId, at)
+}
+
+// StreamEntries calls fn for every approved transaction of accountId created in
+// [from, to), oldest first, without loading them all in memory.
+func (r *statementRepositoryImpl) StreamEntries(ctx context.Context, dbTx *sqlx.Tx, accountId string, from, to time.Time, fn func(*models.StatementEntry) error) error {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		SELECT t.*, (` + signedAmountSQL + `)::BIGINT AS signed_amount_cents
+		FROM transactions t
+		LEFT JOIN transactions t_orig ON t.refund_transaction_id = t_orig.id
+		WHERE t.account_id = $1
+		AND t.status = 'APPROVED'
+		AND t.created_at >= $2
+		AND t.created_at < $3
+		AND ` + merchantFilter("t.merchant_id", 4) + `
+		ORDER BY t.created_at, t.id
+	`
+
+	rows, err := dbTx.QueryxContext(ctx, query, accountId, from.UTC(), to.UTC(), merchantId)
+	if err != nil {
+		return fmt.Errorf("failed to stream statement entries: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry models.StatementEntry
+		if err := rows.StructScan(&entry); err != nil {
+			return fmt.Errorf("failed to read statement entry: %w", err)
+		}
+		if err := fn(&entry); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to stream statement entries: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,700 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"payment-gateway/go-api/internal/models"
+	"payment-gateway/go-api/internal/tenant"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type repositories struct {
+	accounts     AccountRepository
+	apiKeys      APIKeyRepository
+	auditLogs    AuditLogRepository
+	cards        CardRepository
+	cardControls CardControlsRepository
+	idempotency  IdempotencyKeyRepository
+	ledger       LedgerRepository
+	merchants    MerchantRepository
+	operators    OperatorRepository
+	statements   StatementRepository
+	transactions TransactionRepository
+}
+
+func newRepositories(db *sqlx.DB) *repositories {
+	return &repositories{
+		accounts:     NewAccountRepository(db),
+		apiKeys:      NewAPIKeyRepository(db),
+		auditLogs:    NewAuditLogRepository(db),
+		cards:        NewCardRepository(db),
+		cardControls: NewCardControlsRepository(db),
+		idempotency:  NewIdempotencyKeyRepository(db),
+		ledger:       NewLedgerRepository(db),
+		merchants:    NewMerchantRepository(db),
+		operators:    NewOperatorRepository(db),
+		statements:   NewStatementRepository(db),
+		transactions: NewTransactionRepository(db),
+	}
+}
+
+// merchantFixture is one merchant with a row in every merchant owned table.
+// Both merchants of a test use the same username, card digits and
+// idempotency keys, so only the merchant tells their rows apart.
+type merchantFixture struct {
+	ctx        context.Context
+	merchantId string
+	accountId  string
+	cardId     string
+	purchaseId string
+	holdId     string
+	apiKeyId   string
+	operatorId string
+}
+
+const (
+	fixtureUsername       = "charlie"
+	fixtureCaller         = "caller"
+	fixtureIdempotencyKey = "order-purchase"
+	fixtureBalanceCents   = 4000
+)
+
+func seedMerchant(t *testing.T, r *repositories, name string) *merchantFixture {
+	t.Helper()
+
+	merchant := &models.Merchant{Name: name}
+	if err := r.merchants.CreateMerchant(tenant.WithAllMerchants(context.Background()), merchant); err != nil {
+		t.Fatalf("CreateMerchant failed: %v", err)
+	}
+	f := &merchantFixture{ctx: tenant.WithMerchant(context.Background(), merchant.ID), merchantId: merchant.ID}
+	merchantId := sql.NullString{String: merchant.ID, Valid: true}
+
+	account := &models.Account{MerchantId: merchant.ID, Username: fixtureUsername}
+	if err := r.accounts.CreateAccount(f.ctx, account); err != nil {
+		t.Fatalf("CreateAccount failed: %v", err)
+	}
+	f.accountId = account.ID
+
+	// The card expired years ago so ExpireCards has something to expire.
+	card := &models.Card{
+		AccountId:      account.ID,
+		CardToken:      "tok_" + randomHex(t, 8),
+		LastFourDigits: "4242",
+		Brand:          models.CardBrandVisa,
+		ExpiryMonth:    12,
+		ExpiryYear:     2020,
+		Kind:           models.CardKindStandard,
+	}
+	if err := r.cards.CreateCard(f.ctx, card); err != nil {
+		t.Fatalf("CreateCard failed: %v", err)
+	}
+	f.cardId = card.ID
+
+	controls := &models.CardControls{
+		CardId:                    card.ID,
+		DailyLimitCents:           sql.NullInt64{Int64: 10000, Valid: true},
+		AllowedTransactionTypes:   []string{},
+		BlockedMerchantCategories: []string{},
+	}
+	if err := r.cardControls.SaveCardControls(f.ctx, controls); err != nil {
+		t.Fatalf("SaveCardControls failed: %v", err)
+	}
+
+	cardId := sql.NullString{String: card.ID, Valid: true}
+	deposit := &models.Transaction{AccountId: account.ID, AmountCents: 5000, Type: models.TransactionTypeDeposit, IdempotencyKey: "order-deposit"}
+	createTransaction(t, r, f.ctx, deposit, models.TransactionStatusApproved, true)
+	purchase := &models.Transaction{AccountId: account.ID, CardId: cardId, AmountCents: 1000, Type: models.TransactionTypePurchase, IdempotencyKey: fixtureIdempotencyKey}
+	createTransaction(t, r, f.ctx, purchase, models.TransactionStatusApproved, true)
+	f.purchaseId = purchase.ID
+
+	refund := &models.Transaction{
+		AccountId:           account.ID,
+		RefundTransactionId: sql.NullString{String: purchase.ID, Valid: true},
+		AmountCents:         300,
+		Type:                models.TransactionTypeRefund,
+		IdempotencyKey:      "order-refund",
+	}
+	createTransaction(t, r, f.ctx, refund, models.TransactionStatusPending, false)
+
+	hold := &models.Transaction{
+		AccountId:             account.ID,
+		CardId:                cardId,
+		AmountCents:           700,
+		AuthorizedAmountCents: sql.NullInt64{Int64: 700, Valid: true},
+		HoldExpiresAt:         sql.NullString{String: time.Now().Add(-time.Hour).UTC().Format(time.RFC3339), Valid: true},
+		Type:                  models.TransactionTypePurchase,
+		IdempotencyKey:        "order-hold",
+	}
+	createTransaction(t, r, f.ctx, hold, models.TransactionStatusAuthorized, false)
+	f.holdId = hold.ID
+
+	if _, err := r.idempotency.Acquire(f.ctx, fixtureCaller, fixtureIdempotencyKey, "hash-"+name, time.Hour); err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+
+	operator := &models.Operator{MerchantId: merchantId, Username: "ops-" + randomHex(t, 4), Name: name + " support", Role: models.OperatorRoleSupport}
+	if err := r.operators.CreateOperator(f.ctx, operator); err != nil {
+		t.Fatalf("CreateOperator failed: %v", err)
+	}
+	f.operatorId = operator.ID
+
+	key := &models.APIKey{
+		OwnerType:  models.APIKeyOwnerAccount,
+		MerchantId: merchantId,
+		AccountId:  sql.NullString{String: account.ID, Valid: true},
+		Name:       name + " key",
+		Prefix:     randomHex(t, 6),
+		KeyHash:    randomHex(t, 32),
+		Scopes:     []string{models.ScopeAccountsRead},
+	}
+	if err := r.apiKeys.CreateAPIKey(f.ctx, key); err != nil {
+		t.Fatalf("CreateAPIKey failed: %v", err)
+	}
+	f.apiKeyId = key.ID
+
+	entry := &models.AuditLog{
+		MerchantId: merchantId,
+		OperatorId: sql.NullString{String: operator.ID, Valid: true},
+		Method:     "GET",
+		Route:      "/accounts/{id}",
+		Path:       "/accounts/" + account.ID,
+		AccountId:  sql.NullString{String: account.ID, Valid: true},
+		StatusCode: 200,
+	}
+	if err := r.auditLogs.CreateAuditLog(f.ctx, entry); err != nil {
+		t.Fatalf("CreateAuditLog failed: %v", err)
+	}
+
+	return f
+}
+
+// createTransaction stores tx with status, journaling it first when journal
+// is set, the way the transaction service does.
+func createTransaction(t *testing.T, r *repositories, ctx context.Context, tx *models.Transaction, status string, journal bool) {
+	t.Helper()
+	inTx(t, r, func(dbTx *sqlx.Tx) {
+		if err := r.transactions.CreateTransaction(ctx, dbTx, tx); err != nil {
+			t.Fatalf("CreateTransaction failed: %v", err)
+		}
+		if journal {
+			customer := &models.Posting{AccountId: sql.NullString{String: tx.AccountId, Valid: true}, Direction: models.PostingDirectionCredit, AmountCents: tx.AmountCents}
+			settlement := &models.Posting{SystemAccount: sql.NullString{String: models.SystemAccountSettlement, Valid: true}, Direction: models.PostingDirectionDebit, AmountCents: tx.AmountCents}
+			if tx.Type == models.TransactionTypePurchase {
+				customer.Direction, settlement.Direction = settlement.Direction, customer.Direction
+			}
+			entry := &models.JournalEntry{TransactionId: tx.ID, Kind: tx.Type}
+			if err := r.ledger.CreateJournalEntry(ctx, dbTx, entry, []*models.Posting{customer, settlement}); err != nil {
+				t.Fatalf("CreateJournalEntry failed: %v", err)
+			}
+		}
+		if status != models.TransactionStatusPending {
+			if err := r.transactions.UpdateStatus(ctx, dbTx, tx.ID, status); err != nil {
+				t.Fatalf("UpdateStatus failed: %v", err)
+			}
+		}
+	})
+}
+
+// inTx runs fn in a database transaction and commits it.
+func inTx(t *testing.T, r *repositories, fn func(dbTx *sqlx.Tx)) {
+	t.Helper()
+	dbTx, err := r.transactions.BeginTx(context.Background())
+	if err != nil {
+		t.Fatalf("BeginTx failed: %v", err)
+	}
+	defer dbTx.Rollback()
+	fn(dbTx)
+	if err := dbTx.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+}
+
+func TestMerchantIsolation(t *testing.T) {
+	db := testDB(t)
+	r := newRepositories(db)
+	a := seedMerchant(t, r, "Merchant A")
+	b := seedMerchant(t, r, "Merchant B")
+	platform := tenant.WithAllMerchants(context.Background())
+
+	t.Run("contexts without a scope are refused", func(t *testing.T) {
+		calls := map[string]func(ctx context.Context) error{
+			"CreateAccount": func(ctx context.Context) error {
+				return r.accounts.CreateAccount(ctx, &models.Account{MerchantId: a.merchantId, Username: "mallory"})
+			},
+			"GetAllAccounts": func(ctx context.Context) error {
+				_, err := r.accounts.GetAllAccounts(ctx, 1, 100)
+				return err
+			},
+			"GetAccountById": func(ctx context.Context) error {
+				_, err := r.accounts.GetAccountById(ctx, a.accountId)
+				return err
+			},
+			"GetAccountByUsername": func(ctx context.Context) error {
+				_, err := r.accounts.GetAccountByUsername(ctx, fixtureUsername)
+				return err
+			},
+			"GetCardById": func(ctx context.Context) error {
+				_, err := r.cards.GetCardById(ctx, a.cardId)
+				return err
+			},
+			"GetAllCardsByAccountId": func(ctx context.Context) error {
+				_, err := r.cards.GetAllCardsByAccountId(ctx, a.accountId)
+				return err
+			},
+			"ExpireCards": func(ctx context.Context) error {
+				_, err := r.cards.ExpireCards(ctx)
+				return err
+			},
+			"GetCardControls": func(ctx context.Context) error {
+				_, err := r.cardControls.GetCardControls(ctx, a.cardId)
+				return err
+			},
+			"GetTransactionByID": func(ctx context.Context) error {
+				_, err := r.transactions.GetTransactionByID(ctx, a.purchaseId)
+				return err
+			},
+			"GetTransactionByIdempotencyKey": func(ctx context.Context) error {
+				_, err := r.transactions.GetTransactionByIdempotencyKey(ctx, fixtureIdempotencyKey)
+				return err
+			},
+			"ListTransactions": func(ctx context.Context) error {
+				_, err := r.transactions.ListTransactions(ctx, models.TransactionFilter{Limit: 100})
+				return err
+			},
+			"GetBalance": func(ctx context.Context) error {
+				_, err := r.transactions.GetBalance(ctx, a.accountId)
+				return err
+			},
+			"RefreshBalanceSnapshots": func(ctx context.Context) error {
+				_, err := r.transactions.RefreshBalanceSnapshots(ctx, time.Now())
+				return err
+			},
+			"GetPostingsByAccountId": func(ctx context.Context) error {
+				_, err := r.ledger.GetPostingsByAccountId(ctx, a.accountId, 1, 100)
+				return err
+			},
+			"GetByKey": func(ctx context.Context) error {
+				_, err := r.idempotency.GetByKey(ctx, fixtureCaller, fixtureIdempotencyKey)
+				return err
+			},
+			"StreamEntries": func(ctx context.Context) error {
+				var err error
+				inTx(t, r, func(dbTx *sqlx.Tx) {
+					err = r.statements.StreamEntries(ctx, dbTx, a.accountId, time.Now().Add(-time.Hour), time.Now().Add(time.Hour), func(*models.StatementEntry) error { return nil })
+				})
+				return err
+			},
+			"ListAPIKeys": func(ctx context.Context) error {
+				_, err := r.apiKeys.ListAPIKeys(ctx, "")
+				return err
+			},
+			"ListAuditLogs": func(ctx context.Context) error {
+				_, err := r.auditLogs.ListAuditLogs(ctx, models.AuditLogFilter{Limit: 100})
+				return err
+			},
+			"ListOperators": func(ctx context.Context) error {
+				_, err := r.operators.ListOperators(ctx)
+				return err
+			},
+			"ListMerchants": func(ctx context.Context) error {
+				_, err := r.merchants.ListMerchants(ctx)
+				return err
+			},
+		}
+
+		unscoped := map[string]context.Context{
+			"background":     context.Background(),
+			"empty merchant": tenant.WithMerchant(context.Background(), ""),
+		}
+		for name, call := range calls {
+			for ctxName, ctx := range unscoped {
+				if err := call(ctx); !errors.Is(err, tenant.ErrMissingScope) {
+					t.Errorf("%s with a %s context error = %v, want %v", name, ctxName, err, tenant.ErrMissingScope)
+				}
+			}
+		}
+
+		// Usernames are only unique within a merchant.
+		if _, err := r.accounts.GetAccountByUsername(platform, fixtureUsername); !errors.Is(err, tenant.ErrMissingScope) {
+			t.Errorf("GetAccountByUsername with the platform scope error = %v, want %v", err, tenant.ErrMissingScope)
+		}
+	})
+
+	t.Run("accounts", func(t *testing.T) {
+		if account, err := r.accounts.GetAccountById(a.ctx, b.accountId); err != nil || account != nil {
+			t.Errorf("GetAccountById of another merchant = %v, %v, want nil", account, err)
+		}
+		if account, err := r.accounts.GetAccountById(b.ctx, b.accountId); err != nil || account == nil {
+			t.Fatalf("GetAccountById of the own merchant = %v, %v", account, err)
+		}
+
+		accounts, err := r.accounts.GetAllAccounts(a.ctx, 1, 100)
+		if err != nil {
+			t.Fatalf("GetAllAccounts failed: %v", err)
+		}
+		if len(accounts) != 1 || accounts[0].ID != a.accountId {
+			t.Errorf("GetAllAccounts returned %d accounts, want only %s", len(accounts), a.accountId)
+		}
+
+		account, err := r.accounts.GetAccountByUsername(a.ctx, fixtureUsername)
+		if err != nil || account == nil || account.ID != a.accountId {
+			t.Errorf("GetAccountByUsername = %v, %v, want %s", account, err, a.accountId)
+		}
+
+		username := "mallory"
+		if account, err := r.accounts.UpdateAccount(a.ctx, b.accountId, &username, nil); err != nil || account != nil {
+			t.Errorf("UpdateAccount of another merchant = %v, %v, want nil", account, err)
+		}
+		if account, err := r.accounts.UpdateAccountStatus(a.ctx, b.accountId, models.AccountStatusActive, models.AccountStatusFrozen, nil); err != nil || account != nil {
+			t.Errorf("UpdateAccountStatus of another merchant = %v, %v, want nil", account, err)
+		}
+		if account, err := r.accounts.SoftDeleteAccount(a.ctx, b.accountId, models.AccountStatusActive, nil); err != nil || account != nil {
+			t.Errorf("SoftDeleteAccount of another merchant = %v, %v, want nil", account, err)
+		}
+		if err := r.accounts.SetPasswordHash(a.ctx, b.accountId, "hash"); err != nil {
+			t.Errorf("SetPasswordHash failed: %v", err)
+		}
+
+		account, err = r.accounts.GetAccountById(b.ctx, b.accountId)
+		if err != nil || account == nil {
+			t.Fatalf("GetAccountById = %v, %v", account, err)
+		}
+		if account.Username != fixtureUsername || account.Status != models.AccountStatusActive || account.DeletedAt != nil || account.PasswordHash.Valid {
+			t.Errorf("account of merchant B was changed from merchant A: %+v", account)
+		}
+
+		err = r.accounts.CreateAccount(a.ctx, &models.Account{MerchantId: b.merchantId, Username: "mallory"})
+		if !errors.Is(err, ErrOutOfScope) {
+			t.Errorf("CreateAccount in another merchant error = %v, want %v", err, ErrOutOfScope)
+		}
+	})
+
+	t.Run("cards", func(t *testing.T) {
+		if card, err := r.cards.GetCardById(a.ctx, b.cardId); err != nil || card != nil {
+			t.Errorf("GetCardById of another merchant = %v, %v, want nil", card, err)
+		}
+		if card, err := r.cards.GetCardById(b.ctx, b.cardId); err != nil || card == nil {
+			t.Fatalf("GetCardById of the own merchant = %v, %v", card, err)
+		}
+		inTx(t, r, func(dbTx *sqlx.Tx) {
+			if card, err := r.cards.GetCardForUpdate(a.ctx, dbTx, b.cardId); err != nil || card != nil {
+				t.Errorf("GetCardForUpdate of another merchant = %v, %v, want nil", card, err)
+			}
+		})
+		if cards, err := r.cards.GetAllCardsByAccountId(a.ctx, b.accountId); err != nil || len(cards) != 0 {
+			t.Errorf("GetAllCardsByAccountId of another merchant returned %d cards, %v", len(cards), err)
+		}
+
+		card, err := r.cards.GetCardById(b.ctx, b.cardId)
+		if err != nil {
+			t.Fatalf("GetCardById failed: %v", err)
+		}
+		if found, err := r.cards.GetCardByTokenAndAccountId(a.ctx, card.CardToken, b.accountId); err != nil || found != nil {
+			t.Errorf("GetCardByTokenAndAccountId of another merchant = %v, %v, want nil", found, err)
+		}
+		if updated, err := r.cards.UpdateCardStatus(a.ctx, b.cardId, models.CardStatusActive, models.CardStatusBlocked, nil); err != nil || updated != nil {
+			t.Errorf("UpdateCardStatus of another merchant = %v, %v, want nil", updated, err)
+		}
+		replacement := *card
+		replacement.CardToken = "tok_" + randomHex(t, 8)
+		if replaced, err := r.cards.ReplaceCard(a.ctx, b.cardId, models.CardStatusActive, nil, &replacement); err != nil || replaced {
+			t.Errorf("ReplaceCard of another merchant = %v, %v, want false", replaced, err)
+		}
+		if card, err := r.cards.GetCardById(b.ctx, b.cardId); err != nil || card.Status != models.CardStatusActive {
+			t.Errorf("card of merchant B was changed from merchant A: %+v, %v", card, err)
+		}
+
+		newCard := replacement
+		newCard.CardToken = "tok_" + randomHex(t, 8)
+		if err := r.cards.CreateCard(a.ctx, &newCard); !errors.Is(err, ErrOutOfScope) {
+			t.Errorf("CreateCard on another merchant's account error = %v, want %v", err, ErrOutOfScope)
+		}
+	})
+
+	t.Run("card controls", func(t *testing.T) {
+		if controls, err := r.cardControls.GetCardControls(a.ctx, b.cardId); err != nil || controls != nil {
+			t.Errorf("GetCardControls of another merchant = %v, %v, want nil", controls, err)
+		}
+		if controls, err := r.cardControls.GetCardControls(b.ctx, b.cardId); err != nil || controls == nil {
+			t.Fatalf("GetCardControls of the own merchant = %v, %v", controls, err)
+		}
+		inTx(t, r, func(dbTx *sqlx.Tx) {
+			if controls, err := r.cardControls.GetCardControlsForUpdate(a.ctx, dbTx, b.cardId); err != nil || controls != nil {
+				t.Errorf("GetCardControlsForUpdate of another merchant = %v, %v, want nil", controls, err)
+			}
+		})
+
+		controls := &models.CardControls{
+			CardId:                    b.cardId,
+			DailyLimitCents:           sql.NullInt64{Int64: 1, Valid: true},
+			AllowedTransactionTypes:   []string{},
+			BlockedMerchantCategories: []string{},
+		}
+		if err := r.cardControls.SaveCardControls(a.ctx, controls); !errors.Is(err, ErrOutOfScope) {
+			t.Errorf("SaveCardControls of another merchant error = %v, want %v", err, ErrOutOfScope)
+		}
+		if controls, err := r.cardControls.GetCardControls(b.ctx, b.cardId); err != nil || controls.DailyLimitCents.Int64 != 10000 {
+			t.Errorf("controls of merchant B were changed from merchant A: %+v, %v", controls, err)
+		}
+	})
+
+	t.Run("transactions", func(t *testing.T) {
+		if tx, err := r.transactions.GetTransactionByID(a.ctx, b.purchaseId); err != nil || tx != nil {
+			t.Errorf("GetTransactionByID of another merchant = %v, %v, want nil", tx, err)
+		}
+		if tx, err := r.transactions.FindTransactionById(a.ctx, b.purchaseId); err != nil || tx != nil {
+			t.Errorf("FindTransactionById of another merchant = %v, %v, want nil", tx, err)
+		}
+		if tx, err := r.transactions.GetTransactionByID(b.ctx, b.purchaseId); err != nil || tx == nil {
+			t.Fatalf("GetTransactionByID of the own merchant = %v, %v", tx, err)
+		}
+		for _, f := range []*merchantFixture{a, b} {
+			if tx, err := r.transactions.GetTransactionByIdempotencyKey(f.ctx, fixtureIdempotencyKey); err != nil || tx == nil || tx.ID != f.purchaseId {
+				t.Errorf("GetTransactionByIdempotencyKey = %v, %v, want the purchase of the own merchant", tx, err)
+			}
+		}
+
+		if totals, err := r.transactions.GetRefundTotals(a.ctx, b.purchaseId); err != nil || totals.PendingRefundCents != 0 {
+			t.Errorf("GetRefundTotals of another merchant = %+v, %v, want none", totals, err)
+		}
+		if totals, err := r.transactions.GetRefundTotals(b.ctx, b.purchaseId); err != nil || totals.PendingRefundCents != 300 {
+			t.Errorf("GetRefundTotals of the own merchant = %+v, %v, want 300 pending", totals, err)
+		}
+
+		dayStart := time.Now().Add(-24 * time.Hour)
+		inTx(t, r, func(dbTx *sqlx.Tx) {
+			if tx, err := r.transactions.GetTransactionByIDForUpdate(a.ctx, dbTx, b.purchaseId); err != nil || tx != nil {
+				t.Errorf("GetTransactionByIDForUpdate of another merchant = %v, %v, want nil", tx, err)
+			}
+			if spend, err := r.transactions.GetCardSpend(a.ctx, dbTx, b.cardId, dayStart, dayStart); err != nil || spend.DailyCents != 0 {
+				t.Errorf("GetCardSpend of another merchant = %+v, %v, want none", spend, err)
+			}
+			if spend, err := r.transactions.GetCardSpend(b.ctx, dbTx, b.cardId, dayStart, dayStart); err != nil || spend.DailyCents != 1700 {
+				t.Errorf("GetCardSpend of the own merchant = %+v, %v, want 1700", spend, err)
+			}
+
+			if err := r.transactions.UpdateStatus(a.ctx, dbTx, b.purchaseId, models.TransactionStatusRejected); err != nil {
+				t.Errorf("UpdateStatus failed: %v", err)
+			}
+			if err := r.transactions.CaptureAuthorization(a.ctx, dbTx, b.holdId, 700); err != nil {
+				t.Errorf("CaptureAuthorization failed: %v", err)
+			}
+			if err := r.transactions.SetCounterpartTransactionId(a.ctx, dbTx, b.purchaseId, a.purchaseId); err != nil {
+				t.Errorf("SetCounterpartTransactionId failed: %v", err)
+			}
+		})
+		if tx, err := r.transactions.GetTransactionByID(b.ctx, b.purchaseId); err != nil || tx.Status != models.TransactionStatusApproved || tx.CounterpartTransactionId.Valid {
+			t.Errorf("purchase of merchant B was changed from merchant A: %+v, %v", tx, err)
+		}
+		if tx, err := r.transactions.GetTransactionByID(b.ctx, b.holdId); err != nil || tx.Status != models.TransactionStatusAuthorized {
+			t.Errorf("hold of merchant B was captured from merchant A: %+v, %v", tx, err)
+		}
+
+		inTx(t, r, func(dbTx *sqlx.Tx) {
+			tx := &models.Transaction{AccountId: b.accountId, AmountCents: 1, Type: models.TransactionTypeDeposit, IdempotencyKey: "order-mallory"}
+			if err := r.transactions.CreateTransaction(a.ctx, dbTx, tx); !errors.Is(err, ErrOutOfScope) {
+				t.Errorf("CreateTransaction on another merchant's account error = %v, want %v", err, ErrOutOfScope)
+			}
+		})
+	})
+
+	t.Run("search", func(t *testing.T) {
+		filters := map[string]models.TransactionFilter{
+			"no filter":       {},
+			"idempotency key": {IdempotencyKeyContains: "order"},
+			"card last four":  {CardLastFour: "4242"},
+			"other account":   {AccountId: b.accountId},
+			"other card":      {CardId: b.cardId},
+			"ascending":       {Sort: models.TransactionSortCreatedAtAsc},
+		}
+		for name, filter := range filters {
+			filter.Limit = 100
+			transactions, err := r.transactions.ListTransactions(a.ctx, filter)
+			if err != nil {
+				t.Fatalf("ListTransactions by %s failed: %v", name, err)
+			}
+			for _, tx := range transactions {
+				if tx.MerchantId != a.merchantId {
+					t.Errorf("ListTransactions by %s returned %s of merchant %s", name, tx.ID, tx.MerchantId)
+				}
+			}
+		}
+
+		filter := models.TransactionFilter{IdempotencyKeyContains: "order", Limit: 100}
+		if transactions, err := r.transactions.ListTransactions(a.ctx, filter); err != nil || len(transactions) != 4 {
+			t.Errorf("ListTransactions by idempotency key returned %d transactions, %v, want 4", len(transactions), err)
+		}
+		if transactions, err := r.transactions.ListTransactions(platform, filter); err != nil || len(transactions) != 8 {
+			t.Errorf("ListTransactions with the platform scope returned %d transactions, %v, want 8", len(transactions), err)
+		}
+	})
+
+	t.Run("ledger postings", func(t *testing.T) {
+		if postings, err := r.ledger.GetPostingsByAccountId(a.ctx, b.accountId, 1, 100); err != nil || len(postings) != 0 {
+			t.Errorf("GetPostingsByAccountId of another merchant returned %d postings, %v", len(postings), err)
+		}
+		if postings, err := r.ledger.GetPostingsByAccountId(b.ctx, b.accountId, 1, 100); err != nil || len(postings) != 2 {
+			t.Errorf("GetPostingsByAccountId of the own merchant returned %d postings, %v, want 2", len(postings), err)
+		}
+	})
+
+	t.Run("idempotency keys", func(t *testing.T) {
+		record, err := r.idempotency.GetByKey(a.ctx, fixtureCaller, fixtureIdempotencyKey)
+		if err != nil || record == nil || record.RequestHash != "hash-Merchant A" {
+			t.Fatalf("GetByKey = %+v, %v, want the key of merchant A", record, err)
+		}
+
+		if err := r.idempotency.SaveResponse(a.ctx, fixtureCaller, fixtureIdempotencyKey, 201, []byte(`{}`)); err != nil {
+			t.Fatalf("SaveResponse failed: %v", err)
+		}
+		if err := r.idempotency.Delete(a.ctx, fixtureCaller, fixtureIdempotencyKey); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+		record, err = r.idempotency.GetByKey(b.ctx, fixtureCaller, fixtureIdempotencyKey)
+		if err != nil || record == nil || record.RequestHash != "hash-Merchant B" || record.ResponseStatus.Valid {
+			t.Errorf("key of merchant B was changed from merchant A: %+v, %v", record, err)
+		}
+	})
+
+	t.Run("balances and statements", func(t *testing.T) {
+		now := time.Now()
+		if balance, err := r.transactions.GetBalance(a.ctx, b.accountId); err != nil || balance != 0 {
+			t.Errorf("GetBalance of another merchant = %d, %v, want 0", balance, err)
+		}
+		if balance, err := r.transactions.GetBalance(b.ctx, b.accountId); err != nil || balance != fixtureBalanceCents {
+			t.Errorf("GetBalance of the own merchant = %d, %v, want %d", balance, err, fixtureBalanceCents)
+		}
+		if balance, err := r.transactions.GetBalanceAt(a.ctx, b.accountId, now.Add(time.Minute)); err != nil || balance != 0 {
+			t.Errorf("GetBalanceAt of another merchant = %d, %v, want 0", balance, err)
+		}
+
+		movements, err := r.transactions.GetBalanceMovements(a.ctx, b.accountId, now.Add(-time.Hour), now.Add(time.Hour), models.BalanceIntervalDay)
+		if err != nil {
+			t.Fatalf("GetBalanceMovements failed: %v", err)
+		}
+		for _, movement := range movements {
+			if movement.NetChangeCents != 0 {
+				t.Errorf("GetBalanceMovements of another merchant moved %d cents", movement.NetChangeCents)
+			}
+		}
+
+		dbTx, err := r.statements.BeginSnapshot(a.ctx)
+		if err != nil {
+			t.Fatalf("BeginSnapshot failed: %v", err)
+		}
+		defer dbTx.Rollback()
+		if balance, err := r.statements.GetBalanceAt(a.ctx, dbTx, b.accountId, now.Add(time.Minute)); err != nil || balance != 0 {
+			t.Errorf("statement GetBalanceAt of another merchant = %d, %v, want 0", balance, err)
+		}
+		count := func(ctx context.Context) int {
+			entries := 0
+			err := r.statements.StreamEntries(ctx, dbTx, b.accountId, now.Add(-time.Hour), now.Add(time.Hour), func(*models.StatementEntry) error {
+				entries++
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("StreamEntries failed: %v", err)
+			}
+			return entries
+		}
+		if entries := count(a.ctx); entries != 0 {
+			t.Errorf("StreamEntries of another merchant returned %d entries, want 0", entries)
+		}
+		if entries := count(b.ctx); entries != 2 {
+			t.Errorf("StreamEntries of the own merchant returned %d entries, want 2", entries)
+		}
+	})
+
+	t.Run("api keys", func(t *testing.T) {
+		if key, err := r.apiKeys.GetAPIKeyById(a.ctx, b.apiKeyId); err != nil || key != nil {
+			t.Errorf("GetAPIKeyById of another merchant = %v, %v, want nil", key, err)
+		}
+		if keys, err := r.apiKeys.ListAPIKeys(a.ctx, ""); err != nil || len(keys) != 1 || keys[0].ID != a.apiKeyId {
+			t.Errorf("ListAPIKeys returned %d keys, %v, want only %s", len(keys), err, a.apiKeyId)
+		}
+		if key, err := r.apiKeys.RotateAPIKey(a.ctx, b.apiKeyId, randomHex(t, 6), randomHex(t, 32)); err != nil || key != nil {
+			t.Errorf("RotateAPIKey of another merchant = %v, %v, want nil", key, err)
+		}
+		if key, err := r.apiKeys.RevokeAPIKey(a.ctx, b.apiKeyId); err != nil || key != nil {
+			t.Errorf("RevokeAPIKey of another merchant = %v, %v, want nil", key, err)
+		}
+		if key, err := r.apiKeys.GetAPIKeyById(b.ctx, b.apiKeyId); err != nil || key == nil || key.RotatedAt != nil || key.RevokedAt != nil {
+			t.Errorf("key of merchant B was changed from merchant A: %+v, %v", key, err)
+		}
+
+		key := &models.APIKey{OwnerType: models.APIKeyOwnerOperator, Name: "platform", Prefix: randomHex(t, 6), KeyHash: randomHex(t, 32)}
+		if err := r.apiKeys.CreateAPIKey(a.ctx, key); !errors.Is(err, ErrOutOfScope) {
+			t.Errorf("CreateAPIKey without a merchant error = %v, want %v", err, ErrOutOfScope)
+		}
+	})
+
+	t.Run("operators, audit logs and merchants", func(t *testing.T) {
+		if operator, err := r.operators.GetOperatorById(a.ctx, b.operatorId); err != nil || operator != nil {
+			t.Errorf("GetOperatorById of another merchant = %v, %v, want nil", operator, err)
+		}
+		if operators, err := r.operators.ListOperators(a.ctx); err != nil || len(operators) != 1 || operators[0].ID != a.operatorId {
+			t.Errorf("ListOperators returned %d operators, %v, want only %s", len(operators), err, a.operatorId)
+		}
+		active := false
+		if operator, err := r.operators.UpdateOperator(a.ctx, b.operatorId, nil, &active); err != nil || operator != nil {
+			t.Errorf("UpdateOperator of another merchant = %v, %v, want nil", operator, err)
+		}
+		if operator, err := r.operators.GetOperatorById(b.ctx, b.operatorId); err != nil || operator == nil || !operator.Active {
+			t.Errorf("operator of merchant B was changed from merchant A: %+v, %v", operator, err)
+		}
+
+		entries, err := r.auditLogs.ListAuditLogs(a.ctx, models.AuditLogFilter{Limit: 100})
+		if err != nil {
+			t.Fatalf("ListAuditLogs failed: %v", err)
+		}
+		if len(entries) != 1 || entries[0].MerchantId.String != a.merchantId {
+			t.Errorf("ListAuditLogs returned %d entries, want only the one of merchant A", len(entries))
+		}
+
+		if merchant, err := r.merchants.GetMerchantById(a.ctx, b.merchantId); err != nil || merchant != nil {
+			t.Errorf("GetMerchantById of another merchant = %v, %v, want nil", merchant, err)
+		}
+		if merchants, err := r.merchants.ListMerchants(a.ctx); err != nil || len(merchants) != 1 || merchants[0].ID != a.merchantId {
+			t.Errorf("ListMerchants returned %d merchants, %v, want only %s", len(merchants), err, a.merchantId)
+		}
+		if err := r.merchants.CreateMerchant(a.ctx, &models.Merchant{Name: "Merchant C"}); !errors.Is(err, ErrOutOfScope) {
+			t.Errorf("CreateMerchant from a merchant error = %v, want %v", err, ErrOutOfScope)
+		}
+	})
+
+	// Jobs run per merchant must leave the other merchants alone. These change
+	// the fixtures, so they run last.
+	t.Run("jobs", func(t *testing.T) {
+		if expired, err := r.cards.ExpireCards(a.ctx); err != nil || expired != 1 {
+			t.Errorf("ExpireCards = %d, %v, want 1", expired, err)
+		}
+		if card, err := r.cards.GetCardById(b.ctx, b.cardId); err != nil || card.Status != models.CardStatusActive {
+			t.Errorf("card of merchant B was expired from merchant A: %+v, %v", card, err)
+		}
+
+		if refreshed, err := r.transactions.RefreshBalanceSnapshots(a.ctx, time.Now()); err != nil || refreshed != 1 {
+			t.Errorf("RefreshBalanceSnapshots = %d, %v, want 1", refreshed, err)
+		}
+		var snapshots int
+		if err := db.Get(&snapshots, `SELECT COUNT(*) FROM balance_snapshots WHERE account_id = $1`, b.accountId); err != nil || snapshots != 0 {
+			t.Errorf("merchant B has %d snapshots, %v, want 0", snapshots, err)
+		}
+
+		inTx(t, r, func(dbTx *sqlx.Tx) {
+			expired, err := r.transactions.ExpireAuthorizationHolds(a.ctx, dbTx)
+			if err != nil {
+				t.Fatalf("ExpireAuthorizationHolds failed: %v", err)
+			}
+			if len(expired) != 1 || expired[0].ID != a.holdId {
+				t.Errorf("ExpireAuthorizationHolds expired %d holds, want only %s", len(expired), a.holdId)
+			}
+		})
+		if tx, err := r.transactions.GetTransactionByID(b.ctx, b.holdId); err != nil || tx.Status != models.TransactionStatusAuthorized {
+			t.Errorf("hold of merchant B was expired from merchant A: %+v, %v", tx, err)
+		}
+	})
+}
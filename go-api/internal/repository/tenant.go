@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"payment-gateway/go-api/internal/tenant"
+)
+
+// ErrOutOfScope is returned when a write names a merchant the caller cannot
+// reach.
+var ErrOutOfScope = errors.New("merchant out of scope")
+
+// merchantScope returns the merchant the queries of ctx are limited to, or ""
+// when ctx may reach every merchant. Every query on merchant owned rows passes
+// it to merchantFilter; contexts without a scope fail instead of reading
+// across merchants.
+func merchantScope(ctx context.Context) (string, error) {
+	scope, ok := tenant.FromContext(ctx)
+	if !ok {
+		return "", tenant.ErrMissingScope
+	}
+	return scope.MerchantId, nil
+}
+
+// merchantFilter is the condition limiting column to the merchant passed as
+// query parameter $n. An empty merchant, the platform scope, matches every row.
+func merchantFilter(column string, n int) string {
+	return fmt.Sprintf("($%d = '' OR %s = NULLIF($%d, '')::uuid)", n, column, n)
+}
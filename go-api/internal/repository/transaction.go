@@ -5,21 +5,59 @@ import (
 	"database/sql"
 	"fmt"
 	"payment-gateway/go-api/internal/models"
+	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type TransactionRepository interface {
-	CreateTransaction(ctx context.Context, tx *models.Transaction) error
-	BeginTx(ctx context.Context) (*sql.Tx, error)
+	CreateTransaction(ctx context.Context, dbTx *sqlx.Tx, tx *models.Transaction) error
+	BeginTx(ctx context.Context) (*sqlx.Tx, error)
+	SetCounterpartTransactionId(ctx context.Context, dbTx *sqlx.Tx, txID, counterpartTxID string) error
 	GetTransactionByID(ctx context.Context, txID string) (*models.Transaction, error)
-	FindMostRecentTransaction(ctx context.Context, accountID, txType string, amountCents int64) (*models.Transaction, error)
-	GetAllTransactionsByAccountIdTest(ctx context.Context, accountId string) (error, []*models.Transaction)
-	GetAllTransactionsByCardId(ctx context.Context, cardId string) ([]*models.Transaction, error)
+	GetTransactionByIdempotencyKey(ctx context.Context, idempotencyKey string) (*models.Transaction, error)
+	GetTransactionByIDForUpdate(ctx context.Context, dbTx *sqlx.Tx, txID string) (*models.Transaction, error)
+	GetRefundTotals(ctx context.Context, originalTxID string) (*models.RefundTotals, error)
+	GetCardSpend(ctx context.Context, dbTx *sqlx.Tx, cardId string, dayStart, monthStart time.Time) (*models.CardSpend, error)
+	UpdateStatus(ctx context.Context, dbTx *sqlx.Tx, txID, status string) error
+	CaptureAuthorization(ctx context.Context, dbTx *sqlx.Tx, txID string, amountCents int64) error
+	ExpireAuthorizationHolds(ctx context.Context, dbTx *sqlx.Tx) ([]*models.Transaction, error)
+	ListTransactions(ctx context.Context, filter models.TransactionFilter) ([]*models.Transaction, error)
 	FindTransactionById(ctx context.Context, transactionId string) (*models.Transaction, error)
+	GetBalance(ctx context.Context, accountId string) (int64, error)
+	GetBalanceAt(ctx context.Context, accountId string, at time.Time) (int64, error)
+	GetBalanceMovements(ctx context.Context, accountId string, from, to time.Time, interval string) ([]*models.BalanceMovement, error)
+	RefreshBalanceSnapshots(ctx context.Context, day time.Time) (int64, error)
 }
 
+// signedAmountSQL is the effect of transaction t (with its refunded original
+// joined as t_orig) on the available balance of its account. It matches the
+// balance computed by the processor: open authorization holds already count
+// against the balance, expired ones no longer do.
+const signedAmountSQL = `
+	CASE
+		WHEN t.status = 'AUTHORIZED' THEN
+			CASE
+				WHEN t.hold_expires_at > NOW() THEN -t.amount_cents
+				ELSE 0
+			END
+		WHEN t.type = 'DEPOSIT' THEN t.amount_cents
+		WHEN t.type = 'PURCHASE' THEN -t.amount_cents
+		WHEN t.type = 'CHARGE' THEN -t.amount_cents
+		WHEN t.type = 'TRANSFER_IN' THEN t.amount_cents
+		WHEN t.type = 'TRANSFER_OUT' THEN -t.amount_cents
+		WHEN t.type = 'REFUND' THEN
+			CASE t_orig.type
+				WHEN 'DEPOSIT' THEN -t.amount_cents
+				WHEN 'PURCHASE' THEN t.amount_cents
+				WHEN 'CHARGE' THEN t.amount_cents
+				ELSE 0
+			END
+		ELSE 0
+	END`
+
 type transactionRepositoryImpl struct {
 	db *sqlx.DB
 }
@@ -28,37 +66,87 @@ func NewTransactionRepository(db *sqlx.DB) TransactionRepository {
 	return &transactionRepositoryImpl{db: db}
 }
 
-func (r *transactionRepositoryImpl) CreateTransaction(ctx context.Context, tx *models.Transaction) error {
+// CreateTransaction records tx on tx.AccountId under the account's merchant,
+// which must be within the merchant scope of ctx. tx is stored PENDING unless
+// it was already declined and carries the REJECTED status.
+func (r *transactionRepositoryImpl) CreateTransaction(ctx context.Context, dbTx *sqlx.Tx, tx *models.Transaction) error {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	status := models.TransactionStatusPending
+	if tx.Status == models.TransactionStatusRejected {
+		status = tx.Status
+	}
+
 	query := `
-		INSERT INTO transactions (account_id, card_id, amount_cents, status, type, idempotency_key, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-		RETURNING id, status, created_at;
+		INSERT INTO transactions (account_id, merchant_id, card_id, refund_transaction_id, counterpart_transaction_id, amount_cents, authorized_amount_cents, hold_expires_at, reason_code, merchant_category_code, acceptor_id, decline_reason, status, type, idempotency_key, created_at)
+		SELECT a.id, a.merchant_id, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+		FROM accounts a
+		WHERE a.id = $1 AND ` + merchantFilter("a.merchant_id", 16) + `
+		RETURNING id, merchant_id, status, created_at;
 	`
 
-	err := r.db.QueryRowContext(
+	err = dbTx.QueryRowContext(
 		ctx,
 		query,
 		tx.AccountId,
 		tx.CardId,
+		tx.RefundTransactionId,
+		tx.CounterpartTransactionId,
 		tx.AmountCents,
-		"PENDING",
+		tx.AuthorizedAmountCents,
+		tx.HoldExpiresAt,
+		tx.ReasonCode,
+		tx.MerchantCategoryCode,
+		tx.AcceptorId,
+		tx.DeclineReason,
+		status,
 		tx.Type,
 		tx.IdempotencyKey,
 		time.Now().UTC(),
-	).Scan(&tx.ID, &tx.Status, &tx.CreatedAt)
+		merchantId,
+	).Scan(&tx.ID, &tx.MerchantId, &tx.Status, &tx.CreatedAt)
 
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("failed to create transaction: %w", ErrOutOfScope)
+		}
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to create transaction: %w", ErrDuplicateKey)
+		}
 		return fmt.Errorf("failed to create transaction: %w", err)
 	}
 
 	return nil
 }
 
+func (r *transactionRepositoryImpl) SetCounterpartTransactionId(ctx context.Context, dbTx *sqlx.Tx, txID, counterpartTxID string) error {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE transactions SET counterpart_transaction_id = $1 WHERE id = $2 AND ` + merchantFilter("merchant_id", 3)
+
+	if _, err := dbTx.ExecContext(ctx, query, counterpartTxID, txID, merchantId); err != nil {
+		return fmt.Errorf("failed to link counterpart transaction: %w", err)
+	}
+
+	return nil
+}
+
 func (r *transactionRepositoryImpl) GetTransactionByID(ctx context.Context, txID string) (*models.Transaction, error) {
-	query := `SELECT * FROM transactions WHERE id = $1`
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT * FROM transactions WHERE id = $1 AND ` + merchantFilter("merchant_id", 2)
 	var tx models.Transaction
 
-	err := r.db.GetContext(ctx, &tx, query, txID)
+	err = r.db.GetContext(ctx, &tx, query, txID, merchantId)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -69,74 +157,260 @@ func (r *transactionRepositoryImpl) GetTransactionByID(ctx context.Context, txID
 	return &tx, nil
 }
 
-func (r *transactionRepositoryImpl) BeginTx(ctx context.Context) (*sql.Tx, error) {
-	tx, err := r.db.DB.BeginTx(ctx, nil)
+// GetTransactionByIdempotencyKey returns the transaction created with
+// idempotencyKey, which is unique per merchant, or nil when there is none.
+func (r *transactionRepositoryImpl) GetTransactionByIdempotencyKey(ctx context.Context, idempotencyKey string) (*models.Transaction, error) {
+	merchantId, err := merchantScope(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("falha ao iniciar a transação: %w", err)
+		return nil, err
 	}
-	return tx, nil
-}
 
-func (r *transactionRepositoryImpl) FindMostRecentTransaction(ctx context.Context, accountID, txType string, amountCents int64) (*models.Transaction, error) {
-	query := `
-        SELECT *
-        FROM transactions
-        WHERE account_id = $1
-        AND type = $2
-        AND amount_cents = $3
-        ORDER BY created_at DESC
-        LIMIT 1
-    `
+	query := `SELECT * FROM transactions WHERE idempotency_key = $1 AND ` + merchantFilter("merchant_id", 2)
 	var tx models.Transaction
 
-	err := r.db.GetContext(ctx, &tx, query, accountID, txType, amountCents)
+	err = r.db.GetContext(ctx, &tx, query, idempotencyKey, merchantId)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
-		return nil, fmt.Errorf("failed to find most recent transaction: %w", err)
+		return nil, fmt.Errorf("failed to get transaction by idempotency key: %w", err)
 	}
 
 	return &tx, nil
 }
 
-func (r *transactionRepositoryImpl) GetAllTransactionsByAccountIdTest(ctx context.Context, accountId string) (error, []*models.Transaction) {
-	query := `SELECT * FROM transactions WHERE account_id = $1 ORDER BY created_at DESC`
-	var transactions []*models.Transaction
+// GetTransactionByIDForUpdate locks the row until dbTx ends, serializing
+// concurrent refunds against the same transaction.
+func (r *transactionRepositoryImpl) GetTransactionByIDForUpdate(ctx context.Context, dbTx *sqlx.Tx, txID string) (*models.Transaction, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT * FROM transactions WHERE id = $1 AND ` + merchantFilter("merchant_id", 2) + ` FOR UPDATE`
+	var tx models.Transaction
 
-	err := r.db.SelectContext(ctx, &transactions, query, accountId)
+	err = dbTx.GetContext(ctx, &tx, query, txID, merchantId)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
-		return fmt.Errorf("failed to get transactions by account id: %w", err), nil
+		return nil, fmt.Errorf("failed to lock transaction by id: %w", err)
 	}
 
-	return nil, transactions
+	return &tx, nil
 }
 
-func (r *transactionRepositoryImpl) GetAllTransactionsByCardId(ctx context.Context, cardId string) ([]*models.Transaction, error) {
+func (r *transactionRepositoryImpl) GetRefundTotals(ctx context.Context, originalTxID string) (*models.RefundTotals, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		SELECT * FROM transactions WHERE card_id = $1;
+		SELECT
+			COALESCE(SUM(amount_cents) FILTER (WHERE status = 'APPROVED'), 0) AS refunded_cents,
+			COALESCE(SUM(amount_cents) FILTER (WHERE status = 'PENDING'), 0) AS pending_refund_cents
+		FROM transactions
+		WHERE type = 'REFUND' AND refund_transaction_id = $1 AND ` + merchantFilter("merchant_id", 2) + `
 	`
-	var transactions []*models.Transaction
+	var totals models.RefundTotals
+
+	if err := r.db.GetContext(ctx, &totals, query, originalTxID, merchantId); err != nil {
+		return nil, fmt.Errorf("failed to get refund totals: %w", err)
+	}
+
+	return &totals, nil
+}
+
+// GetCardSpend sums what cardId spent on PURCHASE and TRANSFER since dayStart
+// and since monthStart. Pending transactions and open authorizations count, so
+// spend caps hold before the processor settles them.
+func (r *transactionRepositoryImpl) GetCardSpend(ctx context.Context, dbTx *sqlx.Tx, cardId string, dayStart, monthStart time.Time) (*models.CardSpend, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT
+			COALESCE(SUM(amount_cents) FILTER (WHERE created_at >= $2), 0) AS daily_cents,
+			COALESCE(SUM(amount_cents), 0) AS monthly_cents
+		FROM transactions
+		WHERE card_id = $1
+			AND created_at >= $3
+			AND type IN ('PURCHASE', 'TRANSFER_OUT')
+			AND status IN ('PENDING', 'AUTHORIZED', 'APPROVED')
+			AND ` + merchantFilter("merchant_id", 4) + `
+	`
+	var spend models.CardSpend
+
+	if err := dbTx.GetContext(ctx, &spend, query, cardId, dayStart, monthStart, merchantId); err != nil {
+		return nil, fmt.Errorf("failed to get card spend: %w", err)
+	}
+
+	return &spend, nil
+}
+
+func (r *transactionRepositoryImpl) UpdateStatus(ctx context.Context, dbTx *sqlx.Tx, txID, status string) error {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE transactions SET status = $1 WHERE id = $2 AND ` + merchantFilter("merchant_id", 3)
+
+	if _, err := dbTx.ExecContext(ctx, query, status, txID, merchantId); err != nil {
+		return fmt.Errorf("failed to update transaction status: %w", err)
+	}
+
+	return nil
+}
+
+// CaptureAuthorization settles an authorized purchase for amountCents, which
+// may be lower than the amount originally authorized.
+func (r *transactionRepositoryImpl) CaptureAuthorization(ctx context.Context, dbTx *sqlx.Tx, txID string, amountCents int64) error {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE transactions
+		SET status = 'APPROVED', amount_cents = $1
+		WHERE id = $2 AND status = 'AUTHORIZED' AND ` + merchantFilter("merchant_id", 3) + `
+	`
+
+	if _, err := dbTx.ExecContext(ctx, query, amountCents, txID, merchantId); err != nil {
+		return fmt.Errorf("failed to capture authorization: %w", err)
+	}
+
+	return nil
+}
+
+// ExpireAuthorizationHolds marks every overdue hold within the merchant scope
+// of ctx as EXPIRED and returns the expired authorizations.
+func (r *transactionRepositoryImpl) ExpireAuthorizationHolds(ctx context.Context, dbTx *sqlx.Tx) ([]*models.Transaction, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE transactions
+		SET status = 'EXPIRED'
+		WHERE status = 'AUTHORIZED' AND hold_expires_at <= NOW() AND ` + merchantFilter("merchant_id", 1) + `
+		RETURNING *
+	`
+	var expired []*models.Transaction
+
+	if err := dbTx.SelectContext(ctx, &expired, query, merchantId); err != nil {
+		return nil, fmt.Errorf("failed to expire authorization holds: %w", err)
+	}
 
-	err := r.db.SelectContext(ctx, &transactions, query, cardId)
+	return expired, nil
+}
+
+func (r *transactionRepositoryImpl) BeginTx(ctx context.Context) (*sqlx.Tx, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao iniciar a transação: %w", err)
+	}
+	return tx, nil
+}
 
+// escapeLike escapes the LIKE wildcards of a user supplied substring.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// ListTransactions returns up to filter.Limit transactions matching filter,
+// ordered by (created_at, id) so pages can be resumed from a cursor.
+func (r *transactionRepositoryImpl) ListTransactions(ctx context.Context, filter models.TransactionFilter) ([]*models.Transaction, error) {
+	merchantId, err := merchantScope(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get transactions: %w", err)
+		return nil, err
+	}
+
+	var conditions []string
+	var args []interface{}
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	arg(merchantId)
+	conditions = append(conditions, merchantFilter("merchant_id", len(args)))
+
+	if filter.AccountId != "" {
+		conditions = append(conditions, "account_id = "+arg(filter.AccountId))
+	}
+	if filter.CardId != "" {
+		conditions = append(conditions, "card_id = "+arg(filter.CardId))
+	}
+	if len(filter.Types) > 0 {
+		conditions = append(conditions, "type = ANY("+arg(pq.Array(filter.Types))+")")
+	}
+	if len(filter.Statuses) > 0 {
+		conditions = append(conditions, "status::text = ANY("+arg(pq.Array(filter.Statuses))+")")
+	}
+	if filter.MinAmountCents != nil {
+		conditions = append(conditions, "amount_cents >= "+arg(*filter.MinAmountCents))
+	}
+	if filter.MaxAmountCents != nil {
+		conditions = append(conditions, "amount_cents <= "+arg(*filter.MaxAmountCents))
+	}
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, "created_at >= "+arg(filter.CreatedFrom.UTC()))
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, "created_at < "+arg(filter.CreatedTo.UTC()))
+	}
+	if filter.IdempotencyKeyContains != "" {
+		conditions = append(conditions, "idempotency_key ILIKE "+arg("%"+escapeLike(filter.IdempotencyKeyContains)+"%"))
+	}
+	if filter.CardLastFour != "" {
+		conditions = append(conditions, "card_id IN (SELECT id FROM cards WHERE last_four_digits = "+arg(filter.CardLastFour)+")")
+	}
+
+	order := "DESC"
+	comparison := "<"
+	if filter.Sort == models.TransactionSortCreatedAtAsc {
+		order = "ASC"
+		comparison = ">"
+	}
+	if filter.Cursor != nil {
+		conditions = append(conditions, fmt.Sprintf(
+			"(created_at, id) %s (%s, %s)",
+			comparison,
+			arg(filter.Cursor.CreatedAt.UTC()),
+			arg(filter.Cursor.ID),
+		))
+	}
+
+	query := "SELECT * FROM transactions WHERE " + strings.Join(conditions, " AND ")
+	query += fmt.Sprintf(" ORDER BY created_at %s, id %s LIMIT %s", order, order, arg(filter.Limit))
+
+	var transactions []*models.Transaction
+	if err := r.db.SelectContext(ctx, &transactions, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list transactions: %w", err)
 	}
 
 	return transactions, nil
 }
 
 func (r *transactionRepositoryImpl) FindTransactionById(ctx context.Context, transactionId string) (*models.Transaction, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		SELECT * FROM transactions WHERE id = $1;
+		SELECT * FROM transactions WHERE id = $1 AND ` + merchantFilter("merchant_id", 2) + `;
 	`
 	var transaction models.Transaction
 
-	err := r.db.GetContext(ctx, &transaction, query, transactionId)
+	err = r.db.GetContext(ctx, &transaction, query, transactionId, merchantId)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -146,3 +420,155 @@ func (r *transactionRepositoryImpl) FindTransactionById(ctx context.Context, tra
 
 	return &transaction, nil
 }
+
+func (r *transactionRepositoryImpl) GetBalance(ctx context.Context, accountId string) (int64, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+		SELECT COALESCE(SUM(` + signedAmountSQL + `), 0)::BIGINT
+		FROM transactions t
+		LEFT JOIN transactions t_orig ON t.refund_transaction_id = t_orig.id
+		WHERE t.account_id = $1 AND t.status IN ('APPROVED', 'AUTHORIZED') AND ` + merchantFilter("t.merchant_id", 2) + `
+	`
+	var balance int64
+
+	if err := r.db.GetContext(ctx, &balance, query, accountId, merchantId); err != nil {
+		return 0, fmt.Errorf("failed to calculate balance: %w", err)
+	}
+
+	return balance, nil
+}
+
+// snapshotEndSQL is the instant a balance_snapshots row closes: midnight UTC
+// after snapshot_date. A snapshot holds the balance of every approved
+// transaction created before it; the database corrects it when one of them
+// changes status or amount later.
+const snapshotEndSQL = `((s.snapshot_date + 1)::timestamp AT TIME ZONE 'UTC')`
+
+// GetBalanceAt returns the settled balance of accountId at the given instant:
+// the latest snapshot closed by then plus the approved transactions created
+// between that snapshot and at.
+func (r *transactionRepositoryImpl) GetBalanceAt(ctx context.Context, accountId string, at time.Time) (int64, error) {
+	return balanceAt(ctx, r.db, accountId, at)
+}
+
+func balanceAt(ctx context.Context, q sqlx.QueryerContext, accountId string, at time.Time) (int64, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+		WITH snapshot AS (
+			SELECT s.balance_cents, ` + snapshotEndSQL + ` AS closed_at
+			FROM balance_snapshots s
+			JOIN accounts a ON a.id = s.account_id
+			WHERE s.account_id = $1 AND ` + snapshotEndSQL + ` <= $2 AND ` + merchantFilter("a.merchant_id", 3) + `
+			ORDER BY s.snapshot_date DESC
+			LIMIT 1
+		)
+		SELECT
+			COALESCE((SELECT balance_cents FROM snapshot), 0) +
+			COALESCE((
+				SELECT SUM(` + signedAmountSQL + `)
+				FROM transactions t
+				LEFT JOIN transactions t_orig ON t.refund_transaction_id = t_orig.id
+				WHERE t.account_id = $1
+				AND t.status = 'APPROVED'
+				AND t.created_at < $2
+				AND t.created_at >= COALESCE((SELECT closed_at FROM snapshot), '-infinity')
+				AND ` + merchantFilter("t.merchant_id", 3) + `
+			), 0)::BIGINT
+	`
+	var balance int64
+
+	if err := sqlx.GetContext(ctx, q, &balance, query, accountId, at.UTC(), merchantId); err != nil {
+		return 0, fmt.Errorf("failed to calculate balance at %s: %w", at.Format(time.RFC3339), err)
+	}
+
+	return balance, nil
+}
+
+// GetBalanceMovements returns one row per interval period between from and
+// to, including periods without transactions. Periods are truncated in UTC.
+func (r *transactionRepositoryImpl) GetBalanceMovements(ctx context.Context, accountId string, from, to time.Time, interval string) ([]*models.BalanceMovement, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT
+			p.period_start AT TIME ZONE 'UTC' AS period_start,
+			COALESCE(SUM(` + signedAmountSQL + `), 0)::BIGINT AS net_change_cents
+		FROM generate_series(
+			date_trunc($4, $2::timestamptz AT TIME ZONE 'UTC'),
+			$3::timestamptz AT TIME ZONE 'UTC' - INTERVAL '1 microsecond',
+			('1 ' || $4)::interval
+		) AS p(period_start)
+		LEFT JOIN transactions t
+			ON t.account_id = $1
+			AND t.status = 'APPROVED'
+			AND t.created_at >= $2
+			AND t.created_at < $3
+			AND date_trunc($4, t.created_at AT TIME ZONE 'UTC') = p.period_start
+			AND ` + merchantFilter("t.merchant_id", 5) + `
+		LEFT JOIN transactions t_orig ON t.refund_transaction_id = t_orig.id
+		GROUP BY p.period_start
+		ORDER BY p.period_start
+	`
+	var movements []*models.BalanceMovement
+
+	if err := r.db.SelectContext(ctx, &movements, query, accountId, from.UTC(), to.UTC(), interval, merchantId); err != nil {
+		return nil, fmt.Errorf("failed to get balance movements: %w", err)
+	}
+
+	return movements, nil
+}
+
+// RefreshBalanceSnapshots writes or rewrites the snapshot of every account in
+// the merchant scope of ctx for the UTC day of day, building on the previous
+// snapshot of each account.
+func (r *transactionRepositoryImpl) RefreshBalanceSnapshots(ctx context.Context, day time.Time) (int64, error) {
+	merchantId, err := merchantScope(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+		INSERT INTO balance_snapshots (account_id, snapshot_date, balance_cents)
+		SELECT a.id, $1::date, COALESCE(previous.balance_cents, 0) + COALESCE(delta.total, 0)
+		FROM accounts a
+		LEFT JOIN LATERAL (
+			SELECT s.balance_cents, ` + snapshotEndSQL + ` AS closed_at
+			FROM balance_snapshots s
+			WHERE s.account_id = a.id AND s.snapshot_date < $1::date
+			ORDER BY s.snapshot_date DESC
+			LIMIT 1
+		) previous ON TRUE
+		LEFT JOIN LATERAL (
+			SELECT SUM(` + signedAmountSQL + `)::BIGINT AS total
+			FROM transactions t
+			LEFT JOIN transactions t_orig ON t.refund_transaction_id = t_orig.id
+			WHERE t.account_id = a.id
+			AND t.status = 'APPROVED'
+			AND t.created_at < (($1::date + 1)::timestamp AT TIME ZONE 'UTC')
+			AND t.created_at >= COALESCE(previous.closed_at, '-infinity')
+		) delta ON TRUE
+		WHERE a.created_at < (($1::date + 1)::timestamp AT TIME ZONE 'UTC')
+		AND ` + merchantFilter("a.merchant_id", 2) + `
+		ON CONFLICT (account_id, snapshot_date) DO UPDATE SET
+			balance_cents = EXCLUDED.balance_cents,
+			created_at = CURRENT_TIMESTAMP
+	`
+
+	result, err := r.db.ExecContext(ctx, query, day.UTC().Format("2006-01-02"), merchantId)
+	if err != nil {
+		return 0, fmt.Errorf("failed to refresh balance snapshots: %w", err)
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"payment-gateway/go-api/internal/models"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// VaultRepository stores encrypted card secrets by token. The vault is not
+// merchant scoped: tokens are only ever reached through a card, which is.
+type VaultRepository interface {
+	CreateVaultEntry(ctx context.Context, entry *models.VaultEntry) error
+	GetVaultEntry(ctx context.Context, token string) (*models.VaultEntry, error)
+	ListVaultEntriesToRewrap(ctx context.Context, activeVersion, limit int) ([]*models.VaultEntry, error)
+	RewrapVaultEntry(ctx context.Context, token string, fromVersion, toVersion int, wrappedKey []byte) error
+}
+
+type vaultRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewVaultRepository(db *sqlx.DB) VaultRepository {
+	return &vaultRepositoryImpl{db: db}
+}
+
+func (r *vaultRepositoryImpl) CreateVaultEntry(ctx context.Context, entry *models.VaultEntry) error {
+	query := `
+		INSERT INTO card_vault (token, kek_version, wrapped_key, ciphertext)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at;
+	`
+
+	err := r.db.QueryRowContext(ctx, query, entry.Token, entry.KEKVersion, entry.WrappedKey, entry.Ciphertext).Scan(&entry.CreatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to create vault entry: %w", ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to create vault entry: %w", err)
+	}
+
+	return nil
+}
+
+func (r *vaultRepositoryImpl) GetVaultEntry(ctx context.Context, token string) (*models.VaultEntry, error) {
+	var entry models.VaultEntry
+	err := r.db.GetContext(ctx, &entry, `SELECT * FROM card_vault WHERE token = $1`, token)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get vault entry: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// ListVaultEntriesToRewrap returns up to limit entries whose data key is not
+// wrapped by the active key-encryption key.
+func (r *vaultRepositoryImpl) ListVaultEntriesToRewrap(ctx context.Context, activeVersion, limit int) ([]*models.VaultEntry, error) {
+	query := `SELECT * FROM card_vault WHERE kek_version <> $1 ORDER BY created_at LIMIT $2`
+
+	var entries []*models.VaultEntry
+	if err := r.db.SelectContext(ctx, &entries, query, activeVersion, limit); err != nil {
+		return nil, fmt.Errorf("failed to list vault entries to rewrap: %w", err)
+	}
+	return entries, nil
+}
+
+// RewrapVaultEntry replaces the wrapped data key of token, unless another
+// rewrap got there first.
+func (r *vaultRepositoryImpl) RewrapVaultEntry(ctx context.Context, token string, fromVersion, toVersion int, wrappedKey []byte) error {
+	query := `
+		UPDATE card_vault
+		SET kek_version = $3, wrapped_key = $4, rotated_at = CURRENT_TIMESTAMP
+		WHERE token = $1 AND kek_version = $2
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, token, fromVersion, toVersion, wrappedKey); err != nil {
+		return fmt.Errorf("failed to rewrap vault entry: %w", err)
+	}
+	return nil
+}
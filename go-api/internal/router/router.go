@@ -3,7 +3,17 @@ package router
 import (
 	"net/http"
 	"payment-gateway/go-api/internal/account"
+	"payment-gateway/go-api/internal/apikey"
+	"payment-gateway/go-api/internal/auth"
 	"payment-gateway/go-api/internal/card"
+	"payment-gateway/go-api/internal/idempotency"
+	"payment-gateway/go-api/internal/ledger"
+	"payment-gateway/go-api/internal/merchant"
+	"payment-gateway/go-api/internal/models"
+	"payment-gateway/go-api/internal/operator"
+	"payment-gateway/go-api/internal/outbox"
+	"payment-gateway/go-api/internal/session"
+	"payment-gateway/go-api/internal/statement"
 	"payment-gateway/go-api/internal/transaction"
 
 	"github.com/gorilla/mux"
@@ -14,6 +24,15 @@ type Router struct {
 	AccountHandler     *account.AccountHandler
 	CardHandler        *card.CardHandler
 	TransactionHandler *transaction.TransactionHandler
+	LedgerHandler      *ledger.LedgerHandler
+	StatementHandler   *statement.StatementHandler
+	OutboxHandler      *outbox.OutboxHandler
+	APIKeyHandler      *apikey.APIKeyHandler
+	SessionHandler     *session.SessionHandler
+	OperatorHandler    *operator.OperatorHandler
+	MerchantHandler    *merchant.MerchantHandler
+	Idempotency        *idempotency.Middleware
+	Auth               *auth.Middleware
 	muxRouter          *mux.Router
 }
 
@@ -21,11 +40,20 @@ func (r *Router) MuxRouter() http.Handler {
 	return r.muxRouter
 }
 
-func NewRouter(accountHandler *account.AccountHandler, cardHandler *card.CardHandler, transactionHandler *transaction.TransactionHandler) *Router {
+func NewRouter(accountHandler *account.AccountHandler, cardHandler *card.CardHandler, transactionHandler *transaction.TransactionHandler, ledgerHandler *ledger.LedgerHandler, statementHandler *statement.StatementHandler, outboxHandler *outbox.OutboxHandler, apiKeyHandler *apikey.APIKeyHandler, sessionHandler *session.SessionHandler, operatorHandler *operator.OperatorHandler, merchantHandler *merchant.MerchantHandler, idempotencyMiddleware *idempotency.Middleware, authMiddleware *auth.Middleware) *Router {
 	return &Router{
 		AccountHandler:     accountHandler,
 		CardHandler:        cardHandler,
 		TransactionHandler: transactionHandler,
+		LedgerHandler:      ledgerHandler,
+		StatementHandler:   statementHandler,
+		OutboxHandler:      outboxHandler,
+		APIKeyHandler:      apiKeyHandler,
+		SessionHandler:     sessionHandler,
+		OperatorHandler:    operatorHandler,
+		MerchantHandler:    merchantHandler,
+		Idempotency:        idempotencyMiddleware,
+		Auth:               authMiddleware,
 		muxRouter:          mux.NewRouter(),
 	}
 }
@@ -34,19 +62,67 @@ func (r *Router) RegisterRoutes() {
 	// Health check endpoint
 	r.muxRouter.HandleFunc("/health", r.healthCheck).Methods("GET")
 
-	r.muxRouter.HandleFunc("/accounts", r.AccountHandler.CreateAccount).Methods("POST")
-	r.muxRouter.HandleFunc("/accounts", r.AccountHandler.GetAllAccounts).Methods("GET")
-	r.muxRouter.HandleFunc("/accounts/{accountId}/balance", r.TransactionHandler.GetBalanceByAccountId).Methods("GET")
+	r.muxRouter.HandleFunc("/auth/register", r.SessionHandler.Register).Methods("POST")
+	r.muxRouter.HandleFunc("/auth/login", r.SessionHandler.Login).Methods("POST")
+	r.muxRouter.HandleFunc("/auth/refresh", r.SessionHandler.Refresh).Methods("POST")
+	r.muxRouter.HandleFunc("/auth/logout", r.SessionHandler.Logout).Methods("POST")
 
-	r.muxRouter.HandleFunc("/cards", r.CardHandler.CreateCard).Methods("POST")
-	r.muxRouter.HandleFunc("/cards/{accountId}", r.CardHandler.GetAllCardsByAccountId).Methods("GET")
+	// Every other route except /swagger/ requires an API key or session access
+	// token granting the scope named here. Operators get their scopes from
+	// their role (see the role_permissions table) and every request they make
+	// is written to the audit log. Every request only reaches the rows of the
+	// merchant its credentials belong to; only platform operators, not bound
+	// to a merchant, reach them all.
+	r.muxRouter.HandleFunc("/auth/password", r.Auth.Require(models.ScopeAccountsWrite, r.SessionHandler.ChangePassword)).Methods("PUT")
+	r.muxRouter.HandleFunc("/accounts", r.Auth.Require(models.ScopeAccountsWrite, r.AccountHandler.CreateAccount)).Methods("POST")
+	r.muxRouter.HandleFunc("/accounts", r.Auth.RequireOperator(models.ScopeAccountsRead, r.AccountHandler.GetAllAccounts)).Methods("GET")
+	r.muxRouter.HandleFunc("/accounts/by-username/{username}", r.Auth.RequireOperator(models.ScopeAccountsRead, r.AccountHandler.GetAccountByUsername)).Methods("GET")
+	r.muxRouter.HandleFunc("/accounts/{accountId}", r.Auth.Require(models.ScopeAccountsRead, r.AccountHandler.GetAccountById)).Methods("GET")
+	r.muxRouter.HandleFunc("/accounts/{accountId}", r.Auth.Require(models.ScopeAccountsWrite, r.AccountHandler.UpdateAccount)).Methods("PATCH")
+	r.muxRouter.HandleFunc("/accounts/{accountId}", r.Auth.RequireOperator(models.ScopeAccountsClose, r.AccountHandler.DeleteAccount)).Methods("DELETE")
+	r.muxRouter.HandleFunc("/accounts/{accountId}/freeze", r.Auth.RequireFor(models.ScopeAccountsWrite, models.ScopeAccountsFreeze, r.AccountHandler.FreezeAccount)).Methods("POST")
+	r.muxRouter.HandleFunc("/accounts/{accountId}/unfreeze", r.Auth.RequireOperator(models.ScopeAccountsFreeze, r.AccountHandler.UnfreezeAccount)).Methods("POST")
+	r.muxRouter.HandleFunc("/accounts/{accountId}/close", r.Auth.RequireOperator(models.ScopeAccountsClose, r.AccountHandler.CloseAccount)).Methods("POST")
+	r.muxRouter.HandleFunc("/accounts/{accountId}/balance", r.Auth.Require(models.ScopeTransactionsRead, r.TransactionHandler.GetBalanceByAccountId)).Methods("GET")
+	r.muxRouter.HandleFunc("/accounts/{accountId}/balance/history", r.Auth.Require(models.ScopeTransactionsRead, r.TransactionHandler.GetBalanceHistory)).Methods("GET")
+	r.muxRouter.HandleFunc("/accounts/{accountId}/ledger", r.Auth.Require(models.ScopeTransactionsRead, r.LedgerHandler.GetAccountLedger)).Methods("GET")
+	r.muxRouter.HandleFunc("/accounts/{accountId}/statement", r.Auth.Require(models.ScopeTransactionsRead, r.StatementHandler.GetStatement)).Methods("GET")
+
+	r.muxRouter.HandleFunc("/api-keys", r.Auth.Require(models.ScopeAdmin, r.APIKeyHandler.CreateKey)).Methods("POST")
+	r.muxRouter.HandleFunc("/api-keys", r.Auth.Require(models.ScopeAdmin, r.APIKeyHandler.ListKeys)).Methods("GET")
+	r.muxRouter.HandleFunc("/api-keys/{keyId}/rotate", r.Auth.Require(models.ScopeAdmin, r.APIKeyHandler.RotateKey)).Methods("POST")
+	r.muxRouter.HandleFunc("/api-keys/{keyId}", r.Auth.Require(models.ScopeAdmin, r.APIKeyHandler.RevokeKey)).Methods("DELETE")
+
+	r.muxRouter.HandleFunc("/operators", r.Auth.RequireOperator(models.ScopeOperatorsManage, r.OperatorHandler.CreateOperator)).Methods("POST")
+	r.muxRouter.HandleFunc("/operators", r.Auth.RequireOperator(models.ScopeOperatorsManage, r.OperatorHandler.ListOperators)).Methods("GET")
+	r.muxRouter.HandleFunc("/operators/{operatorId}", r.Auth.RequireOperator(models.ScopeOperatorsManage, r.OperatorHandler.UpdateOperator)).Methods("PATCH")
+	r.muxRouter.HandleFunc("/roles", r.Auth.RequireOperator(models.ScopeOperatorsManage, r.OperatorHandler.ListRoles)).Methods("GET")
+	r.muxRouter.HandleFunc("/merchants", r.Auth.RequireOperator(models.ScopeAdmin, r.MerchantHandler.CreateMerchant)).Methods("POST")
+	r.muxRouter.HandleFunc("/merchants", r.Auth.RequireOperator(models.ScopeAdmin, r.MerchantHandler.ListMerchants)).Methods("GET")
+	r.muxRouter.HandleFunc("/merchants/{merchantId}", r.Auth.RequireOperator(models.ScopeAdmin, r.MerchantHandler.GetMerchantById)).Methods("GET")
+	r.muxRouter.HandleFunc("/audit-logs", r.Auth.RequireOperator(models.ScopeAuditRead, r.OperatorHandler.ListAuditLogs)).Methods("GET")
+
+	r.muxRouter.HandleFunc("/cards", r.Auth.Require(models.ScopeCardsWrite, r.CardHandler.CreateCard)).Methods("POST")
+	r.muxRouter.HandleFunc("/cards/{accountId}", r.Auth.Require(models.ScopeCardsRead, r.CardHandler.GetAllCardsByAccountId)).Methods("GET")
+	r.muxRouter.HandleFunc("/cards/{cardId}/block", r.Auth.Require(models.ScopeCardsWrite, r.CardHandler.BlockCard)).Methods("POST")
+	r.muxRouter.HandleFunc("/cards/{cardId}/unblock", r.Auth.Require(models.ScopeCardsWrite, r.CardHandler.UnblockCard)).Methods("POST")
+	r.muxRouter.HandleFunc("/cards/{cardId}/cancel", r.Auth.Require(models.ScopeCardsWrite, r.CardHandler.CancelCard)).Methods("POST")
+	r.muxRouter.HandleFunc("/cards/{cardId}/replace", r.Auth.Require(models.ScopeCardsWrite, r.CardHandler.ReplaceCard)).Methods("POST")
+	r.muxRouter.HandleFunc("/cards/{cardId}/controls", r.Auth.Require(models.ScopeCardsRead, r.CardHandler.GetCardControls)).Methods("GET")
+	r.muxRouter.HandleFunc("/cards/{cardId}/controls", r.Auth.Require(models.ScopeCardsWrite, r.CardHandler.UpdateCardControls)).Methods("PUT")
+	r.muxRouter.HandleFunc("/cards/{cardId}/detokenize", r.Auth.Require(models.ScopeCardsDetokenize, r.CardHandler.DetokenizeCard)).Methods("POST")
 
 	r.muxRouter.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
 
-	r.muxRouter.HandleFunc("/transactions", r.TransactionHandler.CreateTransaction).Methods("POST")
-	r.muxRouter.HandleFunc("/transactions/{accountId}", r.TransactionHandler.GetAllTransactionByAccountIdTestOrderDate).Methods("GET")
-	r.muxRouter.HandleFunc("/transactions/card/{cardId}", r.TransactionHandler.GetAllTransactionByCardId).Methods("GET")
-	r.muxRouter.HandleFunc("/transactions/id/{transactionId}", r.TransactionHandler.FindTransactionById).Methods("GET")
+	r.muxRouter.HandleFunc("/transactions", r.Auth.RequireAny([]string{models.ScopeTransactionsWrite, models.ScopeTransactionsRefund}, r.Idempotency.Wrap(r.TransactionHandler.CreateTransaction))).Methods("POST")
+	r.muxRouter.HandleFunc("/transactions/search", r.Auth.Require(models.ScopeTransactionsRead, r.TransactionHandler.SearchTransactions)).Methods("GET")
+	r.muxRouter.HandleFunc("/transactions/{accountId}", r.Auth.Require(models.ScopeTransactionsRead, r.TransactionHandler.GetAllTransactionByAccountIdTestOrderDate)).Methods("GET")
+	r.muxRouter.HandleFunc("/transactions/card/{cardId}", r.Auth.Require(models.ScopeTransactionsRead, r.TransactionHandler.GetAllTransactionByCardId)).Methods("GET")
+	r.muxRouter.HandleFunc("/transactions/id/{transactionId}", r.Auth.Require(models.ScopeTransactionsRead, r.TransactionHandler.FindTransactionById)).Methods("GET")
+	r.muxRouter.HandleFunc("/transactions/{transactionId}/capture", r.Auth.Require(models.ScopeTransactionsWrite, r.Idempotency.Wrap(r.TransactionHandler.CaptureTransaction))).Methods("POST")
+	r.muxRouter.HandleFunc("/transactions/{transactionId}/void", r.Auth.Require(models.ScopeTransactionsWrite, r.Idempotency.Wrap(r.TransactionHandler.VoidTransaction))).Methods("POST")
+
+	r.muxRouter.HandleFunc("/outbox/metrics", r.Auth.Require(models.ScopeAdmin, r.OutboxHandler.GetMetrics)).Methods("GET")
 }
 
 func (r *Router) healthCheck(w http.ResponseWriter, req *http.Request) {
@@ -0,0 +1,50 @@
+package session
+
+import (
+	"context"
+	"payment-gateway/go-api/internal/account"
+	"payment-gateway/go-api/internal/auth"
+	"payment-gateway/go-api/internal/models"
+	"payment-gateway/go-api/internal/tenant"
+)
+
+// accountHolderScopes are granted to every session: an account holder can do
+// anything on their own account, but never administer the platform.
+var accountHolderScopes = []string{
+	models.ScopeAccountsRead,
+	models.ScopeAccountsWrite,
+	models.ScopeCardsRead,
+	models.ScopeCardsWrite,
+	models.ScopeCardsDetokenize,
+	models.ScopeTransactionsRead,
+	models.ScopeTransactionsWrite,
+}
+
+// Authenticator lets the auth middleware accept session access tokens.
+type Authenticator struct {
+	service        SessionService
+	accountService account.AccountService
+}
+
+func NewAuthenticator(service SessionService, accountService account.AccountService) *Authenticator {
+	return &Authenticator{service: service, accountService: accountService}
+}
+
+func (a *Authenticator) Authenticate(ctx context.Context, token string) (*auth.Principal, error) {
+	accountId, merchantId, err := a.service.AccountFromAccessToken(token)
+	if err != nil {
+		return nil, auth.ErrInvalidCredentials
+	}
+
+	// Access tokens stay valid until they expire, so the account is checked
+	// on every request: closing or deleting it signs it out at once.
+	owner, err := a.accountService.GetAccountById(tenant.WithMerchant(ctx, merchantId), accountId)
+	if err != nil {
+		return nil, err
+	}
+	if owner == nil || owner.IsClosed() {
+		return nil, auth.ErrRevokedCredentials
+	}
+
+	return &auth.Principal{AccountId: accountId, MerchantId: merchantId, Scopes: accountHolderScopes}, nil
+}
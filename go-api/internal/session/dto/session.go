@@ -0,0 +1,65 @@
+package dto
+
+import "payment-gateway/go-api/internal/models"
+
+type RegisterRequest struct {
+	// @Description The username of the new account.
+	// @Example charlie
+	Username string `json:"username" validate:"required,min=3,max=100"`
+
+	// @Description Password used to sign in.
+	// @Example s3nh4-f0rt3
+	Password string `json:"password" validate:"required,min=8,max=72"`
+
+	// @Description Merchant to sign up with. Defaults to the default merchant.
+	// @Format uuid
+	MerchantId *string `json:"merchant_id" validate:"omitempty,uuid"`
+}
+
+type LoginRequest struct {
+	// @Example charlie
+	Username string `json:"username" validate:"required"`
+
+	// @Example s3nh4-f0rt3
+	Password string `json:"password" validate:"required"`
+
+	// @Description Merchant the account belongs to. Defaults to the default merchant.
+	// @Format uuid
+	MerchantId *string `json:"merchant_id" validate:"omitempty,uuid"`
+}
+
+type RefreshRequest struct {
+	// @Description Refresh token returned by login, register or a previous refresh.
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type ChangePasswordRequest struct {
+	// @Example s3nh4-f0rt3
+	CurrentPassword string `json:"current_password" validate:"required"`
+
+	// @Example n0v4-s3nh4-f0rt3
+	NewPassword string `json:"new_password" validate:"required,min=8,max=72"`
+}
+
+// SessionResponse carries a new access and refresh token pair. Refresh tokens
+// are single use: each refresh returns a new one and revokes the old.
+type SessionResponse struct {
+	// @Description JWT to send as "Authorization: Bearer <token>".
+	AccessToken string `json:"access_token"`
+
+	// @Example Bearer
+	TokenType string `json:"token_type"`
+
+	// @Description Seconds until the access token expires.
+	// @Example 900
+	ExpiresIn int64 `json:"expires_in"`
+
+	// @Description Opaque token exchanged for a new pair at /auth/refresh.
+	RefreshToken string `json:"refresh_token"`
+
+	// @Description Seconds until the refresh token expires.
+	// @Example 2592000
+	RefreshExpiresIn int64 `json:"refresh_expires_in"`
+
+	Account *models.Account `json:"account"`
+}
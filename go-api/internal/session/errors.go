@@ -0,0 +1,10 @@
+package session
+
+import "errors"
+
+var (
+	ErrInvalidCredentials  = errors.New("invalid username or password")
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrInvalidAccessToken  = errors.New("invalid or expired access token")
+	ErrAccountRequired     = errors.New("caller is not tied to an account")
+)
@@ -0,0 +1,206 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"payment-gateway/go-api/internal/api"
+	"payment-gateway/go-api/internal/auth"
+	"payment-gateway/go-api/internal/i18n"
+	"payment-gateway/go-api/internal/merchant"
+	"payment-gateway/go-api/internal/repository"
+	"payment-gateway/go-api/internal/session/dto"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type SessionHandler struct {
+	service  SessionService
+	validate *validator.Validate
+}
+
+func NewSessionHandler(service SessionService) *SessionHandler {
+	return &SessionHandler{
+		service:  service,
+		validate: validator.New(),
+	}
+}
+
+// @Summary Register
+// @Description Creates an account with a password in the given merchant, the default merchant when omitted, and signs it in.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param account body dto.RegisterRequest true "Username and password"
+// @Success 201 {object} dto.SessionResponse
+// @Failure 400 {object} api.APIError "Invalid request body or validation failed"
+// @Failure 404 {object} api.APIError "Merchant not found"
+// @Failure 409 {object} api.APIError "Username already taken in the merchant"
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Router /auth/register [post]
+func (h *SessionHandler) Register(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+
+	var req dto.RegisterRequest
+	if !h.decode(w, r, lang, &req) {
+		return
+	}
+
+	session, err := h.service.Register(r.Context(), req)
+	if err != nil {
+		writeSessionError(w, lang, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(session)
+}
+
+// @Summary Login
+// @Description Signs in with username and password, within the given merchant or the default one, and returns an access and refresh token pair.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body dto.LoginRequest true "Username and password"
+// @Success 200 {object} dto.SessionResponse
+// @Failure 400 {object} api.APIError "Invalid request body"
+// @Failure 401 {object} api.APIError "Invalid username or password"
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Router /auth/login [post]
+func (h *SessionHandler) Login(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+
+	var req dto.LoginRequest
+	if !h.decode(w, r, lang, &req) {
+		return
+	}
+
+	session, err := h.service.Login(r.Context(), req)
+	if err != nil {
+		writeSessionError(w, lang, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(session)
+}
+
+// @Summary Refresh session
+// @Description Exchanges a refresh token for a new token pair. The refresh token sent is revoked.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param token body dto.RefreshRequest true "Refresh token"
+// @Success 200 {object} dto.SessionResponse
+// @Failure 400 {object} api.APIError "Invalid request body"
+// @Failure 401 {object} api.APIError "Invalid or expired refresh token"
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Router /auth/refresh [post]
+func (h *SessionHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+
+	var req dto.RefreshRequest
+	if !h.decode(w, r, lang, &req) {
+		return
+	}
+
+	session, err := h.service.Refresh(r.Context(), req.RefreshToken)
+	if err != nil {
+		writeSessionError(w, lang, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(session)
+}
+
+// @Summary Logout
+// @Description Revokes the refresh token. The access token stays valid until it expires.
+// @Tags auth
+// @Accept json
+// @Param token body dto.RefreshRequest true "Refresh token"
+// @Success 204
+// @Failure 400 {object} api.APIError "Invalid request body"
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Router /auth/logout [post]
+func (h *SessionHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+
+	var req dto.RefreshRequest
+	if !h.decode(w, r, lang, &req) {
+		return
+	}
+
+	if err := h.service.Logout(r.Context(), req.RefreshToken); err != nil {
+		writeSessionError(w, lang, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Change password
+// @Description Changes the password of the caller's account and revokes every refresh token of the account.
+// @Tags auth
+// @Accept json
+// @Security BearerAuth
+// @Param password body dto.ChangePasswordRequest true "Current and new password"
+// @Success 204
+// @Failure 400 {object} api.APIError "Invalid request body or validation failed"
+// @Failure 401 {object} api.APIError "Current password is wrong"
+// @Failure 403 {object} api.APIError "Caller is not tied to an account"
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Router /auth/password [put]
+func (h *SessionHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+
+	accountId, ok := auth.AccountScope(r.Context())
+	if !ok {
+		writeSessionError(w, lang, ErrAccountRequired)
+		return
+	}
+
+	var req dto.ChangePasswordRequest
+	if !h.decode(w, r, lang, &req) {
+		return
+	}
+
+	if err := h.service.ChangePassword(r.Context(), accountId, req); err != nil {
+		writeSessionError(w, lang, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *SessionHandler) decode(w http.ResponseWriter, r *http.Request, lang string, req interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidRequestBody))
+		return false
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		api.WriteError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+
+	return true
+}
+
+func writeSessionError(w http.ResponseWriter, lang string, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidCredentials):
+		api.WriteError(w, http.StatusUnauthorized, i18n.GetErrorMessage(lang, i18n.ErrorInvalidCredentials))
+	case errors.Is(err, ErrInvalidRefreshToken):
+		api.WriteError(w, http.StatusUnauthorized, i18n.GetErrorMessage(lang, i18n.ErrorInvalidRefreshToken))
+	case errors.Is(err, ErrAccountRequired):
+		api.WriteError(w, http.StatusForbidden, i18n.GetErrorMessage(lang, i18n.ErrorAccountRequired))
+	case errors.Is(err, repository.ErrDuplicateKey):
+		api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorDuplicateKey))
+	case errors.Is(err, merchant.ErrMerchantNotFound):
+		api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorMerchantNotFound))
+	default:
+		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorInternalServerError))
+	}
+}
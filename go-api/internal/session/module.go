@@ -0,0 +1,25 @@
+package session
+
+import (
+	"payment-gateway/go-api/internal/account"
+	"payment-gateway/go-api/internal/connection"
+	"time"
+)
+
+type Module struct {
+	Handler       *SessionHandler
+	Authenticator *Authenticator
+	Service       SessionService
+}
+
+func NewModule(accountService account.AccountService, redis connection.RedisConnection, secret []byte, accessTTL, refreshTTL time.Duration) *Module {
+	service := NewSessionService(accountService, redis, secret, accessTTL, refreshTTL)
+	handler := NewSessionHandler(service)
+	authenticator := NewAuthenticator(service, accountService)
+
+	return &Module{
+		Handler:       handler,
+		Authenticator: authenticator,
+		Service:       service,
+	}
+}
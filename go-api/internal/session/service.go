@@ -0,0 +1,212 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"payment-gateway/go-api/internal/account"
+	"payment-gateway/go-api/internal/connection"
+	"payment-gateway/go-api/internal/models"
+	"payment-gateway/go-api/internal/session/dto"
+	"payment-gateway/go-api/internal/tenant"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Refresh tokens live in Redis under refreshTokenKey, holding the account id,
+// and every account keeps the set of its refresh tokens under sessionsKey so
+// they can all be revoked at once.
+const (
+	refreshTokenKeyPrefix = "refresh:"
+	sessionsKeyPrefix     = "sessions:"
+)
+
+// dummyPasswordHash is compared against when the username is unknown, so a
+// failed login takes as long whether or not the account exists.
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("payment-gateway"), bcrypt.DefaultCost)
+
+type SessionService interface {
+	Register(ctx context.Context, req dto.RegisterRequest) (*dto.SessionResponse, error)
+	Login(ctx context.Context, req dto.LoginRequest) (*dto.SessionResponse, error)
+	Refresh(ctx context.Context, refreshToken string) (*dto.SessionResponse, error)
+	Logout(ctx context.Context, refreshToken string) error
+	ChangePassword(ctx context.Context, accountId string, req dto.ChangePasswordRequest) error
+	AccountFromAccessToken(token string) (accountId, merchantId string, err error)
+}
+
+type sessionServiceImpl struct {
+	accountService account.AccountService
+	redis          connection.RedisConnection
+	secret         []byte
+	accessTTL      time.Duration
+	refreshTTL     time.Duration
+}
+
+func NewSessionService(accountService account.AccountService, redis connection.RedisConnection, secret []byte, accessTTL, refreshTTL time.Duration) *sessionServiceImpl {
+	return &sessionServiceImpl{accountService: accountService, redis: redis, secret: secret, accessTTL: accessTTL, refreshTTL: refreshTTL}
+}
+
+func (s *sessionServiceImpl) Register(ctx context.Context, req dto.RegisterRequest) (*dto.SessionResponse, error) {
+	ctx = tenant.WithMerchant(ctx, merchantOrDefault(req.MerchantId))
+
+	created, err := s.accountService.CreateAccount(ctx, req.Username, &req.Password)
+	if err != nil {
+		return nil, err
+	}
+	return s.issue(ctx, created)
+}
+
+func (s *sessionServiceImpl) Login(ctx context.Context, req dto.LoginRequest) (*dto.SessionResponse, error) {
+	ctx = tenant.WithMerchant(ctx, merchantOrDefault(req.MerchantId))
+
+	existing, err := s.accountService.GetAccountByUsername(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := dummyPasswordHash
+	if existing != nil && existing.PasswordHash.Valid {
+		hash = []byte(existing.PasswordHash.String)
+	}
+	if err := bcrypt.CompareHashAndPassword(hash, []byte(req.Password)); err != nil || existing == nil || !existing.PasswordHash.Valid || existing.IsClosed() {
+		return nil, ErrInvalidCredentials
+	}
+
+	return s.issue(ctx, existing)
+}
+
+// Refresh exchanges a refresh token for a new pair. The old refresh token is
+// consumed atomically, so a stolen token can be used at most once.
+func (s *sessionServiceImpl) Refresh(ctx context.Context, refreshToken string) (*dto.SessionResponse, error) {
+	hash := hashRefreshToken(refreshToken)
+
+	accountId, err := s.redis.Client.GetDel(ctx, refreshTokenKeyPrefix+hash).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read refresh token: %w", err)
+	}
+	s.redis.Client.SRem(ctx, sessionsKeyPrefix+accountId, hash)
+
+	// The refresh token names the account, whatever its merchant.
+	existing, err := s.accountService.GetAccountById(tenant.WithAllMerchants(ctx), accountId)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil || existing.IsClosed() {
+		if err := s.revokeAll(ctx, accountId); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+
+	return s.issue(ctx, existing)
+}
+
+func (s *sessionServiceImpl) Logout(ctx context.Context, refreshToken string) error {
+	hash := hashRefreshToken(refreshToken)
+
+	accountId, err := s.redis.Client.GetDel(ctx, refreshTokenKeyPrefix+hash).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+	return s.redis.Client.SRem(ctx, sessionsKeyPrefix+accountId, hash).Err()
+}
+
+// ChangePassword sets a new password and signs the account out everywhere by
+// revoking all of its refresh tokens. Access tokens already issued stay valid
+// until they expire.
+func (s *sessionServiceImpl) ChangePassword(ctx context.Context, accountId string, req dto.ChangePasswordRequest) error {
+	existing, err := s.accountService.GetAccountById(ctx, accountId)
+	if err != nil {
+		return err
+	}
+	if existing == nil || !existing.PasswordHash.Valid {
+		return ErrInvalidCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(existing.PasswordHash.String), []byte(req.CurrentPassword)); err != nil {
+		return ErrInvalidCredentials
+	}
+
+	if err := s.accountService.SetPassword(ctx, accountId, req.NewPassword); err != nil {
+		return err
+	}
+	return s.revokeAll(ctx, accountId)
+}
+
+// AccountFromAccessToken validates an access token and returns its account and
+// merchant ids.
+func (s *sessionServiceImpl) AccountFromAccessToken(token string) (string, string, error) {
+	claims, err := s.parseAccessToken(token)
+	if err != nil {
+		return "", "", err
+	}
+	return claims.Subject, claims.MerchantId, nil
+}
+
+func (s *sessionServiceImpl) issue(ctx context.Context, owner *models.Account) (*dto.SessionResponse, error) {
+	now := time.Now()
+
+	accessToken, err := s.signAccessToken(owner.ID, owner.MerchantId, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign access token: %w", err)
+	}
+
+	refreshToken, err := randomToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	hash := hashRefreshToken(refreshToken)
+
+	sessionsKey := sessionsKeyPrefix + owner.ID
+	pipe := s.redis.Client.TxPipeline()
+	pipe.Set(ctx, refreshTokenKeyPrefix+hash, owner.ID, s.refreshTTL)
+	pipe.SAdd(ctx, sessionsKey, hash)
+	pipe.Expire(ctx, sessionsKey, s.refreshTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return &dto.SessionResponse{
+		AccessToken:      accessToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int64(s.accessTTL / time.Second),
+		RefreshToken:     refreshToken,
+		RefreshExpiresIn: int64(s.refreshTTL / time.Second),
+		Account:          owner,
+	}, nil
+}
+
+// merchantOrDefault is the merchant a sign-up or sign-in is for.
+func merchantOrDefault(merchantId *string) string {
+	if merchantId == nil {
+		return models.DefaultMerchantId
+	}
+	return *merchantId
+}
+
+func (s *sessionServiceImpl) revokeAll(ctx context.Context, accountId string) error {
+	sessionsKey := sessionsKeyPrefix + accountId
+
+	hashes, err := s.redis.Client.SMembers(ctx, sessionsKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	keys := make([]string, 0, len(hashes)+1)
+	for _, hash := range hashes {
+		keys = append(keys, refreshTokenKeyPrefix+hash)
+	}
+	keys = append(keys, sessionsKey)
+
+	if err := s.redis.Client.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package statement
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// camt053Renderer writes an ISO 20022 BankToCustomerStatement
+// (camt.053.001.08). Balances must precede the entries, so the closing
+// balance is taken from the header computed when the statement was opened.
+type camt053Renderer struct {
+	w      io.Writer
+	lang   string
+	header Header
+}
+
+func newCAMT053Renderer(w io.Writer, lang string) Renderer {
+	return &camt053Renderer{w: w, lang: lang}
+}
+
+func isoTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05Z")
+}
+
+// camtAmount splits a signed amount into the unsigned amount and the
+// credit/debit indicator used by ISO 20022.
+func camtAmount(cents int64) (string, string) {
+	if cents < 0 {
+		return formatCents(-cents), "DBIT"
+	}
+	return formatCents(cents), "CRDT"
+}
+
+func (r *camt053Renderer) balance(code string, cents int64, at time.Time) string {
+	amount, indicator := camtAmount(cents)
+	return fmt.Sprintf(`<Bal>
+<Tp><CdOrPrtry><Cd>%s</Cd></CdOrPrtry></Tp>
+<Amt Ccy="%s">%s</Amt>
+<CdtDbtInd>%s</CdtDbtInd>
+<Dt><DtTm>%s</DtTm></Dt>
+</Bal>
+`, code, xmlText(r.header.Currency), amount, indicator, isoTime(at))
+}
+
+func (r *camt053Renderer) WriteHeader(header Header) error {
+	r.header = header
+	ref := documentRef(header)
+
+	_, err := fmt.Fprintf(r.w, `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08">
+<BkToCstmrStmt>
+<GrpHdr>
+<MsgId>%s</MsgId>
+<CreDtTm>%s</CreDtTm>
+</GrpHdr>
+<Stmt>
+<Id>%s</Id>
+<CreDtTm>%s</CreDtTm>
+<FrToDt>
+<FrDtTm>%s</FrDtTm>
+<ToDtTm>%s</ToDtTm>
+</FrToDt>
+<Acct>
+<Id><Othr><Id>%s</Id></Othr></Id>
+<Ccy>%s</Ccy>
+<Ownr><Nm>%s</Nm></Ownr>
+</Acct>
+%s%s`,
+		ref,
+		isoTime(header.GeneratedAt),
+		ref,
+		isoTime(header.GeneratedAt),
+		isoTime(header.From),
+		isoTime(header.To),
+		transactionRef(header.AccountId),
+		xmlText(header.Currency),
+		xmlText(header.Username),
+		r.balance("OPBD", header.OpeningBalance, header.From),
+		r.balance("CLBD", header.ClosingBalance, header.To),
+	)
+	return err
+}
+
+func (r *camt053Renderer) WriteLine(line Line) error {
+	entry := line.Entry
+	ref := transactionRef(entry.ID)
+	amount, indicator := camtAmount(entry.SignedAmountCents)
+	bookedAt := isoTime(entryTime(entry))
+
+	_, err := fmt.Fprintf(r.w, `<Ntry>
+<NtryRef>%s</NtryRef>
+<Amt Ccy="%s">%s</Amt>
+<CdtDbtInd>%s</CdtDbtInd>
+<Sts><Cd>BOOK</Cd></Sts>
+<BookgDt><DtTm>%s</DtTm></BookgDt>
+<ValDt><DtTm>%s</DtTm></ValDt>
+<AcctSvcrRef>%s</AcctSvcrRef>
+<BkTxCd><Prtry><Cd>%s</Cd></Prtry></BkTxCd>
+<NtryDtls>
+<TxDtls>
+<Refs><AcctSvcrRef>%s</AcctSvcrRef></Refs>
+<AddtlTxInf>%s</AddtlTxInf>
+</TxDtls>
+</NtryDtls>
+</Ntry>
+`,
+		ref,
+		xmlText(r.header.Currency),
+		amount,
+		indicator,
+		bookedAt,
+		bookedAt,
+		ref,
+		entry.Type,
+		ref,
+		xmlText(describe(r.lang, entry)),
+	)
+	return err
+}
+
+func (r *camt053Renderer) Close(footer Footer) error {
+	_, err := io.WriteString(r.w, `</Stmt>
+</BkToCstmrStmt>
+</Document>
+`)
+	return err
+}
@@ -0,0 +1,61 @@
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+	"payment-gateway/go-api/internal/i18n"
+	"time"
+)
+
+type csvRenderer struct {
+	writer *csv.Writer
+	lang   string
+}
+
+func newCSVRenderer(w io.Writer, lang string) Renderer {
+	return &csvRenderer{writer: csv.NewWriter(w), lang: lang}
+}
+
+func (r *csvRenderer) label(key string) string {
+	return i18n.GetLabel(r.lang, key)
+}
+
+func (r *csvRenderer) WriteHeader(header Header) error {
+	columns := []string{
+		r.label(i18n.LabelDate),
+		r.label(i18n.LabelTransactionId),
+		r.label(i18n.LabelType),
+		r.label(i18n.LabelDescription),
+		r.label(i18n.LabelAmount),
+		r.label(i18n.LabelRunningBalance),
+	}
+	if err := r.writer.Write(columns); err != nil {
+		return err
+	}
+
+	return r.writer.Write([]string{
+		header.From.UTC().Format(time.RFC3339), "", "", r.label(i18n.LabelOpeningBalance), "", formatCents(header.OpeningBalance),
+	})
+}
+
+func (r *csvRenderer) WriteLine(line Line) error {
+	return r.writer.Write([]string{
+		entryTime(line.Entry).Format(time.RFC3339),
+		line.Entry.ID,
+		line.Entry.Type,
+		describe(r.lang, line.Entry),
+		formatCents(line.Entry.SignedAmountCents),
+		formatCents(line.RunningBalance),
+	})
+}
+
+func (r *csvRenderer) Close(footer Footer) error {
+	if err := r.writer.Write([]string{
+		"", "", "", r.label(i18n.LabelClosingBalance), "", formatCents(footer.ClosingBalance),
+	}); err != nil {
+		return err
+	}
+
+	r.writer.Flush()
+	return r.writer.Error()
+}
@@ -0,0 +1,9 @@
+package statement
+
+import "errors"
+
+var (
+	ErrAccountNotFound = errors.New("account not found")
+	ErrInvalidRange    = errors.New("from must be before to")
+	ErrInvalidFormat   = errors.New("unsupported statement format")
+)
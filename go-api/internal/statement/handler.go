@@ -0,0 +1,103 @@
+package statement
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"payment-gateway/go-api/internal/api"
+	"payment-gateway/go-api/internal/i18n"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+type StatementHandler struct {
+	service StatementService
+}
+
+func NewStatementHandler(service StatementService) *StatementHandler {
+	return &StatementHandler{service: service}
+}
+
+// @ID get-account-statement
+// @Summary Export an account statement
+// @Description Streams the opening balance, every approved movement with its running balance and the closing balance of an account.
+// @Description Column headers and descriptions follow Accept-Language (en-US or pt-BR).
+// @Description ofx (OFX 2.2) and camt053 (ISO 20022 camt.053.001.08) can be imported by accounting software; their transaction IDs are the transaction UUIDs without dashes.
+// @Tags accounts
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce application/pdf
+// @Produce application/x-ofx
+// @Produce application/xml
+// @Param accountId path string true "Account ID"
+// @Param from query string false "Start of the period (RFC3339). Defaults to 30 days before to."
+// @Param to query string false "End of the period, exclusive (RFC3339). Defaults to now."
+// @Param format query string false "Output format" Enums(csv, jsonl, pdf, ofx, camt053) default(csv)
+// @Param Accept-Language header string false "Language of labels" default(en-US)
+// @Success 200 {file} file "Statement document"
+// @Failure 400 {object} api.APIError "Invalid period or format"
+// @Failure 404 {object} api.APIError "Account not found"
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Security BearerAuth
+// @Router /accounts/{accountId}/statement [get]
+func (h *StatementHandler) GetStatement(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+	accountId := mux.Vars(r)["accountId"]
+	query := r.URL.Query()
+
+	formatName := query.Get("format")
+	if formatName == "" {
+		formatName = "csv"
+	}
+	format, ok := LookupFormat(formatName)
+	if !ok {
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidStatementFormat))
+		return
+	}
+
+	to := time.Now().UTC()
+	if toStr := query.Get("to"); toStr != "" {
+		parsed, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidTimestamp))
+			return
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -30)
+	if fromStr := query.Get("from"); fromStr != "" {
+		parsed, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidTimestamp))
+			return
+		}
+		from = parsed
+	}
+
+	statement, err := h.service.Open(r.Context(), accountId, from, to)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidRange):
+			api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidHistoryRange))
+		case errors.Is(err, ErrAccountNotFound):
+			api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorAccountNotFound))
+		default:
+			api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorGeneratingStatement))
+		}
+		return
+	}
+	defer statement.Close()
+
+	filename := fmt.Sprintf("statement-%s-%s-%s.%s", accountId, from.UTC().Format("20060102"), to.UTC().Format("20060102"), format.Extension)
+	w.Header().Set("Content-Type", format.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	// The status is already sent: a failure now can only cut the document short.
+	if err := statement.Render(r.Context(), format.New(w, lang)); err != nil {
+		log.Printf("Failed to stream statement of account %s: %v", accountId, err)
+	}
+}
@@ -0,0 +1,87 @@
+package statement
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// The JSON Lines format writes one record per line: a header, one entry per
+// movement and a footer, told apart by their "record" field.
+
+type jsonlHeader struct {
+	Record              string `json:"record"`
+	AccountId           string `json:"account_id"`
+	Username            string `json:"username"`
+	From                string `json:"from"`
+	To                  string `json:"to"`
+	GeneratedAt         string `json:"generated_at"`
+	OpeningBalanceCents int64  `json:"opening_balance_cents"`
+}
+
+type jsonlEntry struct {
+	Record              string  `json:"record"`
+	TransactionId       string  `json:"transaction_id"`
+	CreatedAt           string  `json:"created_at"`
+	Type                string  `json:"type"`
+	Description         string  `json:"description"`
+	ReasonCode          *string `json:"reason_code,omitempty"`
+	AmountCents         int64   `json:"amount_cents"`
+	RunningBalanceCents int64   `json:"running_balance_cents"`
+}
+
+type jsonlFooter struct {
+	Record              string `json:"record"`
+	EntryCount          int    `json:"entry_count"`
+	TotalCreditsCents   int64  `json:"total_credits_cents"`
+	TotalDebitsCents    int64  `json:"total_debits_cents"`
+	ClosingBalanceCents int64  `json:"closing_balance_cents"`
+}
+
+type jsonlRenderer struct {
+	encoder *json.Encoder
+	lang    string
+}
+
+func newJSONLRenderer(w io.Writer, lang string) Renderer {
+	return &jsonlRenderer{encoder: json.NewEncoder(w), lang: lang}
+}
+
+func (r *jsonlRenderer) WriteHeader(header Header) error {
+	return r.encoder.Encode(jsonlHeader{
+		Record:              "header",
+		AccountId:           header.AccountId,
+		Username:            header.Username,
+		From:                header.From.UTC().Format(time.RFC3339),
+		To:                  header.To.UTC().Format(time.RFC3339),
+		GeneratedAt:         header.GeneratedAt.UTC().Format(time.RFC3339),
+		OpeningBalanceCents: header.OpeningBalance,
+	})
+}
+
+func (r *jsonlRenderer) WriteLine(line Line) error {
+	entry := jsonlEntry{
+		Record:              "entry",
+		TransactionId:       line.Entry.ID,
+		CreatedAt:           entryTime(line.Entry).Format(time.RFC3339Nano),
+		Type:                line.Entry.Type,
+		Description:         describe(r.lang, line.Entry),
+		AmountCents:         line.Entry.SignedAmountCents,
+		RunningBalanceCents: line.RunningBalance,
+	}
+	if line.Entry.ReasonCode.Valid {
+		entry.ReasonCode = &line.Entry.ReasonCode.String
+	}
+
+	return r.encoder.Encode(entry)
+}
+
+func (r *jsonlRenderer) Close(footer Footer) error {
+	return r.encoder.Encode(jsonlFooter{
+		Record:              "footer",
+		EntryCount:          footer.EntryCount,
+		TotalCreditsCents:   footer.TotalCredits,
+		TotalDebitsCents:    footer.TotalDebits,
+		ClosingBalanceCents: footer.ClosingBalance,
+	})
+}
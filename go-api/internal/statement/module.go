@@ -0,0 +1,24 @@
+package statement
+
+import (
+	"payment-gateway/go-api/internal/account"
+	"payment-gateway/go-api/internal/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type Module struct {
+	Handler *StatementHandler
+	Service StatementService
+}
+
+func NewModule(db *sqlx.DB, accountService account.AccountService, currency string) *Module {
+	repo := repository.NewStatementRepository(db)
+	service := NewStatementService(repo, accountService, currency)
+	handler := NewStatementHandler(service)
+
+	return &Module{
+		Handler: handler,
+		Service: service,
+	}
+}
@@ -0,0 +1,126 @@
+package statement
+
+import (
+	"fmt"
+	"io"
+	"payment-gateway/go-api/internal/models"
+	"strings"
+	"time"
+)
+
+// ofxRenderer writes an OFX 2.2 (XML) bank statement response. The ledger
+// balance comes after the transaction list, so it is written from the footer.
+type ofxRenderer struct {
+	w      io.Writer
+	lang   string
+	header Header
+}
+
+func newOFXRenderer(w io.Writer, lang string) Renderer {
+	return &ofxRenderer{w: w, lang: lang}
+}
+
+// ofxTime formats t as an OFX datetime in UTC.
+func ofxTime(t time.Time) string {
+	return t.UTC().Format("20060102150405.000") + "[0:GMT]"
+}
+
+func ofxLanguage(lang string) string {
+	if strings.HasPrefix(strings.ToLower(lang), "pt") {
+		return "POR"
+	}
+	return "ENG"
+}
+
+func ofxTransactionType(entry *models.StatementEntry) string {
+	switch entry.Type {
+	case models.TransactionTypeDeposit:
+		return "DEP"
+	case models.TransactionTypePurchase:
+		return "POS"
+	case models.TransactionTypeCharge:
+		return "FEE"
+	case models.TransactionTypeTransferOut, models.TransactionTypeTransferIn:
+		return "XFER"
+	}
+	if entry.SignedAmountCents < 0 {
+		return "DEBIT"
+	}
+	return "CREDIT"
+}
+
+func (r *ofxRenderer) WriteHeader(header Header) error {
+	r.header = header
+	_, err := fmt.Fprintf(r.w, `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+<SIGNONMSGSRSV1>
+<SONRS>
+<STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>
+<DTSERVER>%s</DTSERVER>
+<LANGUAGE>%s</LANGUAGE>
+</SONRS>
+</SIGNONMSGSRSV1>
+<BANKMSGSRSV1>
+<STMTTRNRS>
+<TRNUID>%s</TRNUID>
+<STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>
+<STMTRS>
+<CURDEF>%s</CURDEF>
+<BANKACCTFROM>
+<BANKID>PAYMENTGATEWAY</BANKID>
+<ACCTID>%s</ACCTID>
+<ACCTTYPE>CHECKING</ACCTTYPE>
+</BANKACCTFROM>
+<BANKTRANLIST>
+<DTSTART>%s</DTSTART>
+<DTEND>%s</DTEND>
+`,
+		ofxTime(header.GeneratedAt),
+		ofxLanguage(r.lang),
+		documentRef(header),
+		xmlText(header.Currency),
+		transactionRef(header.AccountId),
+		ofxTime(header.From),
+		ofxTime(header.To),
+	)
+	return err
+}
+
+func (r *ofxRenderer) WriteLine(line Line) error {
+	entry := line.Entry
+	_, err := fmt.Fprintf(r.w, `<STMTTRN>
+<TRNTYPE>%s</TRNTYPE>
+<DTPOSTED>%s</DTPOSTED>
+<TRNAMT>%s</TRNAMT>
+<FITID>%s</FITID>
+<NAME>%s</NAME>
+<MEMO>%s</MEMO>
+</STMTTRN>
+`,
+		ofxTransactionType(entry),
+		ofxTime(entryTime(entry)),
+		formatCents(entry.SignedAmountCents),
+		transactionRef(entry.ID),
+		xmlText(truncate(describe(r.lang, entry), 32)),
+		xmlText(entry.Type+" "+entry.ID),
+	)
+	return err
+}
+
+func (r *ofxRenderer) Close(footer Footer) error {
+	_, err := fmt.Fprintf(r.w, `</BANKTRANLIST>
+<LEDGERBAL>
+<BALAMT>%s</BALAMT>
+<DTASOF>%s</DTASOF>
+</LEDGERBAL>
+</STMTRS>
+</STMTTRNRS>
+</BANKMSGSRSV1>
+</OFX>
+`,
+		formatCents(footer.ClosingBalance),
+		ofxTime(r.header.To),
+	)
+	return err
+}
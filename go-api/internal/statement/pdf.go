@@ -0,0 +1,256 @@
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"payment-gateway/go-api/internal/i18n"
+	"strings"
+	"time"
+)
+
+// pdfRenderer writes a minimal PDF 1.4 document by hand. Pages are flushed to
+// the output as soon as they are full, so only the current page is held in
+// memory. The table uses the Courier standard font so columns line up without
+// font metrics.
+const (
+	pdfPageWidth    = 595
+	pdfPageHeight   = 842
+	pdfMargin       = 40
+	pdfFontSize     = 7.5
+	pdfTitleSize    = 14
+	pdfLineHeight   = 11
+	pdfCatalogObj   = 1
+	pdfPagesObj     = 2
+	pdfBodyFontObj  = 3
+	pdfTitleFontObj = 4
+	pdfFirstFreeObj = 5
+)
+
+type countingWriter struct {
+	w       io.Writer
+	written int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.written += int64(n)
+	return n, err
+}
+
+type pdfRenderer struct {
+	out        *countingWriter
+	lang       string
+	offsets    map[int]int64
+	nextObj    int
+	pageObjs   []int
+	page       *bytes.Buffer
+	y          float64
+	pageNumber int
+}
+
+func newPDFRenderer(w io.Writer, lang string) Renderer {
+	return &pdfRenderer{
+		out:     &countingWriter{w: w},
+		lang:    lang,
+		offsets: make(map[int]int64),
+		nextObj: pdfFirstFreeObj,
+	}
+}
+
+func (r *pdfRenderer) label(key string) string {
+	return i18n.GetLabel(r.lang, key)
+}
+
+func (r *pdfRenderer) WriteHeader(header Header) error {
+	if _, err := io.WriteString(r.out, "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n"); err != nil {
+		return err
+	}
+	if err := r.writeObject(pdfBodyFontObj, "<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>"); err != nil {
+		return err
+	}
+	if err := r.writeObject(pdfTitleFontObj, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>"); err != nil {
+		return err
+	}
+
+	r.startPage()
+	r.text(pdfTitleFontObj, pdfTitleSize, r.label(i18n.LabelStatementTitle))
+	r.y -= 6
+	r.row(fmt.Sprintf("%s: %s (%s)", r.label(i18n.LabelAccount), header.Username, header.AccountId))
+	r.row(fmt.Sprintf("%s: %s - %s", r.label(i18n.LabelPeriod), header.From.UTC().Format(time.RFC3339), header.To.UTC().Format(time.RFC3339)))
+	r.row(fmt.Sprintf("%s: %s", r.label(i18n.LabelGeneratedAt), header.GeneratedAt.UTC().Format(time.RFC3339)))
+	r.row(fmt.Sprintf("%s: %s", r.label(i18n.LabelOpeningBalance), formatCents(header.OpeningBalance)))
+	r.y -= pdfLineHeight
+	r.columnHeader()
+
+	return nil
+}
+
+func (r *pdfRenderer) WriteLine(line Line) error {
+	if err := r.ensureRoom(); err != nil {
+		return err
+	}
+
+	r.row(tableRow(
+		entryTime(line.Entry).Format("2006-01-02 15:04"),
+		line.Entry.ID,
+		describe(r.lang, line.Entry),
+		formatCents(line.Entry.SignedAmountCents),
+		formatCents(line.RunningBalance),
+	))
+	return nil
+}
+
+func (r *pdfRenderer) Close(footer Footer) error {
+	if err := r.ensureRoom(); err != nil {
+		return err
+	}
+	r.y -= 4
+	r.row(tableRow("", "", r.label(i18n.LabelClosingBalance), "", formatCents(footer.ClosingBalance)))
+
+	if err := r.finishPage(); err != nil {
+		return err
+	}
+
+	kids := make([]string, len(r.pageObjs))
+	for i, obj := range r.pageObjs {
+		kids[i] = fmt.Sprintf("%d 0 R", obj)
+	}
+	if err := r.writeObject(pdfPagesObj, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids))); err != nil {
+		return err
+	}
+	if err := r.writeObject(pdfCatalogObj, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pdfPagesObj)); err != nil {
+		return err
+	}
+
+	return r.writeTrailer()
+}
+
+// ensureRoom moves to a new page when the current one is full.
+func (r *pdfRenderer) ensureRoom() error {
+	if r.y-pdfLineHeight >= pdfMargin+pdfLineHeight {
+		return nil
+	}
+	if err := r.finishPage(); err != nil {
+		return err
+	}
+	r.startPage()
+	r.columnHeader()
+	return nil
+}
+
+func (r *pdfRenderer) startPage() {
+	r.page = &bytes.Buffer{}
+	r.pageNumber++
+	r.y = pdfPageHeight - pdfMargin
+}
+
+func (r *pdfRenderer) columnHeader() {
+	r.row(tableRow(
+		r.label(i18n.LabelDate),
+		r.label(i18n.LabelTransactionId),
+		r.label(i18n.LabelDescription),
+		r.label(i18n.LabelAmount),
+		r.label(i18n.LabelRunningBalance),
+	))
+	r.row(strings.Repeat("-", 112))
+}
+
+func (r *pdfRenderer) row(content string) {
+	r.text(pdfBodyFontObj, pdfFontSize, content)
+}
+
+func (r *pdfRenderer) text(fontObj int, size float64, content string) {
+	r.y -= pdfLineHeight
+	if size > pdfFontSize {
+		r.y -= size - pdfFontSize
+	}
+	fmt.Fprintf(r.page, "BT /F%d %.1f Tf %d %.1f Td (%s) Tj ET\n", fontObj, size, pdfMargin, r.y, pdfString(content))
+}
+
+// finishPage writes the page number, then the content stream and page object
+// of the current page.
+func (r *pdfRenderer) finishPage() error {
+	fmt.Fprintf(r.page, "BT /F%d %.1f Tf %d %d Td (%s) Tj ET\n",
+		pdfBodyFontObj, pdfFontSize, pdfPageWidth-pdfMargin-60, pdfMargin/2,
+		pdfString(fmt.Sprintf("%s %d", r.label(i18n.LabelPage), r.pageNumber)))
+
+	contentObj := r.allocate()
+	content := fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", r.page.Len(), r.page.String())
+	if err := r.writeObject(contentObj, content); err != nil {
+		return err
+	}
+
+	pageObj := r.allocate()
+	page := fmt.Sprintf(
+		"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F%d %d 0 R /F%d %d 0 R >> >> /Contents %d 0 R >>",
+		pdfPagesObj, pdfPageWidth, pdfPageHeight,
+		pdfBodyFontObj, pdfBodyFontObj, pdfTitleFontObj, pdfTitleFontObj,
+		contentObj,
+	)
+	if err := r.writeObject(pageObj, page); err != nil {
+		return err
+	}
+
+	r.pageObjs = append(r.pageObjs, pageObj)
+	r.page = nil
+	return nil
+}
+
+func (r *pdfRenderer) allocate() int {
+	obj := r.nextObj
+	r.nextObj++
+	return obj
+}
+
+func (r *pdfRenderer) writeObject(obj int, body string) error {
+	r.offsets[obj] = r.out.written
+	_, err := fmt.Fprintf(r.out, "%d 0 obj\n%s\nendobj\n", obj, body)
+	return err
+}
+
+func (r *pdfRenderer) writeTrailer() error {
+	xrefOffset := r.out.written
+
+	var xref strings.Builder
+	fmt.Fprintf(&xref, "xref\n0 %d\n0000000000 65535 f \n", r.nextObj)
+	for obj := 1; obj < r.nextObj; obj++ {
+		fmt.Fprintf(&xref, "%010d 00000 n \n", r.offsets[obj])
+	}
+	fmt.Fprintf(&xref, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", r.nextObj, pdfCatalogObj, xrefOffset)
+
+	_, err := io.WriteString(r.out, xref.String())
+	return err
+}
+
+// tableRow lays out the statement columns for a fixed-width font.
+func tableRow(date, id, description, amount, balance string) string {
+	return fmt.Sprintf("%-16s  %-36s  %-24s  %14s  %14s",
+		truncate(date, 16), truncate(id, 36), truncate(description, 24), truncate(amount, 14), truncate(balance, 14))
+}
+
+func truncate(value string, width int) string {
+	runes := []rune(value)
+	if len(runes) <= width {
+		return value
+	}
+	return string(runes[:width])
+}
+
+// pdfString encodes value for a WinAnsi font inside a literal string. Latin-1
+// characters map to themselves; anything else is replaced by '?'.
+func pdfString(value string) string {
+	var encoded strings.Builder
+	for _, c := range value {
+		switch {
+		case c == '(' || c == ')' || c == '\\':
+			encoded.WriteByte('\\')
+			encoded.WriteByte(byte(c))
+		case c < 256:
+			encoded.WriteByte(byte(c))
+		default:
+			encoded.WriteByte('?')
+		}
+	}
+	return encoded.String()
+}
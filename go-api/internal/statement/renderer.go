@@ -0,0 +1,116 @@
+package statement
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"payment-gateway/go-api/internal/i18n"
+	"payment-gateway/go-api/internal/models"
+	"strings"
+	"time"
+)
+
+// Header is known before the first entry is rendered.
+type Header struct {
+	AccountId      string
+	Username       string
+	Currency       string
+	From           time.Time
+	To             time.Time
+	GeneratedAt    time.Time
+	OpeningBalance int64
+	ClosingBalance int64
+}
+
+// Line is one statement entry with the balance right after it.
+type Line struct {
+	Entry          *models.StatementEntry
+	RunningBalance int64
+}
+
+type Footer struct {
+	ClosingBalance int64
+	EntryCount     int
+	TotalCredits   int64
+	TotalDebits    int64
+}
+
+// Renderer writes a statement in one format as it is streamed: the header
+// once, every line in order, then the footer.
+type Renderer interface {
+	WriteHeader(header Header) error
+	WriteLine(line Line) error
+	Close(footer Footer) error
+}
+
+// Format describes how a statement format is served and builds its renderer.
+type Format struct {
+	ContentType string
+	Extension   string
+	New         func(w io.Writer, lang string) Renderer
+}
+
+var formats = map[string]Format{
+	"csv":     {ContentType: "text/csv; charset=utf-8", Extension: "csv", New: newCSVRenderer},
+	"jsonl":   {ContentType: "application/x-ndjson", Extension: "jsonl", New: newJSONLRenderer},
+	"pdf":     {ContentType: "application/pdf", Extension: "pdf", New: newPDFRenderer},
+	"ofx":     {ContentType: "application/x-ofx", Extension: "ofx", New: newOFXRenderer},
+	"camt053": {ContentType: "application/xml", Extension: "xml", New: newCAMT053Renderer},
+}
+
+func LookupFormat(name string) (Format, bool) {
+	format, ok := formats[name]
+	return format, ok
+}
+
+// formatCents renders an amount in cents as a decimal number, e.g. -1234.05.
+func formatCents(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// transactionRef is the identifier of a transaction in bank statement formats:
+// its UUID without dashes, which fits the 35 character ISO 20022 references and
+// stays the same every time the statement is downloaded.
+func transactionRef(transactionId string) string {
+	return strings.ReplaceAll(transactionId, "-", "")
+}
+
+// documentRef identifies a statement document. It only depends on the account
+// and the period, so downloading the same statement twice gives the same ID.
+func documentRef(header Header) string {
+	sum := sha256.Sum256([]byte(header.AccountId + "|" + header.From.UTC().Format(time.RFC3339) + "|" + header.To.UTC().Format(time.RFC3339)))
+	return hex.EncodeToString(sum[:16])
+}
+
+// xmlText escapes value for use as XML character data or attribute value.
+func xmlText(value string) string {
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(value))
+	return escaped.String()
+}
+
+// describe is the localized, human readable description of an entry.
+func describe(lang string, entry *models.StatementEntry) string {
+	description := i18n.GetLabel(lang, i18n.LabelTransactionTypes+entry.Type)
+	if entry.ReasonCode.Valid {
+		description += " (" + entry.ReasonCode.String + ")"
+	}
+	return description
+}
+
+// entryTime parses the created_at of an entry, which the database driver
+// returns in RFC3339 format.
+func entryTime(entry *models.StatementEntry) time.Time {
+	createdAt, err := time.Parse(time.RFC3339Nano, entry.CreatedAt)
+	if err != nil {
+		return time.Time{}
+	}
+	return createdAt.UTC()
+}
@@ -0,0 +1,117 @@
+package statement
+
+import (
+	"context"
+	"log"
+	"payment-gateway/go-api/internal/account"
+	"payment-gateway/go-api/internal/models"
+	"payment-gateway/go-api/internal/repository"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type StatementService interface {
+	Open(ctx context.Context, accountId string, from, to time.Time) (*Statement, error)
+}
+
+type statementServiceImpl struct {
+	repo           repository.StatementRepository
+	accountService account.AccountService
+	currency       string
+}
+
+func NewStatementService(repo repository.StatementRepository, accountService account.AccountService, currency string) *statementServiceImpl {
+	return &statementServiceImpl{repo: repo, accountService: accountService, currency: currency}
+}
+
+// Statement is an open statement: its header is known and its entries are
+// read from the same database snapshot when it is rendered. Close must be
+// called once done.
+type Statement struct {
+	Header Header
+	repo   repository.StatementRepository
+	dbTx   *sqlx.Tx
+}
+
+// Open validates the request and computes the opening and closing balances, so
+// every error a client can fix is reported before anything is streamed.
+func (s *statementServiceImpl) Open(ctx context.Context, accountId string, from, to time.Time) (*Statement, error) {
+	if !from.Before(to) {
+		return nil, ErrInvalidRange
+	}
+
+	owner, err := s.accountService.GetAccountById(ctx, accountId)
+	if err != nil {
+		return nil, err
+	}
+	if owner == nil {
+		return nil, ErrAccountNotFound
+	}
+
+	dbTx, err := s.repo.BeginSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	opening, err := s.repo.GetBalanceAt(ctx, dbTx, accountId, from)
+	if err != nil {
+		dbTx.Rollback()
+		return nil, err
+	}
+	closing, err := s.repo.GetBalanceAt(ctx, dbTx, accountId, to)
+	if err != nil {
+		dbTx.Rollback()
+		return nil, err
+	}
+
+	return &Statement{
+		Header: Header{
+			AccountId:      owner.ID,
+			Username:       owner.Username,
+			Currency:       s.currency,
+			From:           from,
+			To:             to,
+			GeneratedAt:    time.Now().UTC(),
+			OpeningBalance: opening,
+			ClosingBalance: closing,
+		},
+		repo: s.repo,
+		dbTx: dbTx,
+	}, nil
+}
+
+// Render streams the statement through renderer.
+func (st *Statement) Render(ctx context.Context, renderer Renderer) error {
+	if err := renderer.WriteHeader(st.Header); err != nil {
+		return err
+	}
+
+	footer := Footer{}
+	running := st.Header.OpeningBalance
+	err := st.repo.StreamEntries(ctx, st.dbTx, st.Header.AccountId, st.Header.From, st.Header.To, func(entry *models.StatementEntry) error {
+		running += entry.SignedAmountCents
+		footer.EntryCount++
+		if entry.SignedAmountCents >= 0 {
+			footer.TotalCredits += entry.SignedAmountCents
+		} else {
+			footer.TotalDebits -= entry.SignedAmountCents
+		}
+		return renderer.WriteLine(Line{Entry: entry, RunningBalance: running})
+	})
+	if err != nil {
+		return err
+	}
+
+	// Only a stale balance snapshot can make these differ; the entries win.
+	if running != st.Header.ClosingBalance {
+		log.Printf("Statement of account %s does not add up: closing balance %d, running balance %d", st.Header.AccountId, st.Header.ClosingBalance, running)
+	}
+
+	footer.ClosingBalance = running
+	return renderer.Close(footer)
+}
+
+func (st *Statement) Close() error {
+	return st.dbTx.Rollback()
+}
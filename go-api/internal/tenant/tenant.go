@@ -0,0 +1,64 @@
+// Package tenant carries the merchant a request acts for. Repositories read it
+// from the context and refuse to run without one, so rows of one merchant can
+// never be read or changed on behalf of another.
+package tenant
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrMissingScope is returned by repositories called with a context that was
+// never given a merchant scope.
+var ErrMissingScope = errors.New("no merchant scope in context")
+
+// Scope is the set of merchants a context may reach: a single merchant, or
+// every merchant for platform operators and background jobs.
+type Scope struct {
+	MerchantId string
+	All        bool
+}
+
+type contextKey struct{}
+
+// WithMerchant limits ctx to merchantId.
+func WithMerchant(ctx context.Context, merchantId string) context.Context {
+	return context.WithValue(ctx, contextKey{}, Scope{MerchantId: merchantId})
+}
+
+// WithAllMerchants lets ctx reach every merchant. Only platform operators and
+// jobs that work across merchants may use it.
+func WithAllMerchants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKey{}, Scope{All: true})
+}
+
+// FromContext returns the scope of ctx and whether it has one.
+func FromContext(ctx context.Context) (Scope, bool) {
+	scope, ok := ctx.Value(contextKey{}).(Scope)
+	return scope, ok && (scope.All || scope.MerchantId != "")
+}
+
+// MerchantId returns the merchant ctx is limited to. It returns false for
+// contexts reaching every merchant and contexts without a scope.
+func MerchantId(ctx context.Context) (string, bool) {
+	scope, ok := FromContext(ctx)
+	if !ok || scope.All {
+		return "", false
+	}
+	return scope.MerchantId, true
+}
+
+// Narrow limits ctx to merchantId when ctx may reach it, so platform operators
+// can act inside a single merchant. It returns false when merchantId is out of
+// reach.
+func Narrow(ctx context.Context, merchantId string) (context.Context, bool) {
+	scope, ok := FromContext(ctx)
+	switch {
+	case !ok:
+		return ctx, false
+	case scope.All:
+		return WithMerchant(ctx, merchantId), true
+	default:
+		return ctx, scope.MerchantId == merchantId
+	}
+}
@@ -0,0 +1,45 @@
+package transaction
+
+import (
+	"encoding/base64"
+	"payment-gateway/go-api/internal/models"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Cursors are opaque to clients: base64url("<created_at RFC3339Nano>|<id>").
+// Clients can still forge them, so the id is checked to be a UUID before it
+// reaches the database.
+
+var cursorValidate = validator.New()
+
+func encodeCursor(transaction *models.Transaction) (string, error) {
+	createdAt, err := time.Parse(time.RFC3339Nano, transaction.CreatedAt)
+	if err != nil {
+		return "", err
+	}
+
+	raw := createdAt.UTC().Format(time.RFC3339Nano) + "|" + transaction.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw)), nil
+}
+
+func decodeCursor(cursor string) (*models.TransactionCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	createdAtStr, id, found := strings.Cut(string(raw), "|")
+	if !found || cursorValidate.Var(id, "uuid") != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, createdAtStr)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &models.TransactionCursor{CreatedAt: createdAt, ID: id}, nil
+}
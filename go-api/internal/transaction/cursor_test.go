@@ -0,0 +1,49 @@
+package transaction
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"payment-gateway/go-api/internal/models"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tx := &models.Transaction{ID: "550e8400-e29b-41d4-a716-446655440000", CreatedAt: "2025-09-22T19:15:24.526505Z"}
+
+	cursor, err := encodeCursor(tx)
+	if err != nil {
+		t.Fatalf("encodeCursor failed: %v", err)
+	}
+	decoded, err := decodeCursor(cursor)
+	if err != nil {
+		t.Fatalf("decodeCursor failed: %v", err)
+	}
+	if decoded.ID != tx.ID || decoded.CreatedAt.Format(time.RFC3339Nano) != tx.CreatedAt {
+		t.Errorf("decodeCursor = %+v, want %s at %s", decoded, tx.ID, tx.CreatedAt)
+	}
+}
+
+func TestDecodeCursorRejectsTamperedCursors(t *testing.T) {
+	encode := func(raw string) string { return base64.RawURLEncoding.EncodeToString([]byte(raw)) }
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"not base64", "!!!"},
+		{"no separator", encode("2025-09-22T19:15:24.526505Z")},
+		{"no id", encode("2025-09-22T19:15:24.526505Z|")},
+		{"id not a uuid", encode("2025-09-22T19:15:24.526505Z|1 OR 1=1")},
+		{"bad timestamp", encode("yesterday|550e8400-e29b-41d4-a716-446655440000")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeCursor(tt.cursor); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("decodeCursor() = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
@@ -1,21 +1,47 @@
 package dto
 
+import "payment-gateway/go-api/internal/models"
+
 // @Description Request body for creating a new transaction
 type CreateTransactionRequest struct {
 	// @Description The account's ID for which the transaction will be performed (UUID).
 	AccountId string `json:"account_id" validate:"required,uuid4" example:"e7b40123-cb12-41fa-b5bc-5a128448027e"`
 
-	// @Description The credit card token (optional for some transaction types like DEPOSIT).
+	// @Description The credit card token (optional for some transaction types like DEPOSIT, not allowed for CHARGE).
 	CardToken *string `json:"card_token,omitempty" validate:"omitempty,min=20,max=126" example:"16ecac04-9e45-4a5b-b7d4-d6c1c66bafd6"`
 
 	// @Description The ID of the transaction being refunded (only for REFUND).
 	RefundTransactionId *string `json:"refund_transaction_id,omitempty" validate:"omitempty,uuid4" example:"3c2b4791-7f84-4d77-b2e0-56de8df97f33"`
 
+	// @Description The account credited by a TRANSFER (UUID, only for TRANSFER).
+	DestinationAccountId *string `json:"destination_account_id,omitempty" validate:"omitempty,uuid4" example:"0b6f3c1e-4d2a-4f7e-9a43-1f5c2d7e8b90"`
+
 	// @Description Transaction amount in cents. Must be positive.
 	AmountCents int64 `json:"amount_cents" validate:"required,gt=0" example:"10000"`
 
-	// @Description Transaction type: DEPOSIT, PURCHASE, REFUND, CHARGE
-	Type string `json:"type" validate:"required,oneof=DEPOSIT PURCHASE REFUND CHARGE" example:"PURCHASE"`
+	// @Description Transaction type: DEPOSIT, PURCHASE, REFUND, CHARGE, TRANSFER
+	Type string `json:"type" validate:"required,oneof=DEPOSIT PURCHASE REFUND CHARGE TRANSFER" example:"PURCHASE"`
+
+	// @Description Why the account is being charged. Required for CHARGE and ignored otherwise.
+	ReasonCode *string `json:"reason_code,omitempty" validate:"omitempty,oneof=SERVICE_FEE MAINTENANCE_FEE CHARGEBACK ADJUSTMENT PENALTY" example:"SERVICE_FEE"`
+
+	// @Description Merchant category code (ISO 18245) of the merchant a card transaction is made at. Checked against the card's blocked categories.
+	MerchantCategoryCode *string `json:"merchant_category_code,omitempty" validate:"omitempty,len=4,numeric" example:"5411"`
+
+	// @Description Merchant (card acceptor) a card transaction is made at. Required for purchases with a card locked to a merchant.
+	AcceptorId *string `json:"acceptor_id,omitempty" validate:"omitempty,min=1,max=64" example:"ACQ-000123"`
+
+	// @Description When false a PURCHASE only reserves the funds (AUTHORIZED) until it is captured or voided. Defaults to true.
+	Capture *bool `json:"capture,omitempty" example:"false"`
+
+	// IdempotencyKey is filled from the Idempotency-Key header by the handler.
+	IdempotencyKey string `json:"-" swaggerignore:"true"`
+}
+
+// @Description Request body for capturing an authorized purchase
+type CaptureTransactionRequest struct {
+	// @Description Amount to capture in cents. Defaults to the full authorized amount.
+	AmountCents *int64 `json:"amount_cents,omitempty" validate:"omitempty,gt=0" example:"7500"`
 }
 
 // @Description Response returned when a transaction is created or queried
@@ -26,14 +52,69 @@ type ResponseCreateTransactionRequest struct {
 	Type        string  `json:"type" example:"PURCHASE"`
 }
 
+// @Description A page of transactions. Pass next_cursor as cursor to fetch the next page.
+type ResponseTransactionPage struct {
+	Data []*models.Transaction `json:"data"`
+
+	// @Description Cursor of the next page. Null on the last page.
+	NextCursor *string `json:"next_cursor" example:"MjAyNS0xMC0wMVQxMjowMDowMFp8NTUwZTg0MDAtZTI5Yi00MWQ0LWE3MTYtNDQ2NjU1NDQwMDAw" extensions:"x-nullable"`
+
+	HasMore bool `json:"has_more" example:"true"`
+}
+
 // @Description Response for account balance
 type ResponseAccountBalance struct {
 	Id      string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
 	Balance int64  `json:"balance_cents" example:"10000"`
 }
 
+// @Description Settled balance of an account at a point in time
+type ResponseBalanceAt struct {
+	AccountId string `json:"account_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	At        string `json:"at" example:"2025-10-01T00:00:00Z"`
+	Balance   int64  `json:"balance_cents" example:"10000"`
+}
+
+// @Description Running balance of an account over time
+type ResponseBalanceHistory struct {
+	AccountId string `json:"account_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	From      string `json:"from" example:"2025-10-01T00:00:00Z"`
+	To        string `json:"to" example:"2025-10-08T00:00:00Z"`
+	Interval  string `json:"interval" example:"day"`
+
+	// @Description Balance at from, in cents.
+	OpeningBalance int64 `json:"opening_balance_cents" example:"10000"`
+
+	Points []BalanceHistoryPoint `json:"points"`
+}
+
+// @Description Balance movement of one history period
+type BalanceHistoryPoint struct {
+	PeriodStart string `json:"period_start" example:"2025-10-01T00:00:00Z"`
+
+	// @Description Net effect of the approved transactions of the period, in cents.
+	NetChange int64 `json:"net_change_cents" example:"-2500"`
+
+	// @Description Balance at the end of the period, in cents.
+	ClosingBalance int64 `json:"closing_balance_cents" example:"7500"`
+}
+
 // @Description Response when balance calculation is processing
 type ProcessingResponse struct {
 	Status  string `json:"status" example:"processing"`
 	Message string `json:"message" example:"The account balance is being calculated. Please try again later."`
 }
+
+// @Description Transaction with its refund summary. Refund fields are omitted for REFUND transactions.
+type ResponseTransactionDetails struct {
+	models.Transaction
+
+	// @Description Sum of approved refunds against this transaction, in cents.
+	RefundedCents *int64 `json:"refunded_cents,omitempty" example:"2500"`
+
+	// @Description Sum of refunds against this transaction still waiting for approval, in cents.
+	PendingRefundCents *int64 `json:"pending_refund_cents,omitempty" example:"0"`
+
+	// @Description Amount that can still be refunded, in cents. Zero unless the transaction is APPROVED.
+	RefundableCents *int64 `json:"refundable_cents,omitempty" example:"7500"`
+}
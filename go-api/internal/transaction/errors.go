@@ -0,0 +1,34 @@
+package transaction
+
+import "errors"
+
+var (
+	ErrAccountNotFound                = errors.New("account not found")
+	ErrAccountFrozen                  = errors.New("account is frozen")
+	ErrAccountClosed                  = errors.New("account is closed")
+	ErrDestinationAccountInactive     = errors.New("destination account is frozen or closed")
+	ErrRefundTransactionIdRequired    = errors.New("refund_transaction_id is required for REFUND transactions")
+	ErrOriginalTransactionNotFound    = errors.New("original transaction for refund not found")
+	ErrRefundAccountMismatch          = errors.New("original transaction belongs to another account")
+	ErrOriginalTransactionNotApproved = errors.New("original transaction is not approved")
+	ErrTransactionNotRefundable       = errors.New("original transaction cannot be refunded")
+	ErrRefundExceedsRefundableAmount  = errors.New("refund amount exceeds the refundable amount")
+	ErrDestinationAccountRequired     = errors.New("destination_account_id is required for TRANSFER transactions")
+	ErrTransferToSameAccount          = errors.New("cannot transfer to the source account")
+	ErrDestinationAccountNotFound     = errors.New("destination account not found")
+	ErrReasonCodeRequired             = errors.New("reason_code is required for CHARGE transactions")
+	ErrCardNotAllowedForCharge        = errors.New("card_token is not allowed for CHARGE transactions")
+	ErrInvalidHistoryRange            = errors.New("from must be before to")
+	ErrInvalidHistoryInterval         = errors.New("interval must be one of hour, day, week, month")
+	ErrHistoryTooManyPoints           = errors.New("balance history range has too many points")
+	ErrPaginationLimitExceeded        = errors.New("pagination limit exceeded")
+	ErrSearchRequiresOperator         = errors.New("searching across accounts requires an operator")
+	ErrAccountAccessDenied            = errors.New("caller cannot access this account")
+	ErrInvalidCursor                  = errors.New("invalid cursor")
+	ErrInvalidTransactionFilter       = errors.New("invalid transaction filter")
+	ErrCaptureOnlyForPurchase         = errors.New("capture=false is only supported for PURCHASE transactions")
+	ErrTransactionNotFound            = errors.New("transaction not found")
+	ErrTransactionNotAuthorized       = errors.New("transaction is not an open authorization")
+	ErrAuthorizationExpired           = errors.New("authorization hold has expired")
+	ErrCaptureExceedsAuthorizedAmount = errors.New("capture amount exceeds the authorized amount")
+)
@@ -1,12 +1,23 @@
 package transaction
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"payment-gateway/go-api/internal/api"
+	"payment-gateway/go-api/internal/auth"
+	"payment-gateway/go-api/internal/card"
 	"payment-gateway/go-api/internal/i18n"
+	"payment-gateway/go-api/internal/idempotency"
+	"payment-gateway/go-api/internal/models"
+	"payment-gateway/go-api/internal/repository"
 	"payment-gateway/go-api/internal/transaction/dto"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/go-redis/redis/v8"
@@ -27,17 +38,26 @@ func NewTransactionHandler(service TransactionService) *TransactionHandler {
 
 // @ID create-transaction
 // @Summary Create a new transaction
-// @Description Creates a new transaction (DEPOSIT, PURCHASE, REFUND, CHARGE) in the payment gateway.
+// @Description Creates a new transaction (DEPOSIT, PURCHASE, REFUND, CHARGE, TRANSFER) in the payment gateway.
+// @Description A CHARGE is a fee or merchant initiated debit: it takes no card_token, requires a reason_code and may overdraw the account up to the configured overdraft limit.
+// @Description A TRANSFER returns its TRANSFER_OUT leg; the TRANSFER_IN leg on the destination account is referenced by counterpart_transaction_id.
+// @Description Virtual cards only make purchases and refunds: a SINGLE_USE card declines purchases once it has one pending or approved but still takes refunds after its purchase cancelled it, and a MERCHANT_LOCKED card declines purchases at any acceptor_id other than its own or beyond its remaining amount.
+// @Description A card transaction that breaks one of the card's controls is still created, as REJECTED with a decline_reason, and moves no money.
 // @Tags transactions
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "Client generated key; retries with the same key replay the original response while the key is kept (IDEMPOTENCY_KEY_TTL). Afterwards a retry gets the transaction created with the key, or 409 when it asks for a different account, type, amount or refunded transaction"
 // @Param transaction body dto.CreateTransactionRequest true "Transaction data"
 // @Success 201 {object} dto.ResponseCreateTransactionRequest "Transaction created successfully"
 // @Header 201 {string} Location "URL of the created transaction"
+// @Header 201 {string} Idempotent-Replayed "Set to true when the response is a replay"
 // @Failure 400 {object} api.APIError "Invalid request body or validation failed"
-// @Failure 404 {object} api.APIError "Account not found"
-// @Failure 422 {object} api.APIError "Business rule violation (e.g. insufficient funds)"
+// @Failure 403 {object} api.APIError "Caller may not create this type of transaction on the account; operators need transactions:refund for a REFUND and transactions:write otherwise"
+// @Failure 404 {object} api.APIError "Account, card, destination account or original transaction not found"
+// @Failure 409 {object} api.APIError "Account or destination account frozen or closed, card blocked, cancelled or expired, or idempotency key in use by a concurrent request or by another transaction"
+// @Failure 422 {object} api.APIError "Business rule violation (e.g. refund of a non approved transaction, idempotency key reused with another payload)"
 // @Failure 500 {object} api.APIError "Internal server error"
+// @Security BearerAuth
 // @Router /transactions [post]
 // @Example request {"account_id":"e7b40123-cb12-41fa-b5bc-5a128448027e","amount_cents":10000,"type":"PURCHASE","card_token":"16ecac04-9e45-4a5b-b7d4-d6c1c66bafd6"}
 // @Example response {"account_id":"e7b40123-cb12-41fa-b5bc-5a128448027e","card_id":"16ecac04-9e45-4a5b-b7d4-d6c1c66bafd6","amount_cents":10000,"type":"PURCHASE"}
@@ -56,9 +76,21 @@ func (h *TransactionHandler) CreateTransaction(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	if !auth.HasScope(r.Context(), requiredCreateScope(r.Context(), req.Type)) {
+		auth.WriteScopeError(w, r)
+		return
+	}
+
+	if !auth.CanAccessAccount(r.Context(), req.AccountId) {
+		api.WriteError(w, http.StatusForbidden, i18n.GetErrorMessage(lang, i18n.ErrorAccountAccessDenied))
+		return
+	}
+
+	req.IdempotencyKey = r.Header.Get(idempotency.HeaderKey)
+
 	createTx, err := h.service.CreateTransaction(r.Context(), req)
 	if err != nil {
-		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorCreatingTransaction))
+		writeCreateTransactionError(w, lang, err)
 		return
 	}
 
@@ -67,39 +99,396 @@ func (h *TransactionHandler) CreateTransaction(w http.ResponseWriter, r *http.Re
 	json.NewEncoder(w).Encode(createTx)
 }
 
+// requiredCreateScope is the scope needed to create a transaction of txType.
+// Operators issue refunds under transactions:refund, which roles such as
+// FINANCE hold without being allowed to create any other transaction.
+func requiredCreateScope(ctx context.Context, txType string) string {
+	if principal := auth.FromContext(ctx); txType == models.TransactionTypeRefund && principal != nil && principal.Operator {
+		return models.ScopeTransactionsRefund
+	}
+	return models.ScopeTransactionsWrite
+}
+
+func writeCreateTransactionError(w http.ResponseWriter, lang string, err error) {
+	switch {
+	case errors.Is(err, ErrAccountNotFound):
+		api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorAccountNotFound))
+	case errors.Is(err, ErrAccountFrozen):
+		api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorAccountFrozen))
+	case errors.Is(err, ErrAccountClosed):
+		api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorAccountClosed))
+	case errors.Is(err, ErrDestinationAccountInactive):
+		api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorDestinationAccountInactive))
+	case errors.Is(err, ErrRefundTransactionIdRequired):
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorRefundTransactionIdRequired))
+	case errors.Is(err, ErrOriginalTransactionNotFound):
+		api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorOriginalTransactionNotFound))
+	case errors.Is(err, ErrRefundAccountMismatch):
+		api.WriteError(w, http.StatusUnprocessableEntity, i18n.GetErrorMessage(lang, i18n.ErrorRefundAccountMismatch))
+	case errors.Is(err, ErrOriginalTransactionNotApproved):
+		api.WriteError(w, http.StatusUnprocessableEntity, i18n.GetErrorMessage(lang, i18n.ErrorOriginalTransactionNotApproved))
+	case errors.Is(err, ErrTransactionNotRefundable):
+		api.WriteError(w, http.StatusUnprocessableEntity, i18n.GetErrorMessage(lang, i18n.ErrorTransactionNotRefundable))
+	case errors.Is(err, ErrRefundExceedsRefundableAmount):
+		api.WriteError(w, http.StatusUnprocessableEntity, i18n.GetErrorMessage(lang, i18n.ErrorRefundExceedsRefundableAmount))
+	case errors.Is(err, ErrDestinationAccountRequired):
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorDestinationAccountRequired))
+	case errors.Is(err, ErrTransferToSameAccount):
+		api.WriteError(w, http.StatusUnprocessableEntity, i18n.GetErrorMessage(lang, i18n.ErrorTransferToSameAccount))
+	case errors.Is(err, ErrDestinationAccountNotFound):
+		api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorDestinationAccountNotFound))
+	case errors.Is(err, ErrReasonCodeRequired):
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorReasonCodeRequired))
+	case errors.Is(err, ErrCardNotAllowedForCharge):
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorCardNotAllowedForCharge))
+	case errors.Is(err, card.ErrCardNotFound):
+		api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorCardNotFound))
+	case errors.Is(err, card.ErrCardBlocked):
+		api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorCardBlocked))
+	case errors.Is(err, card.ErrCardCancelled):
+		api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorCardCancelled))
+	case errors.Is(err, card.ErrCardExpired):
+		api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrorCardExpired))
+	case errors.Is(err, ErrCaptureOnlyForPurchase):
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorCaptureOnlyForPurchase))
+	case errors.Is(err, repository.ErrDuplicateKey):
+		api.WriteError(w, http.StatusConflict, i18n.GetErrorMessage(lang, i18n.ErrIdempotencyKeyExists))
+	default:
+		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorCreatingTransaction))
+	}
+}
+
 // @ID get-transactions-by-account
 // @Summary Get transactions by Account ID
-// @Description Returns a list of transactions for a specific account.
+// @Description Returns a page of transactions for a specific account, newest first by default.
 // @Tags transactions
 // @Produce json
 // @Param accountId path string true "Account ID"
-// @Success 200 {array} dto.ResponseCreateTransactionRequest
+// @Param card_id query string false "Only transactions of this card"
+// @Param limit query int false "Page size (max 100)" default(20)
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param type query string false "Comma separated transaction types, e.g. PURCHASE,REFUND"
+// @Param status query string false "Comma separated statuses, e.g. APPROVED,PENDING"
+// @Param min_amount_cents query int false "Minimum amount in cents (inclusive)"
+// @Param max_amount_cents query int false "Maximum amount in cents (inclusive)"
+// @Param created_from query string false "Created at or after (RFC3339)"
+// @Param created_to query string false "Created before (RFC3339)"
+// @Param sort query string false "Sort order" Enums(created_at_desc, created_at_asc) default(created_at_desc)
+// @Success 200 {object} dto.ResponseTransactionPage
+// @Failure 400 {object} api.APIError "Invalid filter or cursor"
 // @Failure 404 {object} api.APIError "Account not found"
 // @Failure 500 {object} api.APIError "Internal server error"
+// @Security BearerAuth
 // @Router /transactions/{accountId} [get]
-func (h *TransactionHandler) GetTransactionByAccountId(w http.ResponseWriter, r *http.Request) {}
+func (h *TransactionHandler) GetAllTransactionByAccountIdTestOrderDate(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+
+	filter, err := parseTransactionFilter(r)
+	if err != nil {
+		writeListTransactionsError(w, lang, err)
+		return
+	}
+	filter.AccountId = mux.Vars(r)["accountId"]
+	filter.CardId = r.URL.Query().Get("card_id")
+
+	h.listTransactions(w, r, lang, filter)
+}
+
+// @ID get-transactions-by-card
+// @Summary Get transactions by Card ID
+// @Description Returns a page of transactions made with a specific card, newest first by default.
+// @Tags transactions
+// @Produce json
+// @Param cardId path string true "Card ID"
+// @Param limit query int false "Page size (max 100)" default(20)
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param type query string false "Comma separated transaction types, e.g. PURCHASE,REFUND"
+// @Param status query string false "Comma separated statuses, e.g. APPROVED,PENDING"
+// @Param min_amount_cents query int false "Minimum amount in cents (inclusive)"
+// @Param max_amount_cents query int false "Maximum amount in cents (inclusive)"
+// @Param created_from query string false "Created at or after (RFC3339)"
+// @Param created_to query string false "Created before (RFC3339)"
+// @Param sort query string false "Sort order" Enums(created_at_desc, created_at_asc) default(created_at_desc)
+// @Success 200 {object} dto.ResponseTransactionPage
+// @Failure 400 {object} api.APIError "Invalid filter or cursor"
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Security BearerAuth
+// @Router /transactions/card/{cardId} [get]
+func (h *TransactionHandler) GetAllTransactionByCardId(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+
+	filter, err := parseTransactionFilter(r)
+	if err != nil {
+		writeListTransactionsError(w, lang, err)
+		return
+	}
+	filter.CardId = mux.Vars(r)["cardId"]
+
+	if err := scopeFilterToCaller(r.Context(), &filter); err != nil {
+		writeListTransactionsError(w, lang, err)
+		return
+	}
+
+	h.listTransactions(w, r, lang, filter)
+}
+
+// @ID search-transactions
+// @Summary Search transactions
+// @Description Looks up transactions by any combination of criteria, newest first by default, with the same paging envelope as the listings.
+// @Description Without account_id the search spans every account and requires an operator API key with transactions:read. Account-bound callers only see their own account.
+// @Tags transactions
+// @Produce json
+// @Param account_id query string false "Only transactions of this account"
+// @Param card_id query string false "Only transactions of this card"
+// @Param idempotency_key query string false "Part of the idempotency key (case insensitive)"
+// @Param card_last_four query string false "Last four digits of the card used"
+// @Param amount_cents query int false "Exact amount in cents"
+// @Param limit query int false "Page size (max 100)" default(20)
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param type query string false "Comma separated transaction types, e.g. PURCHASE,REFUND"
+// @Param status query string false "Comma separated statuses, e.g. APPROVED,PENDING"
+// @Param min_amount_cents query int false "Minimum amount in cents (inclusive)"
+// @Param max_amount_cents query int false "Maximum amount in cents (inclusive)"
+// @Param created_from query string false "Created at or after (RFC3339)"
+// @Param created_to query string false "Created before (RFC3339)"
+// @Param sort query string false "Sort order" Enums(created_at_desc, created_at_asc) default(created_at_desc)
+// @Success 200 {object} dto.ResponseTransactionPage
+// @Failure 400 {object} api.APIError "Invalid filter or cursor"
+// @Failure 403 {object} api.APIError "Cross-account search without operator access"
+// @Failure 404 {object} api.APIError "Account not found"
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Security BearerAuth
+// @Router /transactions/search [get]
+func (h *TransactionHandler) SearchTransactions(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+	query := r.URL.Query()
+
+	filter, err := parseTransactionFilter(r)
+	if err != nil {
+		writeListTransactionsError(w, lang, err)
+		return
+	}
+	filter.AccountId = query.Get("account_id")
+	filter.CardId = query.Get("card_id")
+	filter.IdempotencyKeyContains = query.Get("idempotency_key")
+	filter.CardLastFour = query.Get("card_last_four")
+
+	if filter.CardLastFour != "" && !isLastFour(filter.CardLastFour) {
+		writeListTransactionsError(w, lang, ErrInvalidTransactionFilter)
+		return
+	}
+
+	if value := query.Get("amount_cents"); value != "" {
+		amount, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			writeListTransactionsError(w, lang, ErrInvalidTransactionFilter)
+			return
+		}
+		filter.MinAmountCents = &amount
+		filter.MaxAmountCents = &amount
+	}
+
+	if err := scopeFilterToCaller(r.Context(), &filter); err != nil {
+		writeListTransactionsError(w, lang, err)
+		return
+	}
+
+	if filter.AccountId == "" && !auth.IsOperator(r.Context()) {
+		writeListTransactionsError(w, lang, ErrSearchRequiresOperator)
+		return
+	}
+
+	h.listTransactions(w, r, lang, filter)
+}
+
+// scopeFilterToCaller limits listings made by an account-bound caller to that
+// caller's account.
+func scopeFilterToCaller(ctx context.Context, filter *models.TransactionFilter) error {
+	accountId, ok := auth.AccountScope(ctx)
+	if !ok {
+		return nil
+	}
+	if filter.AccountId == "" {
+		filter.AccountId = accountId
+	}
+	if filter.AccountId != accountId {
+		return ErrAccountAccessDenied
+	}
+	return nil
+}
+
+func isLastFour(value string) bool {
+	if len(value) != 4 {
+		return false
+	}
+	for _, c := range value {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *TransactionHandler) listTransactions(w http.ResponseWriter, r *http.Request, lang string, filter models.TransactionFilter) {
+	page, err := h.service.ListTransactions(r.Context(), filter, r.URL.Query().Get("cursor"))
+	if err != nil {
+		writeListTransactionsError(w, lang, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(page)
+}
+
+const (
+	defaultTransactionPageSize = 20
+	maxTransactionPageSize     = 100
+)
+
+// parseTransactionFilter reads the paging, filter and sort query parameters
+// shared by the transaction listings.
+func parseTransactionFilter(r *http.Request) (models.TransactionFilter, error) {
+	query := r.URL.Query()
+	filter := models.TransactionFilter{
+		Limit: defaultTransactionPageSize,
+		Sort:  models.TransactionSortCreatedAtDesc,
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			return filter, ErrInvalidTransactionFilter
+		}
+		if limit > maxTransactionPageSize {
+			return filter, ErrPaginationLimitExceeded
+		}
+		filter.Limit = limit
+	}
+
+	if types := query.Get("type"); types != "" {
+		filter.Types = strings.Split(strings.ToUpper(types), ",")
+		for _, txType := range filter.Types {
+			if !models.IsStoredType(txType) {
+				return filter, ErrInvalidTransactionFilter
+			}
+		}
+	}
+	if statuses := query.Get("status"); statuses != "" {
+		filter.Statuses = strings.Split(strings.ToUpper(statuses), ",")
+		for _, status := range filter.Statuses {
+			if !models.IsTransactionStatus(status) {
+				return filter, ErrInvalidTransactionFilter
+			}
+		}
+	}
+
+	for name, target := range map[string]**int64{
+		"min_amount_cents": &filter.MinAmountCents,
+		"max_amount_cents": &filter.MaxAmountCents,
+	} {
+		if value := query.Get(name); value != "" {
+			amount, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return filter, ErrInvalidTransactionFilter
+			}
+			*target = &amount
+		}
+	}
+
+	for name, target := range map[string]**time.Time{
+		"created_from": &filter.CreatedFrom,
+		"created_to":   &filter.CreatedTo,
+	} {
+		if value := query.Get(name); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return filter, ErrInvalidTransactionFilter
+			}
+			*target = &parsed
+		}
+	}
+
+	if sort := query.Get("sort"); sort != "" {
+		if sort != models.TransactionSortCreatedAtDesc && sort != models.TransactionSortCreatedAtAsc {
+			return filter, ErrInvalidTransactionFilter
+		}
+		filter.Sort = sort
+	}
+
+	return filter, nil
+}
+
+func writeListTransactionsError(w http.ResponseWriter, lang string, err error) {
+	switch {
+	case errors.Is(err, ErrPaginationLimitExceeded):
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.PaginationLimitExceeded))
+	case errors.Is(err, ErrInvalidTransactionFilter):
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidTransactionFilter))
+	case errors.Is(err, ErrInvalidCursor):
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidCursor))
+	case errors.Is(err, ErrSearchRequiresOperator):
+		api.WriteError(w, http.StatusForbidden, i18n.GetErrorMessage(lang, i18n.ErrorSearchRequiresOperator))
+	case errors.Is(err, ErrAccountAccessDenied):
+		api.WriteError(w, http.StatusForbidden, i18n.GetErrorMessage(lang, i18n.ErrorAccountAccessDenied))
+	case errors.Is(err, ErrAccountNotFound):
+		api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorAccountNotFound))
+	default:
+		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorFindAllTransaction))
+	}
+}
 
 // @ID get-account-balance
 // @Summary Get Account Balance
 // @Description Retrieves the current balance for a specific account.
 // If cached → returns immediately (200).
-// If not cached → triggers background calc and returns processing (202).
+// If not cached → computes it from the database, caches it and returns it (200).
+// With async=true a cache miss triggers background calc and returns processing (202) instead.
+// With at → returns the settled balance (approved transactions only) at that instant (200).
 // @Tags accounts
 // @Produce json
 // @Param accountId path string true "Account ID"
-// @Success 200 {object} dto.ResponseAccountBalance "Balance retrieved from cache"
-// @Success 202 {object} dto.ProcessingResponse "Balance calculation triggered"
+// @Param async query bool false "Return 202 and let the processor compute the balance on a cache miss" default(false)
+// @Param at query string false "Point in time (RFC3339), e.g. 2025-10-01T00:00:00Z"
+// @Success 200 {object} dto.ResponseAccountBalance "Balance retrieved"
+// @Success 202 {object} dto.ProcessingResponse "Balance calculation triggered (async=true only)"
+// @Failure 400 {object} api.APIError "Invalid at timestamp"
 // @Failure 404 {object} api.APIError "Account not found"
 // @Failure 500 {object} api.APIError "Internal server error"
+// @Security BearerAuth
 // @Router /accounts/{accountId}/balance [get]
 func (h *TransactionHandler) GetBalanceByAccountId(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	lang := i18n.GetLangFromHeader(r)
 	vars := mux.Vars(r)
 	accountId := vars["accountId"]
-	redisKey := "balance:" + accountId
 
-	balance, err := h.service.GetBalanceFromCache(ctx, redisKey)
+	if atStr := r.URL.Query().Get("at"); atStr != "" {
+		h.getBalanceAt(w, r, lang, accountId, atStr)
+		return
+	}
+
+	if r.URL.Query().Get("async") != "true" {
+		balance, err := h.service.GetBalance(ctx, accountId)
+		if err != nil {
+			if errors.Is(err, ErrAccountNotFound) {
+				api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorAccountNotFound))
+				return
+			}
+			api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorCalculatingBalance))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]string{
+			"account_id":    accountId,
+			"balance_cents": strconv.FormatInt(balance, 10),
+		})
+		return
+	}
+
+	balance, err := h.service.GetBalanceFromCache(ctx, balanceCacheKey(accountId))
 
 	if err == redis.Nil {
 
@@ -130,69 +519,241 @@ func (h *TransactionHandler) GetBalanceByAccountId(w http.ResponseWriter, r *htt
 	})
 }
 
-// @ID get-transactions-test
-// @Summary Get All Transactions for an Account (Test)
-// @Description Retrieves a list of all transactions for an account, ordered by creation date (desc).
+// @ID get-transaction-by-id
+// @Summary Get transaction by ID
+// @Description Returns a transaction together with how much of it has been refunded and how much can still be refunded.
 // @Tags transactions
 // @Produce json
-// @Param accountId path string true "Account ID"
-// @Success 200 {array} dto.ResponseCreateTransactionRequest
-// @Failure 404 {object} api.APIError "Account not found"
+// @Param transactionId path string true "Transaction ID"
+// @Success 200 {object} dto.ResponseTransactionDetails
+// @Failure 404 {object} api.APIError "Transaction not found"
 // @Failure 500 {object} api.APIError "Internal server error"
-// @Router /transactions/test/{accountId} [get]
-func (h *TransactionHandler) GetAllTransactionByAccountIdTestOrderDate(w http.ResponseWriter, r *http.Request) {
+// @Security BearerAuth
+// @Router /transactions/id/{transactionId} [get]
+func (h *TransactionHandler) FindTransactionById(w http.ResponseWriter, r *http.Request) {
 	lang := i18n.GetLangFromHeader(r)
 
 	vars := mux.Vars(r)
-	accountId := vars["accountId"]
+	transactionId := vars["transactionId"]
 
-	transactions, err := h.service.GetAllTransactionsByAccountId(r.Context(), accountId)
-	fmt.Print("teste")
+	transaction, err := h.service.FindTransactionById(r.Context(), transactionId)
 	if err != nil {
-		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorFindAllTransaction))
+		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorFindTransactionById))
+		return
+	}
+	if transaction == nil {
+		api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorTransactionNotFound))
+		return
+	}
+	if !auth.CanAccessAccount(r.Context(), transaction.AccountId) {
+		api.WriteError(w, http.StatusForbidden, i18n.GetErrorMessage(lang, i18n.ErrorAccountAccessDenied))
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(transactions)
-
+	json.NewEncoder(w).Encode(transaction)
 }
 
-func (h *TransactionHandler) GetAllTransactionByCardId(w http.ResponseWriter, r *http.Request) {
+// @ID capture-transaction
+// @Summary Capture an authorized purchase
+// @Description Settles a PURCHASE created with capture=false. Capturing less than the authorized amount releases the remainder of the hold.
+// @Tags transactions
+// @Accept json
+// @Produce json
+// @Param transactionId path string true "Transaction ID"
+// @Param Idempotency-Key header string false "Client generated key; retries with the same key replay the original response"
+// @Param capture body dto.CaptureTransactionRequest false "Amount to capture"
+// @Success 200 {object} models.Transaction "Authorization captured"
+// @Failure 400 {object} api.APIError "Invalid request body"
+// @Failure 404 {object} api.APIError "Transaction not found"
+// @Failure 422 {object} api.APIError "Transaction is not an open authorization, hold expired or amount above the authorized amount"
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Security BearerAuth
+// @Router /transactions/{transactionId}/capture [post]
+func (h *TransactionHandler) CaptureTransaction(w http.ResponseWriter, r *http.Request) {
 	lang := i18n.GetLangFromHeader(r)
+	transactionId := mux.Vars(r)["transactionId"]
 
-	vars := mux.Vars(r)
-	cardId := vars["cardId"]
+	var req dto.CaptureTransactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidRequestBody))
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidRequestBody))
+		return
+	}
 
-	transactions, err := h.service.GetAllTransactionsByCardId(r.Context(), cardId)
+	if !h.canAccessTransaction(w, r, lang, transactionId) {
+		return
+	}
+
+	transaction, err := h.service.CaptureTransaction(r.Context(), transactionId, req)
 	if err != nil {
-		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorFindAllTransaction))
+		writeAuthorizationError(w, lang, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(transactions)
+	json.NewEncoder(w).Encode(transaction)
 }
 
-func (h *TransactionHandler) FindTransactionById(w http.ResponseWriter, r *http.Request) {
+// @ID void-transaction
+// @Summary Void an authorized purchase
+// @Description Releases the hold of a PURCHASE created with capture=false without capturing any amount.
+// @Tags transactions
+// @Produce json
+// @Param transactionId path string true "Transaction ID"
+// @Param Idempotency-Key header string false "Client generated key; retries with the same key replay the original response"
+// @Success 200 {object} models.Transaction "Authorization voided"
+// @Failure 404 {object} api.APIError "Transaction not found"
+// @Failure 422 {object} api.APIError "Transaction is not an open authorization"
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Security BearerAuth
+// @Router /transactions/{transactionId}/void [post]
+func (h *TransactionHandler) VoidTransaction(w http.ResponseWriter, r *http.Request) {
 	lang := i18n.GetLangFromHeader(r)
+	transactionId := mux.Vars(r)["transactionId"]
 
-	vars := mux.Vars(r)
-	transactionId := vars["transactionId"]
+	if !h.canAccessTransaction(w, r, lang, transactionId) {
+		return
+	}
+
+	transaction, err := h.service.VoidTransaction(r.Context(), transactionId)
+	if err != nil {
+		writeAuthorizationError(w, lang, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(transaction)
+}
 
+// canAccessTransaction writes a 403 and returns false when the transaction
+// belongs to an account the caller cannot access. Unknown transactions are let
+// through so the service reports them as not found.
+func (h *TransactionHandler) canAccessTransaction(w http.ResponseWriter, r *http.Request, lang, transactionId string) bool {
 	transaction, err := h.service.FindTransactionById(r.Context(), transactionId)
 	if err != nil {
 		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorFindTransactionById))
+		return false
+	}
+	if transaction != nil && !auth.CanAccessAccount(r.Context(), transaction.AccountId) {
+		api.WriteError(w, http.StatusForbidden, i18n.GetErrorMessage(lang, i18n.ErrorAccountAccessDenied))
+		return false
+	}
+	return true
+}
+
+func (h *TransactionHandler) getBalanceAt(w http.ResponseWriter, r *http.Request, lang, accountId, atStr string) {
+	at, err := time.Parse(time.RFC3339, atStr)
+	if err != nil {
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidTimestamp))
 		return
 	}
-	if transaction == nil {
-		api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorTransactionNotFound))
+
+	balance, err := h.service.GetBalanceAt(r.Context(), accountId, at)
+	if err != nil {
+		writeBalanceError(w, lang, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(transaction)
+	json.NewEncoder(w).Encode(dto.ResponseBalanceAt{
+		AccountId: accountId,
+		At:        at.UTC().Format(time.RFC3339),
+		Balance:   balance,
+	})
+}
+
+// @ID get-account-balance-history
+// @Summary Get Account Balance History
+// @Description Returns the running balance of an account between from and to, one point per interval period (UTC).
+// @Description Balances are settled balances: only APPROVED transactions count, by creation time.
+// @Tags accounts
+// @Produce json
+// @Param accountId path string true "Account ID"
+// @Param from query string false "Start of the range (RFC3339). Defaults to 30 days before to."
+// @Param to query string false "End of the range, exclusive (RFC3339). Defaults to now."
+// @Param interval query string false "Period length" Enums(hour, day, week, month) default(day)
+// @Success 200 {object} dto.ResponseBalanceHistory
+// @Failure 400 {object} api.APIError "Invalid range or interval"
+// @Failure 404 {object} api.APIError "Account not found"
+// @Failure 500 {object} api.APIError "Internal server error"
+// @Security BearerAuth
+// @Router /accounts/{accountId}/balance/history [get]
+func (h *TransactionHandler) GetBalanceHistory(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLangFromHeader(r)
+	accountId := mux.Vars(r)["accountId"]
+	query := r.URL.Query()
+
+	to := time.Now().UTC()
+	if toStr := query.Get("to"); toStr != "" {
+		parsed, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidTimestamp))
+			return
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -30)
+	if fromStr := query.Get("from"); fromStr != "" {
+		parsed, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidTimestamp))
+			return
+		}
+		from = parsed
+	}
+
+	interval := query.Get("interval")
+	if interval == "" {
+		interval = models.BalanceIntervalDay
+	}
+
+	history, err := h.service.GetBalanceHistory(r.Context(), accountId, from, to, interval)
+	if err != nil {
+		writeBalanceError(w, lang, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(history)
+}
+
+func writeBalanceError(w http.ResponseWriter, lang string, err error) {
+	switch {
+	case errors.Is(err, ErrAccountNotFound):
+		api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorAccountNotFound))
+	case errors.Is(err, ErrInvalidHistoryRange):
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidHistoryRange))
+	case errors.Is(err, ErrInvalidHistoryInterval):
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorInvalidHistoryInterval))
+	case errors.Is(err, ErrHistoryTooManyPoints):
+		api.WriteError(w, http.StatusBadRequest, i18n.GetErrorMessage(lang, i18n.ErrorHistoryTooManyPoints))
+	default:
+		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorCalculatingBalance))
+	}
+}
+
+func writeAuthorizationError(w http.ResponseWriter, lang string, err error) {
+	switch {
+	case errors.Is(err, ErrTransactionNotFound):
+		api.WriteError(w, http.StatusNotFound, i18n.GetErrorMessage(lang, i18n.ErrorTransactionNotFound))
+	case errors.Is(err, ErrTransactionNotAuthorized):
+		api.WriteError(w, http.StatusUnprocessableEntity, i18n.GetErrorMessage(lang, i18n.ErrorTransactionNotAuthorized))
+	case errors.Is(err, ErrAuthorizationExpired):
+		api.WriteError(w, http.StatusUnprocessableEntity, i18n.GetErrorMessage(lang, i18n.ErrorAuthorizationExpired))
+	case errors.Is(err, ErrCaptureExceedsAuthorizedAmount):
+		api.WriteError(w, http.StatusUnprocessableEntity, i18n.GetErrorMessage(lang, i18n.ErrorCaptureExceedsAuthorizedAmount))
+	default:
+		api.WriteError(w, http.StatusInternalServerError, i18n.GetErrorMessage(lang, i18n.ErrorInternalServerError))
+	}
 }
@@ -4,21 +4,26 @@ import (
 	"payment-gateway/go-api/internal/account"
 	"payment-gateway/go-api/internal/card"
 	"payment-gateway/go-api/internal/connection"
+	"payment-gateway/go-api/internal/ledger"
 	"payment-gateway/go-api/internal/repository"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 )
 
 type Module struct {
 	Handler *TransactionHandler
+	Service TransactionService
 }
 
-func NewModule(db *sqlx.DB, accountService account.AccountService, mqClient connection.RabbitMQClient, cardService card.CardService, redis connection.RedisConnection) *Module {
+func NewModule(db *sqlx.DB, accountService account.AccountService, mqClient connection.RabbitMQClient, cardService card.CardService, ledgerService ledger.LedgerService, redis connection.RedisConnection, holdTTL time.Duration) *Module {
 	repo := repository.NewTransactionRepository(db)
-	service := NewTransactionService(repo, accountService, mqClient, cardService, redis)
+	outboxRepo := repository.NewOutboxRepository(db)
+	service := NewTransactionService(repo, outboxRepo, accountService, mqClient, cardService, ledgerService, redis, holdTTL)
 	handler := NewTransactionHandler(service)
 
 	return &Module{
 		Handler: handler,
+		Service: service,
 	}
 }
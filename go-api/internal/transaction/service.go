@@ -2,62 +2,115 @@ package transaction
 
 import (
 	"context"
+	"crypto/rand"
 	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"log"
 	"payment-gateway/go-api/internal/account"
 	"payment-gateway/go-api/internal/card"
 	"payment-gateway/go-api/internal/connection"
+	"payment-gateway/go-api/internal/ledger"
 
 	"payment-gateway/go-api/internal/models"
 	"payment-gateway/go-api/internal/repository"
 	"payment-gateway/go-api/internal/transaction/dto"
+	"slices"
 	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
 )
 
 type TransactionService interface {
 	CreateTransaction(ctx context.Context, tx dto.CreateTransactionRequest) (*models.Transaction, error)
 	GetBalanceByAccountId(ctx context.Context, accountId string) error
 	GetBalanceFromCache(ctx context.Context, key string) (string, error)
-	GetAllTransactionsByAccountId(ctx context.Context, accountId string) ([]*models.Transaction, error)
-	GetAllTransactionsByCardId(ctx context.Context, cardId string) ([]*models.Transaction, error)
-	FindTransactionById(ctx context.Context, transactionId string) (*models.Transaction, error)
+	GetBalance(ctx context.Context, accountId string) (int64, error)
+	GetBalanceAt(ctx context.Context, accountId string, at time.Time) (int64, error)
+	GetBalanceHistory(ctx context.Context, accountId string, from, to time.Time, interval string) (*dto.ResponseBalanceHistory, error)
+	RefreshBalanceSnapshots(ctx context.Context) (int64, error)
+	ListTransactions(ctx context.Context, filter models.TransactionFilter, cursor string) (*dto.ResponseTransactionPage, error)
+	FindTransactionById(ctx context.Context, transactionId string) (*dto.ResponseTransactionDetails, error)
+	CaptureTransaction(ctx context.Context, transactionId string, req dto.CaptureTransactionRequest) (*models.Transaction, error)
+	VoidTransaction(ctx context.Context, transactionId string) (*models.Transaction, error)
+	ExpireAuthorizationHolds(ctx context.Context) (int, error)
+}
+
+const (
+	transactionsQueue     = "transactions_queue"
+	calculateBalanceQueue = "calculate_balance_queue"
+)
+
+// balanceCacheTTL matches the expiration used by the processor for balance:{accountId}.
+const balanceCacheTTL = 24 * time.Hour
+
+// maxBalanceHistoryPoints bounds the number of periods a history request can span.
+const maxBalanceHistoryPoints = 1000
+
+// approximate period lengths, only used to bound history requests.
+var balanceIntervalLengths = map[string]time.Duration{
+	models.BalanceIntervalHour:  time.Hour,
+	models.BalanceIntervalDay:   24 * time.Hour,
+	models.BalanceIntervalWeek:  7 * 24 * time.Hour,
+	models.BalanceIntervalMonth: 28 * 24 * time.Hour,
+}
+
+func balanceCacheKey(accountId string) string {
+	return "balance:" + accountId
 }
 
 type transactionServiceImpl struct {
 	repo           repository.TransactionRepository
+	outboxRepo     repository.OutboxRepository
 	accountService account.AccountService
 	cardService    card.CardService
+	ledgerService  ledger.LedgerService
 	mqClient       connection.RabbitMQClient
 	redis          connection.RedisConnection
+	holdTTL        time.Duration
 }
 
-func NewTransactionService(repo repository.TransactionRepository, service account.AccountService, mqClient connection.RabbitMQClient, cardService card.CardService, redis connection.RedisConnection) *transactionServiceImpl {
-	return &transactionServiceImpl{repo: repo, accountService: service, mqClient: mqClient, cardService: cardService, redis: redis}
+func NewTransactionService(repo repository.TransactionRepository, outboxRepo repository.OutboxRepository, service account.AccountService, mqClient connection.RabbitMQClient, cardService card.CardService, ledgerService ledger.LedgerService, redis connection.RedisConnection, holdTTL time.Duration) *transactionServiceImpl {
+	return &transactionServiceImpl{repo: repo, outboxRepo: outboxRepo, accountService: service, mqClient: mqClient, cardService: cardService, ledgerService: ledgerService, redis: redis, holdTTL: holdTTL}
 }
 
 func (s *transactionServiceImpl) CreateTransaction(ctx context.Context, req dto.CreateTransactionRequest) (*models.Transaction, error) {
-	timePrefix := time.Now().Format("2006-01-02-15:04:05.000")
-	idempotencyKey := fmt.Sprintf(
-		"%s:%s:%s:%d",
-		timePrefix,
-		req.AccountId,
-		req.Type,
-		req.AmountCents,
-	)
-
-	existingTx, err := s.repo.FindMostRecentTransaction(ctx, req.AccountId, req.Type, req.AmountCents)
-	if err != nil {
-		return nil, err
+	authorizeOnly := req.Capture != nil && !*req.Capture
+	if authorizeOnly && req.Type != models.TransactionTypePurchase {
+		return nil, ErrCaptureOnlyForPurchase
 	}
 
-	var parsedTime time.Time
+	if req.Type == models.TransactionTypeCharge {
+		if err := validateCharge(req); err != nil {
+			return nil, err
+		}
+	}
 
-	if existingTx != nil {
-		parsedTime, _ = time.Parse(time.RFC3339, existingTx.CreatedAt)
+	// The idempotency middleware replays retries only while the key is kept,
+	// but a transaction holds its key for good. A retry after the key expired
+	// gets the transaction it created, unless it asks for a different one.
+	if req.IdempotencyKey != "" {
+		existing, err := s.repo.GetTransactionByIdempotencyKey(ctx, req.IdempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			if !createdBy(existing, req) {
+				return nil, fmt.Errorf("idempotency key %q is taken: %w", req.IdempotencyKey, repository.ErrDuplicateKey)
+			}
+			return existing, nil
+		}
 	}
-	if (time.Since(parsedTime) <= 3*time.Minute) && existingTx != nil {
-		return existingTx, nil
+
+	idempotencyKey := req.IdempotencyKey
+	if idempotencyKey == "" {
+		generatedKey, err := generateIdempotencyKey(req)
+		if err != nil {
+			return nil, err
+		}
+		idempotencyKey = generatedKey
 	}
 
 	tx, err := s.repo.BeginTx(ctx)
@@ -70,20 +123,37 @@ func (s *transactionServiceImpl) CreateTransaction(ctx context.Context, req dto.
 	if err != nil {
 		return nil, err
 	}
-	var cardId string
-
+	if account == nil {
+		return nil, ErrAccountNotFound
+	}
+	if err := checkAccountActive(account); err != nil {
+		return nil, err
+	}
+	var card *models.Card
 	if req.CardToken != nil {
-		cardIsReturn, err := s.cardService.GetCardByTokenAndAccountId(ctx, *req.CardToken, req.AccountId)
+		if req.Type == models.TransactionTypeRefund {
+			card, err = s.cardService.GetRefundCardByTokenAndAccountId(ctx, *req.CardToken, req.AccountId)
+		} else {
+			card, err = s.cardService.GetCardByTokenAndAccountId(ctx, *req.CardToken, req.AccountId)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var original *models.Transaction
+	if req.Type == models.TransactionTypeRefund {
+		original, err = s.validateRefund(ctx, tx, req)
 		if err != nil {
 			return nil, err
 		}
-		cardId = cardIsReturn
 	}
 
-	if req.Type == "REFUND" {
-		_, err = s.repo.GetTransactionByID(ctx, *req.RefundTransactionId)
+	var destinationAccountId string
+	if req.Type == models.TransactionTypeTransfer {
+		destinationAccountId, err = s.validateTransfer(ctx, req, account)
 		if err != nil {
-			return nil, fmt.Errorf("original transaction for refund not found: %w", err)
+			return nil, err
 		}
 	}
 
@@ -95,24 +165,63 @@ func (s *transactionServiceImpl) CreateTransaction(ctx context.Context, req dto.
 		Type:                req.Type,
 		IdempotencyKey:      idempotencyKey,
 	}
-	if cardId != "" {
-		transaction.CardId = sql.NullString{String: cardId, Valid: true}
+	if card != nil {
+		transaction.CardId = sql.NullString{String: card.ID, Valid: true}
+	}
+	if req.MerchantCategoryCode != nil {
+		transaction.MerchantCategoryCode = sql.NullString{String: *req.MerchantCategoryCode, Valid: true}
+	}
+	if req.AcceptorId != nil {
+		transaction.AcceptorId = sql.NullString{String: *req.AcceptorId, Valid: true}
 	}
-	if req.RefundTransactionId != nil && req.Type == "REFUND" {
+	if req.RefundTransactionId != nil && req.Type == models.TransactionTypeRefund {
 		transaction.RefundTransactionId = sql.NullString{String: *req.RefundTransactionId, Valid: true}
 	}
 
-	if err := s.repo.CreateTransaction(ctx, transaction); err != nil {
+	if req.Type == models.TransactionTypeTransfer {
+		transaction.Type = models.TransactionTypeTransferOut
+	}
+	if req.Type == models.TransactionTypeCharge {
+		transaction.ReasonCode = sql.NullString{String: *req.ReasonCode, Valid: true}
+	}
+	if authorizeOnly {
+		transaction.AuthorizedAmountCents = sql.NullInt64{Int64: req.AmountCents, Valid: true}
+		transaction.HoldExpiresAt = sql.NullString{String: time.Now().UTC().Add(s.holdTTL).Format(time.RFC3339Nano), Valid: true}
+	}
+
+	if card != nil {
+		declineReason, err := s.checkCardKind(ctx, tx, card, req)
+		if err != nil {
+			return nil, err
+		}
+		if declineReason == "" {
+			declineReason, err = s.checkCardControls(ctx, tx, card, req)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if declineReason != "" {
+			return s.declineTransaction(ctx, tx, transaction, declineReason)
+		}
+	}
+
+	if err := s.repo.CreateTransaction(ctx, tx, transaction); err != nil {
 		return nil, fmt.Errorf("fail to create transaction: %w", err)
 	}
 
-	message, err := json.Marshal(transaction)
-	if err != nil {
-		return nil, fmt.Errorf("failed to serialize transaction for queue: %w", err)
+	if req.Type == models.TransactionTypeTransfer {
+		if err := s.createTransferCreditLeg(ctx, tx, transaction, destinationAccountId); err != nil {
+			return nil, err
+		}
+		if err := s.ledgerService.RecordTransfer(ctx, tx, transaction, destinationAccountId); err != nil {
+			return nil, err
+		}
+	} else if err := s.ledgerService.RecordTransaction(ctx, tx, transaction, original); err != nil {
+		return nil, err
 	}
 
-	if err := s.mqClient.Publish(ctx, "transactions_queue", message); err != nil {
-		return nil, fmt.Errorf("failed to publish message to RabbitMQ: %w", err)
+	if err := s.enqueueTransaction(ctx, tx, transaction); err != nil {
+		return nil, err
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -122,10 +231,447 @@ func (s *transactionServiceImpl) CreateTransaction(ctx context.Context, req dto.
 	return transaction, nil
 }
 
+// checkCardKind evaluates the constraints of a virtual card against the
+// transaction requested and returns the reason it is declined, or "" when it
+// may proceed. Virtual cards only make purchases and take their refunds, which
+// a SINGLE_USE card still takes once its purchase cancelled it. The card stays
+// locked until dbTx ends, so concurrent purchases see each other's usage.
+func (s *transactionServiceImpl) checkCardKind(ctx context.Context, dbTx *sqlx.Tx, card *models.Card, req dto.CreateTransactionRequest) (string, error) {
+	if card.Kind == models.CardKindStandard {
+		return "", nil
+	}
+	if req.Type != models.TransactionTypePurchase && req.Type != models.TransactionTypeRefund {
+		return models.DeclineReasonTransactionTypeNotAllowed, nil
+	}
+	if req.Type != models.TransactionTypePurchase {
+		return "", nil
+	}
+
+	card, err := s.cardService.GetCardForUpdate(ctx, dbTx, card.ID)
+	if err != nil {
+		return "", err
+	}
+
+	if card.RemainingUses.Valid && card.RemainingUses.Int64 <= 0 {
+		return models.DeclineReasonSingleUseCardUsed, nil
+	}
+	if card.LockedAcceptorId.Valid && (req.AcceptorId == nil || *req.AcceptorId != card.LockedAcceptorId.String) {
+		return models.DeclineReasonAcceptorNotAllowed, nil
+	}
+	if card.RemainingAmountCents.Valid && req.AmountCents > card.RemainingAmountCents.Int64 {
+		return models.DeclineReasonAmountLimitExceeded, nil
+	}
+
+	return "", nil
+}
+
+// checkCardControls evaluates the controls of card against the transaction
+// requested and returns the reason it is declined, or "" when it may proceed.
+// The controls stay locked until dbTx ends, so concurrent transactions on the
+// card cannot both fit under a spend cap.
+func (s *transactionServiceImpl) checkCardControls(ctx context.Context, dbTx *sqlx.Tx, card *models.Card, req dto.CreateTransactionRequest) (string, error) {
+	controls, err := s.cardService.GetCardControlsForUpdate(ctx, dbTx, card.ID)
+	if err != nil {
+		return "", err
+	}
+	if controls == nil {
+		return "", nil
+	}
+
+	if len(controls.AllowedTransactionTypes) > 0 && !slices.Contains(controls.AllowedTransactionTypes, req.Type) {
+		return models.DeclineReasonTransactionTypeNotAllowed, nil
+	}
+	if req.MerchantCategoryCode != nil && slices.Contains(controls.BlockedMerchantCategories, *req.MerchantCategoryCode) {
+		return models.DeclineReasonMerchantCategoryBlocked, nil
+	}
+
+	if req.Type == models.TransactionTypePurchase || req.Type == models.TransactionTypeTransfer {
+		if controls.MaxTransactionCents.Valid && req.AmountCents > controls.MaxTransactionCents.Int64 {
+			return models.DeclineReasonMaxTransactionExceeded, nil
+		}
+
+		if controls.DailyLimitCents.Valid || controls.MonthlyLimitCents.Valid {
+			now := time.Now().UTC()
+			dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+			monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+			spend, err := s.repo.GetCardSpend(ctx, dbTx, card.ID, dayStart, monthStart)
+			if err != nil {
+				return "", err
+			}
+			if controls.DailyLimitCents.Valid && spend.DailyCents+req.AmountCents > controls.DailyLimitCents.Int64 {
+				return models.DeclineReasonDailyLimitExceeded, nil
+			}
+			if controls.MonthlyLimitCents.Valid && spend.MonthlyCents+req.AmountCents > controls.MonthlyLimitCents.Int64 {
+				return models.DeclineReasonMonthlyLimitExceeded, nil
+			}
+		}
+	}
+
+	// Velocity is checked last: every transaction that reaches it counts
+	// towards the window, whether it is then declined or not.
+	if controls.VelocityLimit.Valid && controls.VelocityWindowSeconds.Valid {
+		window := time.Duration(controls.VelocityWindowSeconds.Int64) * time.Second
+		if s.cardVelocity(ctx, card.ID, window) > controls.VelocityLimit.Int64 {
+			return models.DeclineReasonVelocityLimitExceeded, nil
+		}
+	}
+
+	return "", nil
+}
+
+// cardVelocity counts a transaction on cardId in the current fixed window of
+// length window and returns the count so far. Counting fails open: when Redis
+// is unavailable the transaction is not declined for velocity.
+func (s *transactionServiceImpl) cardVelocity(ctx context.Context, cardId string, window time.Duration) int64 {
+	key := cardVelocityKey(cardId, window)
+
+	var count *redis.IntCmd
+	_, err := s.redis.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		count = pipe.Incr(ctx, key)
+		pipe.ExpireNX(ctx, key, window)
+		return nil
+	})
+	if err != nil {
+		log.Printf("Failed to count velocity of card %s: %v", cardId, err)
+		return 0
+	}
+
+	return count.Val()
+}
+
+func cardVelocityKey(cardId string, window time.Duration) string {
+	return fmt.Sprintf("velocity:card:%s:%d", cardId, int64(window.Seconds()))
+}
+
+// declineTransaction stores transaction as REJECTED with reason. A declined
+// transaction moves no money: it gets no journal entry and is not sent to the
+// processor.
+func (s *transactionServiceImpl) declineTransaction(ctx context.Context, dbTx *sqlx.Tx, transaction *models.Transaction, reason string) (*models.Transaction, error) {
+	transaction.Status = models.TransactionStatusRejected
+	transaction.DeclineReason = sql.NullString{String: reason, Valid: true}
+	transaction.AuthorizedAmountCents = sql.NullInt64{}
+	transaction.HoldExpiresAt = sql.NullString{}
+
+	if err := s.repo.CreateTransaction(ctx, dbTx, transaction); err != nil {
+		return nil, fmt.Errorf("fail to create transaction: %w", err)
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit database transaction: %w", err)
+	}
+
+	return transaction, nil
+}
+
+// validateRefund checks that the transaction being refunded exists, belongs to
+// the same account, has been approved, is itself refundable and still has
+// enough refundable amount left, and returns it. The original row stays locked
+// until dbTx ends so concurrent partial refunds cannot exceed it.
+func (s *transactionServiceImpl) validateRefund(ctx context.Context, dbTx *sqlx.Tx, req dto.CreateTransactionRequest) (*models.Transaction, error) {
+	if req.RefundTransactionId == nil {
+		return nil, ErrRefundTransactionIdRequired
+	}
+
+	original, err := s.repo.GetTransactionByIDForUpdate(ctx, dbTx, *req.RefundTransactionId)
+	if err != nil {
+		return nil, err
+	}
+	if original == nil {
+		return nil, ErrOriginalTransactionNotFound
+	}
+
+	if original.AccountId != req.AccountId {
+		return nil, ErrRefundAccountMismatch
+	}
+
+	if original.Status != models.TransactionStatusApproved {
+		return nil, ErrOriginalTransactionNotApproved
+	}
+
+	if !models.IsRefundableType(original.Type) {
+		return nil, ErrTransactionNotRefundable
+	}
+
+	totals, err := s.repo.GetRefundTotals(ctx, original.ID)
+	if err != nil {
+		return nil, err
+	}
+	if req.AmountCents > totals.RefundableCents(original.AmountCents) {
+		return nil, ErrRefundExceedsRefundableAmount
+	}
+
+	return original, nil
+}
+
+// validateCharge enforces the CHARGE specific rules: charges are initiated by
+// the platform, so they never reference a card and must explain themselves.
+func validateCharge(req dto.CreateTransactionRequest) error {
+	if req.CardToken != nil {
+		return ErrCardNotAllowedForCharge
+	}
+
+	if req.ReasonCode == nil {
+		return ErrReasonCodeRequired
+	}
+
+	return nil
+}
+
+// checkAccountActive rejects new transactions on frozen and closed accounts.
+func checkAccountActive(account *models.Account) error {
+	switch account.Status {
+	case models.AccountStatusFrozen:
+		return ErrAccountFrozen
+	case models.AccountStatusClosed:
+		return ErrAccountClosed
+	}
+	return nil
+}
+
+// validateTransfer checks the destination of a TRANSFER from source and returns
+// its account ID. Money never moves between merchants: a destination of another
+// merchant is reported as not found, even to platform operators.
+func (s *transactionServiceImpl) validateTransfer(ctx context.Context, req dto.CreateTransactionRequest, source *models.Account) (string, error) {
+	if req.DestinationAccountId == nil {
+		return "", ErrDestinationAccountRequired
+	}
+
+	if *req.DestinationAccountId == req.AccountId {
+		return "", ErrTransferToSameAccount
+	}
+
+	destination, err := s.accountService.GetAccountById(ctx, *req.DestinationAccountId)
+	if err != nil {
+		return "", err
+	}
+	if destination == nil || destination.MerchantId != source.MerchantId {
+		return "", ErrDestinationAccountNotFound
+	}
+	if destination.Status != models.AccountStatusActive {
+		return "", ErrDestinationAccountInactive
+	}
+
+	return destination.ID, nil
+}
+
+// createTransferCreditLeg writes the TRANSFER_IN leg matching debit on the
+// destination account and links both legs to each other. Only the debit leg is
+// queued: the processor approves or rejects both legs together.
+func (s *transactionServiceImpl) createTransferCreditLeg(ctx context.Context, dbTx *sqlx.Tx, debit *models.Transaction, destinationAccountId string) error {
+	credit := &models.Transaction{
+		AccountId:                destinationAccountId,
+		CounterpartTransactionId: sql.NullString{String: debit.ID, Valid: true},
+		AmountCents:              debit.AmountCents,
+		Type:                     models.TransactionTypeTransferIn,
+		IdempotencyKey:           debit.IdempotencyKey + ":in",
+	}
+
+	if err := s.repo.CreateTransaction(ctx, dbTx, credit); err != nil {
+		return fmt.Errorf("fail to create transfer credit leg: %w", err)
+	}
+
+	if err := s.repo.SetCounterpartTransactionId(ctx, dbTx, debit.ID, credit.ID); err != nil {
+		return err
+	}
+	debit.CounterpartTransactionId = sql.NullString{String: credit.ID, Valid: true}
+
+	return nil
+}
+
+// enqueueTransaction writes the queue message for transaction to the outbox in
+// the same database transaction, so the row and its message commit together.
+func (s *transactionServiceImpl) enqueueTransaction(ctx context.Context, dbTx *sqlx.Tx, transaction *models.Transaction) error {
+	message, err := json.Marshal(transaction)
+	if err != nil {
+		return fmt.Errorf("failed to serialize transaction for queue: %w", err)
+	}
+
+	outboxMessage := &models.OutboxMessage{
+		AggregateId: transaction.ID,
+		QueueName:   transactionsQueue,
+		Payload:     message,
+	}
+	if err := s.outboxRepo.Enqueue(ctx, dbTx, outboxMessage); err != nil {
+		return fmt.Errorf("failed to enqueue transaction message: %w", err)
+	}
+
+	return nil
+}
+
+// enqueueBalanceRecalculation asks the processor to refresh the cached balance
+// of accountId once dbTx commits.
+func (s *transactionServiceImpl) enqueueBalanceRecalculation(ctx context.Context, dbTx *sqlx.Tx, accountId string) error {
+	message, err := json.Marshal(map[string]string{"account_id": accountId})
+	if err != nil {
+		return fmt.Errorf("failed to serialize message for queue: %w", err)
+	}
+
+	outboxMessage := &models.OutboxMessage{
+		AggregateId: accountId,
+		QueueName:   calculateBalanceQueue,
+		Payload:     message,
+	}
+	if err := s.outboxRepo.Enqueue(ctx, dbTx, outboxMessage); err != nil {
+		return fmt.Errorf("failed to enqueue balance recalculation: %w", err)
+	}
+
+	return nil
+}
+
+// generateIdempotencyKey builds a unique key for requests sent without an
+// Idempotency-Key header. Such requests are never deduplicated.
+// createdBy reports whether tx is the transaction req asks for: same account,
+// type, amount and refunded transaction.
+func createdBy(tx *models.Transaction, req dto.CreateTransactionRequest) bool {
+	txType := req.Type
+	if txType == models.TransactionTypeTransfer {
+		txType = models.TransactionTypeTransferOut
+	}
+	refundOf := ""
+	if req.RefundTransactionId != nil && req.Type == models.TransactionTypeRefund {
+		refundOf = *req.RefundTransactionId
+	}
+
+	return tx.AccountId == req.AccountId &&
+		tx.Type == txType &&
+		tx.AmountCents == req.AmountCents &&
+		tx.RefundTransactionId.String == refundOf
+}
+
+func generateIdempotencyKey(req dto.CreateTransactionRequest) (string, error) {
+	nonce := make([]byte, 4)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate idempotency key: %w", err)
+	}
+
+	return fmt.Sprintf(
+		"%s:%s:%s:%d:%s",
+		time.Now().UTC().Format("2006-01-02-15:04:05.000"),
+		req.AccountId,
+		req.Type,
+		req.AmountCents,
+		hex.EncodeToString(nonce),
+	), nil
+}
+
 func (s *transactionServiceImpl) GetBalanceFromCache(ctx context.Context, key string) (string, error) {
 	return s.redis.Client.Get(ctx, key).Result()
 }
 
+// GetBalance returns the cached balance of accountId, computing it from
+// Postgres on a cache miss. The computed value is only cached if the processor
+// has not written a fresher one in the meantime.
+func (s *transactionServiceImpl) GetBalance(ctx context.Context, accountId string) (int64, error) {
+	key := balanceCacheKey(accountId)
+
+	cached, err := s.redis.Client.Get(ctx, key).Int64()
+	if err == nil {
+		return cached, nil
+	}
+	if err != redis.Nil {
+		log.Printf("Failed to read balance of account %s from cache: %v", accountId, err)
+	}
+
+	account, err := s.accountService.GetAccountById(ctx, accountId)
+	if err != nil {
+		return 0, err
+	}
+	if account == nil {
+		return 0, ErrAccountNotFound
+	}
+
+	balance, err := s.repo.GetBalance(ctx, accountId)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.redis.Client.SetNX(ctx, key, balance, balanceCacheTTL).Err(); err != nil {
+		log.Printf("Failed to cache balance of account %s: %v", accountId, err)
+	}
+
+	return balance, nil
+}
+
+// GetBalanceAt returns the settled balance of accountId at the given instant.
+// Only APPROVED transactions count; open authorization holds do not.
+func (s *transactionServiceImpl) GetBalanceAt(ctx context.Context, accountId string, at time.Time) (int64, error) {
+	account, err := s.accountService.GetAccountById(ctx, accountId)
+	if err != nil {
+		return 0, err
+	}
+	if account == nil {
+		return 0, ErrAccountNotFound
+	}
+
+	return s.repo.GetBalanceAt(ctx, accountId, at)
+}
+
+// GetBalanceHistory returns the balance of accountId at from followed by the
+// net change and closing balance of every interval period up to to.
+func (s *transactionServiceImpl) GetBalanceHistory(ctx context.Context, accountId string, from, to time.Time, interval string) (*dto.ResponseBalanceHistory, error) {
+	if !models.IsBalanceInterval(interval) {
+		return nil, ErrInvalidHistoryInterval
+	}
+	if !from.Before(to) {
+		return nil, ErrInvalidHistoryRange
+	}
+	if to.Sub(from)/balanceIntervalLengths[interval] > maxBalanceHistoryPoints {
+		return nil, ErrHistoryTooManyPoints
+	}
+
+	opening, err := s.GetBalanceAt(ctx, accountId, from)
+	if err != nil {
+		return nil, err
+	}
+
+	movements, err := s.repo.GetBalanceMovements(ctx, accountId, from, to, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &dto.ResponseBalanceHistory{
+		AccountId:      accountId,
+		From:           from.UTC().Format(time.RFC3339),
+		To:             to.UTC().Format(time.RFC3339),
+		Interval:       interval,
+		OpeningBalance: opening,
+		Points:         make([]dto.BalanceHistoryPoint, 0, len(movements)),
+	}
+
+	running := opening
+	for _, movement := range movements {
+		running += movement.NetChangeCents
+		history.Points = append(history.Points, dto.BalanceHistoryPoint{
+			PeriodStart:    movement.PeriodStart.UTC().Format(time.RFC3339),
+			NetChange:      movement.NetChangeCents,
+			ClosingBalance: running,
+		})
+	}
+
+	return history, nil
+}
+
+// RefreshBalanceSnapshots rewrites the daily snapshots of the last few days,
+// oldest first. The window covers the authorization hold TTL so days the job
+// missed are written; snapshots already written are corrected by the database
+// when a transaction changes afterwards.
+func (s *transactionServiceImpl) RefreshBalanceSnapshots(ctx context.Context) (int64, error) {
+	days := int(s.holdTTL/(24*time.Hour)) + 1
+	yesterday := time.Now().UTC().AddDate(0, 0, -1)
+
+	var written int64
+	for offset := days - 1; offset >= 0; offset-- {
+		rows, err := s.repo.RefreshBalanceSnapshots(ctx, yesterday.AddDate(0, 0, -offset))
+		if err != nil {
+			return written, err
+		}
+		written += rows
+	}
+
+	return written, nil
+}
+
 func (s *transactionServiceImpl) GetBalanceByAccountId(ctx context.Context, accountId string) error {
 	message := map[string]string{"account_id": accountId}
 
@@ -142,38 +688,271 @@ func (s *transactionServiceImpl) GetBalanceByAccountId(ctx context.Context, acco
 		return fmt.Errorf("failed to serialize message for queue: %w", err)
 	}
 
-	return s.mqClient.Publish(ctx, "calculate_balance_queue", messageBytes)
+	return s.mqClient.Publish(ctx, calculateBalanceQueue, messageBytes)
 }
 
-func (s *transactionServiceImpl) GetAllTransactionsByAccountId(ctx context.Context, accountId string) ([]*models.Transaction, error) {
-	err, transactions := s.repo.GetAllTransactionsByAccountIdTest(ctx, accountId)
+// ListTransactions returns one page of the transactions matching filter,
+// starting after cursor when it is set.
+func (s *transactionServiceImpl) ListTransactions(ctx context.Context, filter models.TransactionFilter, cursor string) (*dto.ResponseTransactionPage, error) {
+	if cursor != "" {
+		decoded, err := decodeCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter.Cursor = decoded
+	}
+
+	if filter.AccountId != "" {
+		account, err := s.accountService.GetAccountById(ctx, filter.AccountId)
+		if err != nil {
+			return nil, err
+		}
+		if account == nil {
+			return nil, ErrAccountNotFound
+		}
+	}
+
+	limit := filter.Limit
+	filter.Limit = limit + 1
+	transactions, err := s.repo.ListTransactions(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
-	if len(transactions) > 0 {
-		return transactions, nil
+
+	page := &dto.ResponseTransactionPage{Data: transactions}
+	if len(transactions) > limit {
+		page.Data = transactions[:limit]
+		page.HasMore = true
+
+		next, err := encodeCursor(page.Data[limit-1])
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode cursor: %w", err)
+		}
+		page.NextCursor = &next
 	}
-	return transactions, nil
+	if page.Data == nil {
+		page.Data = make([]*models.Transaction, 0)
+	}
+
+	return page, nil
 }
 
-func (s *transactionServiceImpl) GetAllTransactionsByCardId(ctx context.Context, cardId string) ([]*models.Transaction, error) {
-	transactions, err := s.repo.GetAllTransactionsByCardId(ctx, cardId)
+func (s *transactionServiceImpl) FindTransactionById(ctx context.Context, transactionId string) (*dto.ResponseTransactionDetails, error) {
+	transaction, err := s.repo.FindTransactionById(ctx, transactionId)
+	if err != nil {
+		return nil, err
+	}
+	if transaction == nil {
+		return nil, nil
+	}
+
+	details := &dto.ResponseTransactionDetails{Transaction: *transaction}
+	if !models.IsRefundableType(transaction.Type) {
+		return details, nil
+	}
+
+	totals, err := s.repo.GetRefundTotals(ctx, transaction.ID)
 	if err != nil {
 		return nil, err
 	}
-	if len(transactions) > 0 {
-		return transactions, nil
+	refundable := int64(0)
+	if transaction.Status == models.TransactionStatusApproved {
+		refundable = totals.RefundableCents(transaction.AmountCents)
 	}
-	return []*models.Transaction{}, nil
+	details.RefundedCents = &totals.RefundedCents
+	details.PendingRefundCents = &totals.PendingRefundCents
+	details.RefundableCents = &refundable
+
+	return details, nil
 }
 
-func (s *transactionServiceImpl) FindTransactionById(ctx context.Context, transactionId string) (*models.Transaction, error) {
-	transaction, err := s.repo.FindTransactionById(ctx, transactionId)
+// CaptureTransaction settles an authorized purchase. Without an amount the full
+// authorized amount is captured; a lower amount releases the rest of the hold.
+func (s *transactionServiceImpl) CaptureTransaction(ctx context.Context, transactionId string, req dto.CaptureTransactionRequest) (*models.Transaction, error) {
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin database transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	transaction, err := s.lockAuthorization(ctx, tx, transactionId)
 	if err != nil {
 		return nil, err
 	}
-	if transaction != nil {
-		return transaction, nil
+
+	if holdExpired(transaction) {
+		if err := s.finishAuthorization(ctx, tx, transaction, models.TransactionStatusExpired); err != nil {
+			return nil, err
+		}
+		return nil, ErrAuthorizationExpired
+	}
+
+	amountCents := transaction.AuthorizedAmountCents.Int64
+	if req.AmountCents != nil {
+		amountCents = *req.AmountCents
+	}
+	if amountCents > transaction.AuthorizedAmountCents.Int64 {
+		return nil, ErrCaptureExceedsAuthorizedAmount
+	}
+
+	if err := s.repo.CaptureAuthorization(ctx, tx, transaction.ID, amountCents); err != nil {
+		return nil, err
+	}
+	if err := s.ledgerService.RecordCaptureRelease(ctx, tx, transaction, transaction.AuthorizedAmountCents.Int64-amountCents); err != nil {
+		return nil, err
+	}
+	if err := s.enqueueBalanceRecalculation(ctx, tx, transaction.AccountId); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit database transaction: %w", err)
+	}
+
+	transaction.Status = models.TransactionStatusApproved
+	transaction.AmountCents = amountCents
+
+	return transaction, nil
+}
+
+// VoidTransaction releases the hold of an authorized purchase without capturing it.
+func (s *transactionServiceImpl) VoidTransaction(ctx context.Context, transactionId string) (*models.Transaction, error) {
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin database transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	transaction, err := s.lockAuthorization(ctx, tx, transactionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.finishAuthorization(ctx, tx, transaction, models.TransactionStatusVoided); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// ExpireAuthorizationHolds expires every overdue hold, releases it in the
+// ledger and returns how many accounts were affected.
+func (s *transactionServiceImpl) ExpireAuthorizationHolds(ctx context.Context) (int, error) {
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin database transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	expired, err := s.repo.ExpireAuthorizationHolds(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	var accountIds []string
+	for _, transaction := range expired {
+		if err := s.ledgerService.RecordHoldRelease(ctx, tx, transaction); err != nil {
+			return 0, err
+		}
+		if !slices.Contains(accountIds, transaction.AccountId) {
+			accountIds = append(accountIds, transaction.AccountId)
+		}
+	}
+	for _, accountId := range accountIds {
+		if err := s.enqueueBalanceRecalculation(ctx, tx, accountId); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit database transaction: %w", err)
+	}
+
+	return len(accountIds), nil
+}
+
+func (s *transactionServiceImpl) lockAuthorization(ctx context.Context, dbTx *sqlx.Tx, transactionId string) (*models.Transaction, error) {
+	transaction, err := s.repo.GetTransactionByIDForUpdate(ctx, dbTx, transactionId)
+	if err != nil {
+		return nil, err
+	}
+	if transaction == nil {
+		return nil, ErrTransactionNotFound
+	}
+	if transaction.Status != models.TransactionStatusAuthorized {
+		return nil, ErrTransactionNotAuthorized
+	}
+
+	return transaction, nil
+}
+
+// finishAuthorization moves a locked authorization to a final status, releases
+// its hold and commits dbTx.
+func (s *transactionServiceImpl) finishAuthorization(ctx context.Context, dbTx *sqlx.Tx, transaction *models.Transaction, status string) error {
+	if err := s.repo.UpdateStatus(ctx, dbTx, transaction.ID, status); err != nil {
+		return err
+	}
+	if err := s.ledgerService.RecordHoldRelease(ctx, dbTx, transaction); err != nil {
+		return err
+	}
+	if err := s.enqueueBalanceRecalculation(ctx, dbTx, transaction.AccountId); err != nil {
+		return err
+	}
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit database transaction: %w", err)
+	}
+
+	transaction.Status = status
+	return nil
+}
+
+func holdExpired(transaction *models.Transaction) bool {
+	if !transaction.HoldExpiresAt.Valid {
+		return false
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, transaction.HoldExpiresAt.String)
+	if err != nil {
+		return false
+	}
+
+	return !time.Now().Before(expiresAt)
+}
+
+// RunHoldExpiry periodically expires overdue authorization holds until ctx is cancelled.
+func RunHoldExpiry(ctx context.Context, service TransactionService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			accounts, err := service.ExpireAuthorizationHolds(ctx)
+			if err != nil {
+				log.Printf("Failed to expire authorization holds: %v", err)
+				continue
+			}
+			if accounts > 0 {
+				log.Printf("Expired authorization holds on %d accounts", accounts)
+			}
+		}
+	}
+}
+
+// RunBalanceSnapshots periodically refreshes the daily balance snapshots until ctx is cancelled.
+func RunBalanceSnapshots(ctx context.Context, service TransactionService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := service.RefreshBalanceSnapshots(ctx); err != nil {
+				log.Printf("Failed to refresh balance snapshots: %v", err)
+			}
+		}
 	}
-	return nil, nil
 }
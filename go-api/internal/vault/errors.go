@@ -0,0 +1,10 @@
+package vault
+
+import "errors"
+
+var (
+	ErrInvalidKeyring    = errors.New("invalid vault keyring")
+	ErrUnknownKEKVersion = errors.New("unknown key-encryption key version")
+	ErrTokenNotFound     = errors.New("vault token not found")
+	ErrDecryptionFailed  = errors.New("vault entry could not be decrypted")
+)
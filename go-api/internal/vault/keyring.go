@@ -0,0 +1,141 @@
+package vault
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// keySize is the size of every key the vault uses: AES-256.
+const keySize = 32
+
+// Keyring holds the versioned key-encryption keys (KEKs). New data keys are
+// wrapped with the active one; older versions are kept to unwrap the data keys
+// written before a rotation until they have all been rewrapped.
+type Keyring struct {
+	keys   map[int][]byte
+	active int
+}
+
+// ParseKeyring reads KEKs written as "<version>:<base64 key>", such as
+// "2:q4mJ...". active is the version new data keys are wrapped with; 0 picks
+// the highest version.
+func ParseKeyring(entries []string, active int) (*Keyring, error) {
+	keys := make(map[int][]byte, len(entries))
+	for _, entry := range entries {
+		versionValue, encoded, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok {
+			return nil, fmt.Errorf("%w: expected <version>:<base64 key>", ErrInvalidKeyring)
+		}
+		version, err := strconv.Atoi(versionValue)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("%w: version %q is not a positive integer", ErrInvalidKeyring, versionValue)
+		}
+		if _, ok := keys[version]; ok {
+			return nil, fmt.Errorf("%w: version %d is defined twice", ErrInvalidKeyring, version)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != keySize {
+			return nil, fmt.Errorf("%w: key %d must be %d bytes encoded in base64", ErrInvalidKeyring, version, keySize)
+		}
+		keys[version] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: no keys configured", ErrInvalidKeyring)
+	}
+	if active == 0 {
+		for version := range keys {
+			active = max(active, version)
+		}
+	}
+	if _, ok := keys[active]; !ok {
+		return nil, fmt.Errorf("%w: active version %d is not configured", ErrInvalidKeyring, active)
+	}
+
+	return &Keyring{keys: keys, active: active}, nil
+}
+
+// NewRandomKeyring returns a keyring with a single random key. Secrets it
+// protects cannot be read once the process exits.
+func NewRandomKeyring() (*Keyring, error) {
+	key, err := randomBytes(keySize)
+	if err != nil {
+		return nil, err
+	}
+	return &Keyring{keys: map[int][]byte{1: key}, active: 1}, nil
+}
+
+// ActiveVersion is the version new data keys are wrapped with.
+func (k *Keyring) ActiveVersion() int {
+	return k.active
+}
+
+// wrap encrypts dataKey with the active KEK, bound to aad.
+func (k *Keyring) wrap(dataKey, aad []byte) (int, []byte, error) {
+	wrapped, err := seal(k.keys[k.active], dataKey, aad)
+	if err != nil {
+		return 0, nil, err
+	}
+	return k.active, wrapped, nil
+}
+
+// unwrap decrypts a data key wrapped with the KEK of version.
+func (k *Keyring) unwrap(version int, wrapped, aad []byte) ([]byte, error) {
+	key, ok := k.keys[version]
+	if !ok {
+		return nil, fmt.Errorf("%w %d", ErrUnknownKEKVersion, version)
+	}
+	return open(key, wrapped, aad)
+}
+
+// seal encrypts plaintext with AES-GCM under key. The random nonce is
+// prepended to the ciphertext.
+func seal(key, plaintext, aad []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randomBytes(aead.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+func open(key, sealed, aad []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrDecryptionFailed
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, aad)
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+	return plaintext, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+func randomBytes(size int) ([]byte, error) {
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(rand.Reader, buf); err != nil {
+		return nil, fmt.Errorf("failed to read random bytes: %w", err)
+	}
+	return buf, nil
+}
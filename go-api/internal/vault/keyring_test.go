@@ -0,0 +1,143 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// kekEntry returns a VAULT_KEKS entry for version with a random key.
+func kekEntry(t *testing.T, version int) string {
+	t.Helper()
+	key, err := randomBytes(keySize)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return fmt.Sprintf("%d:%s", version, base64.StdEncoding.EncodeToString(key))
+}
+
+func TestParseKeyringRejectsInvalidEntries(t *testing.T) {
+	valid := kekEntry(t, 1)
+	shortKey := "2:" + base64.StdEncoding.EncodeToString(make([]byte, 16))
+	longKey := "2:" + base64.StdEncoding.EncodeToString(make([]byte, 48))
+
+	tests := []struct {
+		name    string
+		entries []string
+		active  int
+	}{
+		{"no keys", nil, 0},
+		{"missing version", []string{strings.TrimPrefix(valid, "1:")}, 0},
+		{"zero version", []string{"0" + strings.TrimPrefix(valid, "1")}, 0},
+		{"negative version", []string{"-1" + strings.TrimPrefix(valid, "1")}, 0},
+		{"non numeric version", []string{"v1" + strings.TrimPrefix(valid, "1")}, 0},
+		{"duplicate version", []string{valid, kekEntry(t, 1)}, 0},
+		{"short key", []string{valid, shortKey}, 0},
+		{"long key", []string{valid, longKey}, 0},
+		{"key not base64", []string{"1:not base64!"}, 0},
+		{"active version not configured", []string{valid}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseKeyring(tt.entries, tt.active); !errors.Is(err, ErrInvalidKeyring) {
+				t.Errorf("ParseKeyring error = %v, want %v", err, ErrInvalidKeyring)
+			}
+		})
+	}
+}
+
+func TestParseKeyringActiveVersion(t *testing.T) {
+	entries := []string{kekEntry(t, 1), " " + kekEntry(t, 3) + " ", kekEntry(t, 2)}
+
+	keyring, err := ParseKeyring(entries, 0)
+	if err != nil {
+		t.Fatalf("ParseKeyring failed: %v", err)
+	}
+	if keyring.ActiveVersion() != 3 {
+		t.Errorf("ActiveVersion() = %d with active 0, want the highest version 3", keyring.ActiveVersion())
+	}
+
+	keyring, err = ParseKeyring(entries, 2)
+	if err != nil {
+		t.Fatalf("ParseKeyring failed: %v", err)
+	}
+	if keyring.ActiveVersion() != 2 {
+		t.Errorf("ActiveVersion() = %d, want 2", keyring.ActiveVersion())
+	}
+}
+
+func TestSealOpen(t *testing.T) {
+	key, _ := randomBytes(keySize)
+	otherKey, _ := randomBytes(keySize)
+	plaintext := []byte("4532111234567890")
+	aad := []byte("tok_a")
+
+	sealed, err := seal(key, plaintext, aad)
+	if err != nil {
+		t.Fatalf("seal failed: %v", err)
+	}
+	if bytes.Contains(sealed, plaintext) {
+		t.Fatal("sealed data contains the plaintext")
+	}
+
+	opened, err := open(key, sealed, aad)
+	if err != nil {
+		t.Fatalf("open failed: %v", err)
+	}
+	if !bytes.Equal(opened, plaintext) {
+		t.Errorf("open = %q, want %q", opened, plaintext)
+	}
+
+	again, err := seal(key, plaintext, aad)
+	if err != nil {
+		t.Fatalf("seal failed: %v", err)
+	}
+	if bytes.Equal(again, sealed) {
+		t.Error("sealing twice gave the same output; the nonce is not random")
+	}
+
+	tampered := append([]byte(nil), sealed...)
+	tampered[len(tampered)-1] ^= 1
+
+	failures := []struct {
+		name   string
+		key    []byte
+		sealed []byte
+		aad    []byte
+	}{
+		{"other aad", key, sealed, []byte("tok_b")},
+		{"no aad", key, sealed, nil},
+		{"other key", otherKey, sealed, aad},
+		{"tampered ciphertext", key, tampered, aad},
+		{"truncated", key, sealed[:4], aad},
+	}
+	for _, tt := range failures {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := open(tt.key, tt.sealed, tt.aad); !errors.Is(err, ErrDecryptionFailed) {
+				t.Errorf("open error = %v, want %v", err, ErrDecryptionFailed)
+			}
+		})
+	}
+}
+
+func TestKeyringUnwrapUnknownVersion(t *testing.T) {
+	keyring, err := ParseKeyring([]string{kekEntry(t, 1)}, 0)
+	if err != nil {
+		t.Fatalf("ParseKeyring failed: %v", err)
+	}
+
+	version, wrapped, err := keyring.wrap([]byte("data key"), []byte("tok_a"))
+	if err != nil {
+		t.Fatalf("wrap failed: %v", err)
+	}
+	if version != 1 {
+		t.Errorf("wrap used version %d, want 1", version)
+	}
+	if _, err := keyring.unwrap(2, wrapped, []byte("tok_a")); !errors.Is(err, ErrUnknownKEKVersion) {
+		t.Errorf("unwrap error = %v, want %v", err, ErrUnknownKEKVersion)
+	}
+}
@@ -0,0 +1,20 @@
+package vault
+
+import (
+	"payment-gateway/go-api/internal/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type Module struct {
+	Service VaultService
+}
+
+func NewModule(db *sqlx.DB, keyring *Keyring) *Module {
+	repo := repository.NewVaultRepository(db)
+	service := NewVaultService(repo, keyring)
+
+	return &Module{
+		Service: service,
+	}
+}
@@ -0,0 +1,163 @@
+package vault
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"payment-gateway/go-api/internal/models"
+	"payment-gateway/go-api/internal/repository"
+	"time"
+)
+
+const (
+	tokenPrefix = "tok_"
+	tokenBytes  = 24
+	// rewrapBatchSize bounds how many entries a single rewrap query loads.
+	rewrapBatchSize = 100
+)
+
+// VaultService keeps card numbers out of the card tables. Each secret is
+// encrypted with its own random data key, and the data key is stored wrapped
+// by a versioned key-encryption key from the keyring.
+type VaultService interface {
+	// Tokenize encrypts secret and returns the opaque token it is stored under.
+	Tokenize(ctx context.Context, secret string) (string, error)
+	// Detokenize returns the secret stored under token.
+	Detokenize(ctx context.Context, token string) (string, error)
+	// Rewrap moves every data key still wrapped by a retired KEK onto the
+	// active one and returns how many entries were rewrapped.
+	Rewrap(ctx context.Context) (int, error)
+}
+
+type vaultServiceImpl struct {
+	repo    repository.VaultRepository
+	keyring *Keyring
+}
+
+func NewVaultService(repo repository.VaultRepository, keyring *Keyring) *vaultServiceImpl {
+	return &vaultServiceImpl{
+		repo:    repo,
+		keyring: keyring,
+	}
+}
+
+func (s *vaultServiceImpl) Tokenize(ctx context.Context, secret string) (string, error) {
+	raw, err := randomBytes(tokenBytes)
+	if err != nil {
+		return "", err
+	}
+	token := tokenPrefix + base64.RawURLEncoding.EncodeToString(raw)
+
+	dataKey, err := randomBytes(keySize)
+	if err != nil {
+		return "", err
+	}
+	// Binding both ciphertexts to the token stops an entry's key or payload
+	// from being swapped into another row.
+	aad := []byte(token)
+	ciphertext, err := seal(dataKey, []byte(secret), aad)
+	if err != nil {
+		return "", err
+	}
+	version, wrappedKey, err := s.keyring.wrap(dataKey, aad)
+	if err != nil {
+		return "", err
+	}
+
+	entry := &models.VaultEntry{
+		Token:      token,
+		KEKVersion: version,
+		WrappedKey: wrappedKey,
+		Ciphertext: ciphertext,
+	}
+	if err := s.repo.CreateVaultEntry(ctx, entry); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (s *vaultServiceImpl) Detokenize(ctx context.Context, token string) (string, error) {
+	entry, err := s.repo.GetVaultEntry(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	if entry == nil {
+		return "", ErrTokenNotFound
+	}
+
+	aad := []byte(entry.Token)
+	dataKey, err := s.keyring.unwrap(entry.KEKVersion, entry.WrappedKey, aad)
+	if err != nil {
+		return "", err
+	}
+	secret, err := open(dataKey, entry.Ciphertext, aad)
+	if err != nil {
+		return "", err
+	}
+
+	return string(secret), nil
+}
+
+func (s *vaultServiceImpl) Rewrap(ctx context.Context) (int, error) {
+	active := s.keyring.ActiveVersion()
+	rewrapped := 0
+	// Entries whose KEK is no longer configured can never be rewrapped; they
+	// are skipped so they do not keep filling every batch.
+	skipped := 0
+
+	for {
+		entries, err := s.repo.ListVaultEntriesToRewrap(ctx, active, skipped+rewrapBatchSize)
+		if err != nil {
+			return rewrapped, err
+		}
+		if len(entries) <= skipped {
+			return rewrapped, nil
+		}
+
+		for _, entry := range entries[skipped:] {
+			aad := []byte(entry.Token)
+			dataKey, err := s.keyring.unwrap(entry.KEKVersion, entry.WrappedKey, aad)
+			if err != nil {
+				if errors.Is(err, ErrUnknownKEKVersion) || errors.Is(err, ErrDecryptionFailed) {
+					log.Printf("Cannot rewrap vault entry %s: %v", entry.Token[:len(tokenPrefix)+4], err)
+					skipped++
+					continue
+				}
+				return rewrapped, err
+			}
+			version, wrappedKey, err := s.keyring.wrap(dataKey, aad)
+			if err != nil {
+				return rewrapped, err
+			}
+			if err := s.repo.RewrapVaultEntry(ctx, entry.Token, entry.KEKVersion, version, wrappedKey); err != nil {
+				return rewrapped, fmt.Errorf("failed to rewrap vault entry: %w", err)
+			}
+			rewrapped++
+		}
+	}
+}
+
+// RunRewrap periodically rewraps data keys onto the active KEK until ctx is cancelled.
+func RunRewrap(ctx context.Context, service VaultService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			entries, err := service.Rewrap(ctx)
+			if err != nil {
+				log.Printf("Failed to rewrap vault entries: %v", err)
+				continue
+			}
+			if entries > 0 {
+				log.Printf("Rewrapped %d vault entries", entries)
+			}
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"payment-gateway/go-api/internal/models"
+	"testing"
+	"time"
+)
+
+// fakeVaultRepository keeps vault entries in memory, in creation order.
+type fakeVaultRepository struct {
+	entries []*models.VaultEntry
+}
+
+func (r *fakeVaultRepository) CreateVaultEntry(ctx context.Context, entry *models.VaultEntry) error {
+	stored := *entry
+	r.entries = append(r.entries, &stored)
+	return nil
+}
+
+func (r *fakeVaultRepository) GetVaultEntry(ctx context.Context, token string) (*models.VaultEntry, error) {
+	for _, entry := range r.entries {
+		if entry.Token == token {
+			found := *entry
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeVaultRepository) ListVaultEntriesToRewrap(ctx context.Context, activeVersion, limit int) ([]*models.VaultEntry, error) {
+	var entries []*models.VaultEntry
+	for _, entry := range r.entries {
+		if entry.KEKVersion != activeVersion && len(entries) < limit {
+			found := *entry
+			entries = append(entries, &found)
+		}
+	}
+	return entries, nil
+}
+
+func (r *fakeVaultRepository) RewrapVaultEntry(ctx context.Context, token string, fromVersion, toVersion int, wrappedKey []byte) error {
+	for _, entry := range r.entries {
+		if entry.Token == token && entry.KEKVersion == fromVersion {
+			entry.KEKVersion = toVersion
+			entry.WrappedKey = wrappedKey
+		}
+	}
+	return nil
+}
+
+func (r *fakeVaultRepository) entry(token string) *models.VaultEntry {
+	for _, entry := range r.entries {
+		if entry.Token == token {
+			return entry
+		}
+	}
+	return nil
+}
+
+func mustParseKeyring(t *testing.T, entries []string, active int) *Keyring {
+	t.Helper()
+	keyring, err := ParseKeyring(entries, active)
+	if err != nil {
+		t.Fatalf("ParseKeyring failed: %v", err)
+	}
+	return keyring
+}
+
+func TestTokenizeDetokenize(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeVaultRepository{}
+	service := NewVaultService(repo, mustParseKeyring(t, []string{kekEntry(t, 1)}, 0))
+
+	token, err := service.Tokenize(ctx, "4532111234567890")
+	if err != nil {
+		t.Fatalf("Tokenize failed: %v", err)
+	}
+	if len(token) <= len(tokenPrefix) || token[:len(tokenPrefix)] != tokenPrefix {
+		t.Errorf("token %q does not start with %q", token, tokenPrefix)
+	}
+
+	secret, err := service.Detokenize(ctx, token)
+	if err != nil {
+		t.Fatalf("Detokenize failed: %v", err)
+	}
+	if secret != "4532111234567890" {
+		t.Errorf("Detokenize = %q, want %q", secret, "4532111234567890")
+	}
+
+	if _, err := service.Detokenize(ctx, "tok_unknown"); !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("Detokenize of an unknown token error = %v, want %v", err, ErrTokenNotFound)
+	}
+}
+
+func TestDetokenizeRejectsSwappedCiphertexts(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeVaultRepository{}
+	service := NewVaultService(repo, mustParseKeyring(t, []string{kekEntry(t, 1)}, 0))
+
+	first, err := service.Tokenize(ctx, "4532111234567890")
+	if err != nil {
+		t.Fatalf("Tokenize failed: %v", err)
+	}
+	second, err := service.Tokenize(ctx, "5454541234567890")
+	if err != nil {
+		t.Fatalf("Tokenize failed: %v", err)
+	}
+	a, b := repo.entry(first), repo.entry(second)
+
+	// Swapping only the payload leaves each data key with its own token.
+	a.Ciphertext, b.Ciphertext = b.Ciphertext, a.Ciphertext
+	if _, err := service.Detokenize(ctx, first); !errors.Is(err, ErrDecryptionFailed) {
+		t.Errorf("Detokenize with a swapped ciphertext error = %v, want %v", err, ErrDecryptionFailed)
+	}
+
+	// Swapping the whole row still fails: the wrapped key is bound to the token.
+	a.WrappedKey, b.WrappedKey = b.WrappedKey, a.WrappedKey
+	if _, err := service.Detokenize(ctx, first); !errors.Is(err, ErrDecryptionFailed) {
+		t.Errorf("Detokenize with a swapped entry error = %v, want %v", err, ErrDecryptionFailed)
+	}
+	if _, err := service.Detokenize(ctx, second); !errors.Is(err, ErrDecryptionFailed) {
+		t.Errorf("Detokenize with a swapped entry error = %v, want %v", err, ErrDecryptionFailed)
+	}
+}
+
+func TestRewrap(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeVaultRepository{}
+	retired, current, lost := kekEntry(t, 1), kekEntry(t, 2), kekEntry(t, 9)
+
+	// Entries wrapped by a KEK that is no longer configured come first, so
+	// they fill the first batch the rewrap loads.
+	const unknownEntries, oldEntries = 3, rewrapBatchSize + 5
+	lostService := NewVaultService(repo, mustParseKeyring(t, []string{lost}, 0))
+	for i := 0; i < unknownEntries; i++ {
+		if _, err := lostService.Tokenize(ctx, "4532110000000000"); err != nil {
+			t.Fatalf("Tokenize failed: %v", err)
+		}
+	}
+	oldService := NewVaultService(repo, mustParseKeyring(t, []string{retired}, 0))
+	secrets := map[string]string{}
+	for i := 0; i < oldEntries; i++ {
+		secret := "45321100000" + string(rune('0'+i%10)) + "1234"
+		token, err := oldService.Tokenize(ctx, secret)
+		if err != nil {
+			t.Fatalf("Tokenize failed: %v", err)
+		}
+		secrets[token] = secret
+	}
+
+	service := NewVaultService(repo, mustParseKeyring(t, []string{retired, current}, 0))
+	rewrapped := rewrapWithin(t, service, 5*time.Second)
+	if rewrapped != oldEntries {
+		t.Errorf("Rewrap = %d, want %d", rewrapped, oldEntries)
+	}
+
+	unknown := 0
+	for _, entry := range repo.entries {
+		switch entry.KEKVersion {
+		case 2:
+		case 9:
+			unknown++
+		default:
+			t.Errorf("entry still wrapped by version %d", entry.KEKVersion)
+		}
+	}
+	if unknown != unknownEntries {
+		t.Errorf("%d entries left on the unknown KEK, want %d", unknown, unknownEntries)
+	}
+
+	// Once rewrapped the retired KEK can be dropped.
+	currentOnly := NewVaultService(repo, mustParseKeyring(t, []string{current}, 0))
+	for token, secret := range secrets {
+		got, err := currentOnly.Detokenize(ctx, token)
+		if err != nil {
+			t.Fatalf("Detokenize after rewrap failed: %v", err)
+		}
+		if got != secret {
+			t.Errorf("Detokenize after rewrap = %q, want %q", got, secret)
+		}
+	}
+
+	if again := rewrapWithin(t, service, 5*time.Second); again != 0 {
+		t.Errorf("second Rewrap = %d, want 0", again)
+	}
+}
+
+// rewrapWithin runs Rewrap and fails the test if it has not returned within
+// timeout, which means it keeps reloading entries it cannot rewrap.
+func rewrapWithin(t *testing.T, service VaultService, timeout time.Duration) int {
+	t.Helper()
+
+	type result struct {
+		rewrapped int
+		err       error
+	}
+	done := make(chan result, 1)
+	go func() {
+		rewrapped, err := service.Rewrap(context.Background())
+		done <- result{rewrapped, err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			t.Fatalf("Rewrap failed: %v", r.err)
+		}
+		return r.rewrapped
+	case <-time.After(timeout):
+		t.Fatal("Rewrap did not return; it keeps loading entries it cannot rewrap")
+		return 0
+	}
+}
@@ -0,0 +1,14 @@
+CREATE TABLE outbox_messages(
+    id UUID PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+    aggregate_id UUID NOT NULL,
+    queue_name VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    dispatched_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_outbox_messages_pending ON outbox_messages (next_attempt_at, created_at)
+    WHERE dispatched_at IS NULL;
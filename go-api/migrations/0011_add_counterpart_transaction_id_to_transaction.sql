@@ -0,0 +1,2 @@
+ALTER TABLE transactions
+ADD COLUMN counterpart_transaction_id UUID REFERENCES transactions(id);
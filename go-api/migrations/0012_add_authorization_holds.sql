@@ -0,0 +1,10 @@
+ALTER TYPE transaction_status ADD VALUE IF NOT EXISTS 'AUTHORIZED';
+ALTER TYPE transaction_status ADD VALUE IF NOT EXISTS 'VOIDED';
+ALTER TYPE transaction_status ADD VALUE IF NOT EXISTS 'EXPIRED';
+
+ALTER TABLE transactions
+ADD COLUMN authorized_amount_cents BIGINT,
+ADD COLUMN hold_expires_at TIMESTAMPTZ;
+
+-- The index on open holds filters on AUTHORIZED, which cannot be used in the
+-- transaction that adds it. It is created by 0031.
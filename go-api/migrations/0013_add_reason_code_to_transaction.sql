@@ -0,0 +1,5 @@
+ALTER TABLE transactions
+ADD COLUMN reason_code VARCHAR(50);
+
+ALTER TABLE transactions
+ADD CONSTRAINT charge_requires_reason_code CHECK (type <> 'CHARGE' OR reason_code IS NOT NULL);
@@ -0,0 +1,45 @@
+CREATE TABLE journal_entries(
+    id UUID PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+    transaction_id UUID NOT NULL REFERENCES transactions(id),
+    kind VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_journal_entries_transaction_id ON journal_entries (transaction_id);
+
+CREATE TABLE postings(
+    id UUID PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+    journal_entry_id UUID NOT NULL REFERENCES journal_entries(id),
+    account_id UUID REFERENCES accounts(id),
+    system_account VARCHAR(50),
+    direction VARCHAR(6) NOT NULL CHECK (direction IN ('DEBIT', 'CREDIT')),
+    amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT posting_has_one_owner CHECK ((account_id IS NULL) <> (system_account IS NULL))
+);
+
+CREATE INDEX idx_postings_journal_entry_id ON postings (journal_entry_id);
+CREATE INDEX idx_postings_account_id_created_at ON postings (account_id, created_at DESC, id DESC);
+
+-- Every journal entry must balance to zero once its database transaction commits.
+CREATE FUNCTION check_journal_entry_balanced() RETURNS TRIGGER AS $$
+DECLARE
+    total BIGINT;
+BEGIN
+    SELECT COALESCE(SUM(CASE direction WHEN 'DEBIT' THEN amount_cents ELSE -amount_cents END), 0)
+    INTO total
+    FROM postings
+    WHERE journal_entry_id = NEW.journal_entry_id;
+
+    IF total <> 0 THEN
+        RAISE EXCEPTION 'journal entry % is unbalanced by % cents', NEW.journal_entry_id, total;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER postings_balanced
+    AFTER INSERT OR UPDATE ON postings
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION check_journal_entry_balanced();
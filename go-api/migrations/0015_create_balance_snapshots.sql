@@ -0,0 +1,10 @@
+CREATE TABLE balance_snapshots(
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    snapshot_date DATE NOT NULL,
+    balance_cents BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (account_id, snapshot_date)
+);
+
+CREATE INDEX idx_transactions_account_id_created_at ON transactions (account_id, created_at)
+    WHERE status = 'APPROVED';
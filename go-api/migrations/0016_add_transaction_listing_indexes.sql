@@ -0,0 +1,4 @@
+CREATE INDEX idx_transactions_account_id_created_at_id ON transactions (account_id, created_at DESC, id DESC);
+
+CREATE INDEX idx_transactions_card_id_created_at_id ON transactions (card_id, created_at DESC, id DESC)
+    WHERE card_id IS NOT NULL;
@@ -0,0 +1,9 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_transactions_idempotency_key_trgm ON transactions USING GIN (idempotency_key gin_trgm_ops);
+
+CREATE INDEX idx_transactions_status_created_at ON transactions (status, created_at DESC, id DESC);
+
+CREATE INDEX idx_transactions_amount_cents ON transactions (amount_cents);
+
+CREATE INDEX idx_cards_last_four_digits ON cards (last_four_digits);
@@ -0,0 +1,14 @@
+ALTER TABLE accounts
+ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE',
+ADD COLUMN status_reason VARCHAR(255),
+ADD COLUMN metadata JSONB NOT NULL DEFAULT '{}'::jsonb,
+ADD COLUMN deleted_at TIMESTAMPTZ;
+
+ALTER TABLE accounts
+ADD CONSTRAINT accounts_status_check CHECK (status IN ('ACTIVE', 'FROZEN', 'CLOSED'));
+
+ALTER TABLE accounts
+ADD CONSTRAINT accounts_deleted_are_closed CHECK (deleted_at IS NULL OR status = 'CLOSED');
+
+CREATE INDEX idx_accounts_created_at_active ON accounts (created_at DESC)
+    WHERE deleted_at IS NULL;
@@ -0,0 +1,2 @@
+CREATE UNIQUE INDEX accounts_username_lower_key ON accounts (LOWER(username))
+    WHERE deleted_at IS NULL;
@@ -0,0 +1,16 @@
+CREATE TABLE api_keys(
+    id UUID PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+    owner_type VARCHAR(20) NOT NULL CHECK (owner_type IN ('ACCOUNT', 'OPERATOR')),
+    account_id UUID REFERENCES accounts(id),
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(16) NOT NULL UNIQUE,
+    key_hash CHAR(64) NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    rotated_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    CONSTRAINT api_keys_owner CHECK ((owner_type = 'ACCOUNT') = (account_id IS NOT NULL))
+);
+
+CREATE INDEX idx_api_keys_account_id ON api_keys (account_id);
@@ -0,0 +1,2 @@
+ALTER TABLE accounts
+ADD COLUMN password_hash VARCHAR(255);
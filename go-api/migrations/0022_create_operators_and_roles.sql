@@ -0,0 +1,61 @@
+CREATE TABLE roles(
+    name VARCHAR(20) PRIMARY KEY NOT NULL,
+    description VARCHAR(255) NOT NULL
+);
+
+-- The policy table: what each operator role may do. Permissions use the same
+-- names as API key scopes; 'admin' grants every permission.
+CREATE TABLE role_permissions(
+    role VARCHAR(20) NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+    permission VARCHAR(50) NOT NULL,
+    PRIMARY KEY (role, permission)
+);
+
+INSERT INTO roles (name, description) VALUES
+    ('SUPPORT', 'Reads any account, card and transaction to help customers'),
+    ('FINANCE', 'Reads any account and transaction and issues refunds'),
+    ('ADMIN', 'Full access, including freezing accounts and managing operators');
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('SUPPORT', 'accounts:read'),
+    ('SUPPORT', 'cards:read'),
+    ('SUPPORT', 'transactions:read'),
+    ('FINANCE', 'accounts:read'),
+    ('FINANCE', 'transactions:read'),
+    ('FINANCE', 'transactions:refund'),
+    ('ADMIN', 'admin');
+
+CREATE TABLE operators(
+    id UUID PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+    username VARCHAR(100) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    role VARCHAR(20) NOT NULL REFERENCES roles(name),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX operators_username_lower_key ON operators (LOWER(username));
+
+ALTER TABLE api_keys
+ADD COLUMN operator_id UUID REFERENCES operators(id);
+
+ALTER TABLE api_keys
+ADD CONSTRAINT api_keys_operator_owner CHECK (operator_id IS NULL OR owner_type = 'OPERATOR');
+
+CREATE TABLE audit_logs(
+    id UUID PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+    operator_id UUID REFERENCES operators(id),
+    api_key_id UUID REFERENCES api_keys(id),
+    role VARCHAR(20),
+    method VARCHAR(10) NOT NULL,
+    route VARCHAR(255) NOT NULL,
+    path VARCHAR(2048) NOT NULL,
+    account_id UUID,
+    status_code INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_logs_created_at ON audit_logs (created_at DESC);
+CREATE INDEX idx_audit_logs_operator_id_created_at ON audit_logs (operator_id, created_at DESC);
+CREATE INDEX idx_audit_logs_account_id_created_at ON audit_logs (account_id, created_at DESC);
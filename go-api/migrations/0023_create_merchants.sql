@@ -0,0 +1,58 @@
+CREATE TABLE merchants(
+    id UUID PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Accounts created before merchants existed, and dashboard sign-ups that do
+-- not name a merchant, belong to the default merchant.
+INSERT INTO merchants (id, name) VALUES ('00000000-0000-0000-0000-000000000001', 'Default merchant');
+
+ALTER TABLE accounts
+ADD COLUMN merchant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES merchants(id);
+ALTER TABLE accounts ALTER COLUMN merchant_id DROP DEFAULT;
+ALTER TABLE accounts ADD CONSTRAINT accounts_id_merchant_id_key UNIQUE (id, merchant_id);
+
+-- Usernames are unique per merchant: two merchants may each have a charlie.
+DROP INDEX accounts_username_lower_key;
+CREATE UNIQUE INDEX accounts_merchant_id_username_lower_key ON accounts (merchant_id, LOWER(username))
+    WHERE deleted_at IS NULL;
+CREATE INDEX idx_accounts_merchant_id_created_at ON accounts (merchant_id, created_at DESC)
+    WHERE deleted_at IS NULL;
+
+-- Cards and transactions carry the merchant of their account so every query
+-- can filter on it directly. The composite keys keep them in step with it.
+ALTER TABLE cards
+ADD COLUMN merchant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001';
+ALTER TABLE cards ALTER COLUMN merchant_id DROP DEFAULT;
+ALTER TABLE cards ADD CONSTRAINT cards_account_merchant_fkey
+    FOREIGN KEY (account_id, merchant_id) REFERENCES accounts(id, merchant_id);
+
+ALTER TABLE transactions
+ADD COLUMN merchant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001';
+ALTER TABLE transactions ALTER COLUMN merchant_id DROP DEFAULT;
+ALTER TABLE transactions ADD CONSTRAINT transactions_account_merchant_fkey
+    FOREIGN KEY (account_id, merchant_id) REFERENCES accounts(id, merchant_id);
+CREATE INDEX idx_transactions_merchant_id_created_at ON transactions (merchant_id, created_at DESC, id DESC);
+
+-- Idempotency keys only have to be unique within a merchant.
+ALTER TABLE transactions DROP CONSTRAINT transactions_idempotency_key_key;
+ALTER TABLE transactions ADD CONSTRAINT transactions_merchant_id_idempotency_key_key UNIQUE (merchant_id, idempotency_key);
+
+-- merchant_scope is the merchant of the caller, or '' for platform operators.
+ALTER TABLE idempotency_keys ADD COLUMN merchant_scope VARCHAR(36) NOT NULL DEFAULT '';
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (merchant_scope, key);
+
+-- Credentials, operators and audit logs without a merchant belong to the
+-- platform and are only visible to platform operators.
+ALTER TABLE api_keys ADD COLUMN merchant_id UUID REFERENCES merchants(id);
+UPDATE api_keys k SET merchant_id = a.merchant_id FROM accounts a WHERE a.id = k.account_id;
+ALTER TABLE api_keys ADD CONSTRAINT api_keys_account_merchant CHECK (account_id IS NULL OR merchant_id IS NOT NULL);
+CREATE INDEX idx_api_keys_merchant_id ON api_keys (merchant_id);
+
+ALTER TABLE operators ADD COLUMN merchant_id UUID REFERENCES merchants(id);
+
+ALTER TABLE audit_logs ADD COLUMN merchant_id UUID REFERENCES merchants(id);
+CREATE INDEX idx_audit_logs_merchant_id_created_at ON audit_logs (merchant_id, created_at DESC);
@@ -0,0 +1,18 @@
+-- The card number and expiry of cards issued so far were never stored. Their
+-- brand is unknown, and their expiry is set to the latest date the old
+-- generator could have picked so none of them expires early.
+ALTER TABLE cards
+ADD COLUMN brand VARCHAR(20) NOT NULL DEFAULT 'UNKNOWN',
+ADD COLUMN expiry_month SMALLINT,
+ADD COLUMN expiry_year SMALLINT;
+
+UPDATE cards
+SET expiry_month = EXTRACT(MONTH FROM created_at AT TIME ZONE 'UTC'),
+    expiry_year = EXTRACT(YEAR FROM created_at AT TIME ZONE 'UTC') + 5;
+
+ALTER TABLE cards
+ALTER COLUMN brand DROP DEFAULT,
+ALTER COLUMN expiry_month SET NOT NULL,
+ALTER COLUMN expiry_year SET NOT NULL,
+ADD CONSTRAINT cards_brand_check CHECK (brand IN ('VISA', 'MASTERCARD', 'ELO', 'AMEX', 'UNKNOWN')),
+ADD CONSTRAINT cards_expiry_month_check CHECK (expiry_month BETWEEN 1 AND 12);
@@ -0,0 +1,13 @@
+-- Card numbers are held encrypted in the vault, each under its own data key.
+-- wrapped_key is that data key encrypted with the key-encryption key of
+-- kek_version; rotating the KEK only rewrites wrapped_key.
+CREATE TABLE card_vault(
+    token VARCHAR(64) PRIMARY KEY NOT NULL,
+    kek_version INTEGER NOT NULL,
+    wrapped_key BYTEA NOT NULL,
+    ciphertext BYTEA NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    rotated_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_card_vault_kek_version ON card_vault (kek_version);
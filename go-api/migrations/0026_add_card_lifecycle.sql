@@ -0,0 +1,24 @@
+-- Cards move between ACTIVE and BLOCKED until they are CANCELLED or reach the
+-- end of their expiry month and become EXPIRED. A replacement card points at
+-- the card it replaces; each card is replaced at most once.
+ALTER TABLE cards
+ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE',
+ADD COLUMN status_reason VARCHAR(255),
+ADD COLUMN replaces_card_id UUID REFERENCES cards(id),
+ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+ALTER TABLE cards
+ADD CONSTRAINT cards_status_check CHECK (status IN ('ACTIVE', 'BLOCKED', 'CANCELLED', 'EXPIRED'));
+
+CREATE UNIQUE INDEX cards_replaces_card_id_key ON cards (replaces_card_id)
+    WHERE replaces_card_id IS NOT NULL;
+
+CREATE INDEX idx_cards_expiry_live ON cards (expiry_year, expiry_month)
+    WHERE status IN ('ACTIVE', 'BLOCKED');
+
+UPDATE cards
+SET status = 'EXPIRED'
+WHERE (expiry_year, expiry_month) < (
+    EXTRACT(YEAR FROM CURRENT_TIMESTAMP AT TIME ZONE 'UTC')::int,
+    EXTRACT(MONTH FROM CURRENT_TIMESTAMP AT TIME ZONE 'UTC')::int
+);
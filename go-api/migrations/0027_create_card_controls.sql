@@ -0,0 +1,23 @@
+-- Spending controls set by the card holder. A NULL or empty control does not
+-- restrict the card. Transactions declined by a control are stored REJECTED
+-- with the control that declined them.
+CREATE TABLE card_controls(
+    card_id UUID PRIMARY KEY NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+    merchant_id UUID NOT NULL REFERENCES merchants(id),
+    max_transaction_cents BIGINT CHECK (max_transaction_cents > 0),
+    daily_limit_cents BIGINT CHECK (daily_limit_cents > 0),
+    monthly_limit_cents BIGINT CHECK (monthly_limit_cents > 0),
+    allowed_transaction_types VARCHAR(20)[] NOT NULL DEFAULT '{}',
+    blocked_merchant_categories CHAR(4)[] NOT NULL DEFAULT '{}',
+    velocity_limit INTEGER CHECK (velocity_limit > 0),
+    velocity_window_seconds INTEGER CHECK (velocity_window_seconds > 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT card_controls_velocity_check CHECK ((velocity_limit IS NULL) = (velocity_window_seconds IS NULL))
+);
+
+ALTER TABLE transactions
+ADD COLUMN merchant_category_code CHAR(4),
+ADD COLUMN decline_reason VARCHAR(50);
+
+CREATE INDEX idx_transactions_card_id_created_at ON transactions (card_id, created_at)
+    WHERE card_id IS NOT NULL;
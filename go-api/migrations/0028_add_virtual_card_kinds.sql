@@ -0,0 +1,37 @@
+-- Virtual cards for procurement. A SINGLE_USE card makes one PURCHASE and is
+-- cancelled once it is approved. A MERCHANT_LOCKED card only buys from one
+-- merchant (card acceptor) and up to an amount ceiling over its lifetime.
+ALTER TABLE cards
+ADD COLUMN kind VARCHAR(20) NOT NULL DEFAULT 'STANDARD',
+ADD COLUMN locked_acceptor_id VARCHAR(64),
+ADD COLUMN amount_limit_cents BIGINT CHECK (amount_limit_cents > 0);
+
+ALTER TABLE cards
+ADD CONSTRAINT cards_kind_check CHECK (kind IN ('STANDARD', 'SINGLE_USE', 'MERCHANT_LOCKED')),
+ADD CONSTRAINT cards_standard_unconstrained CHECK (
+    kind <> 'STANDARD' OR (locked_acceptor_id IS NULL AND amount_limit_cents IS NULL)
+),
+ADD CONSTRAINT cards_merchant_locked_constrained CHECK (
+    kind <> 'MERCHANT_LOCKED' OR (locked_acceptor_id IS NOT NULL AND amount_limit_cents IS NOT NULL)
+);
+
+ALTER TABLE transactions
+ADD COLUMN acceptor_id VARCHAR(64);
+
+-- Purchases are approved by the processor or by capturing an authorization, so
+-- single-use cards are cancelled here rather than by either of them.
+CREATE FUNCTION cancel_used_single_use_card() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE cards
+    SET status = 'CANCELLED', status_reason = 'Single-use card used', updated_at = CURRENT_TIMESTAMP
+    WHERE id = NEW.card_id AND kind = 'SINGLE_USE' AND status IN ('ACTIVE', 'BLOCKED');
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER transactions_cancel_single_use_card
+    AFTER UPDATE OF status ON transactions
+    FOR EACH ROW
+    WHEN (NEW.status = 'APPROVED' AND OLD.status <> 'APPROVED' AND NEW.type = 'PURCHASE' AND NEW.card_id IS NOT NULL)
+    EXECUTE FUNCTION cancel_used_single_use_card();
@@ -0,0 +1,40 @@
+-- Journal entries are written when a transaction is created, before the
+-- processor decides on it. A transaction that ends REJECTED or ERROR moved no
+-- money, so whatever was posted for it is reversed by a REVERSAL entry. The
+-- processor rejects transactions itself, hence a trigger rather than the API.
+CREATE FUNCTION reverse_rejected_transaction() RETURNS TRIGGER AS $$
+DECLARE
+    reversal_id UUID;
+BEGIN
+    INSERT INTO journal_entries (transaction_id, kind)
+    SELECT NEW.id, 'REVERSAL'
+    WHERE EXISTS (SELECT 1 FROM journal_entries WHERE transaction_id = NEW.id)
+    RETURNING id INTO reversal_id;
+
+    IF reversal_id IS NULL THEN
+        RETURN NULL;
+    END IF;
+
+    INSERT INTO postings (journal_entry_id, account_id, system_account, direction, amount_cents)
+    SELECT reversal_id, net.account_id, net.system_account,
+        CASE WHEN net.amount_cents > 0 THEN 'CREDIT' ELSE 'DEBIT' END,
+        ABS(net.amount_cents)
+    FROM (
+        SELECT p.account_id, p.system_account,
+            SUM(CASE p.direction WHEN 'DEBIT' THEN p.amount_cents ELSE -p.amount_cents END) AS amount_cents
+        FROM postings p
+        JOIN journal_entries je ON je.id = p.journal_entry_id
+        WHERE je.transaction_id = NEW.id
+        GROUP BY p.account_id, p.system_account
+    ) net
+    WHERE net.amount_cents <> 0;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER transactions_reverse_rejected
+    AFTER UPDATE OF status ON transactions
+    FOR EACH ROW
+    WHEN (NEW.status IN ('REJECTED', 'ERROR') AND OLD.status NOT IN ('REJECTED', 'ERROR'))
+    EXECUTE FUNCTION reverse_rejected_transaction();
@@ -0,0 +1,6 @@
+-- caller_id is the API key, or the account of a session, that first used the
+-- key. Stored responses are only replayed to that same caller; another caller
+-- sending the key gets its own entry instead of someone else's response.
+ALTER TABLE idempotency_keys ADD COLUMN caller_id VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (merchant_scope, caller_id, key);
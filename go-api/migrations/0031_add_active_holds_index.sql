@@ -0,0 +1,5 @@
+-- Open holds, scanned by the hold expiry job. Kept apart from 0012, which adds
+-- the AUTHORIZED value: Postgres refuses a new enum value in the transaction
+-- that added it.
+CREATE INDEX IF NOT EXISTS idx_transactions_active_holds ON transactions (hold_expires_at)
+    WHERE status = 'AUTHORIZED';
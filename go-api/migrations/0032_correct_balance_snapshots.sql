@@ -0,0 +1,44 @@
+-- A balance snapshot holds the approved transactions created before it closed.
+-- Transactions are approved, captured for less or reversed after they were
+-- created, possibly after the snapshot job last rewrote the days they belong
+-- to, so every snapshot of the account from the transaction's day on is moved
+-- by the change in its effect on the settled balance.
+CREATE FUNCTION approved_balance_effect(tx_status VARCHAR, tx_type VARCHAR, tx_amount_cents BIGINT, tx_refund_transaction_id UUID) RETURNS BIGINT AS $$
+    SELECT CASE
+        WHEN tx_status <> 'APPROVED' THEN 0
+        WHEN tx_type IN ('DEPOSIT', 'TRANSFER_IN') THEN tx_amount_cents
+        WHEN tx_type IN ('PURCHASE', 'CHARGE', 'TRANSFER_OUT') THEN -tx_amount_cents
+        WHEN tx_type = 'REFUND' THEN
+            CASE (SELECT t_orig.type FROM transactions t_orig WHERE t_orig.id = tx_refund_transaction_id)
+                WHEN 'DEPOSIT' THEN -tx_amount_cents
+                WHEN 'PURCHASE' THEN tx_amount_cents
+                WHEN 'CHARGE' THEN tx_amount_cents
+                ELSE 0
+            END
+        ELSE 0
+    END;
+$$ LANGUAGE sql STABLE;
+
+CREATE FUNCTION correct_balance_snapshots() RETURNS TRIGGER AS $$
+DECLARE
+    delta BIGINT;
+BEGIN
+    delta := approved_balance_effect(NEW.status, NEW.type, NEW.amount_cents, NEW.refund_transaction_id)
+        - approved_balance_effect(OLD.status, OLD.type, OLD.amount_cents, OLD.refund_transaction_id);
+
+    IF delta <> 0 THEN
+        UPDATE balance_snapshots
+        SET balance_cents = balance_cents + delta
+        WHERE account_id = NEW.account_id
+        AND snapshot_date >= (NEW.created_at AT TIME ZONE 'UTC')::date;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER transactions_correct_balance_snapshots
+    AFTER UPDATE OF status, amount_cents ON transactions
+    FOR EACH ROW
+    WHEN (NEW.status IS DISTINCT FROM OLD.status OR NEW.amount_cents IS DISTINCT FROM OLD.amount_cents)
+    EXECUTE FUNCTION correct_balance_snapshots();
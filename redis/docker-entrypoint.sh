@@ -11,7 +11,7 @@ mkdir -p /data
 cat >/data/users.acl <<EOF
 user default off
 user ${WRITER_REDIS_USER} on >${WRITER_REDIS_PASSWORD} ~* &* +@all
-user ${READER_REDIS_USER} on >${READER_REDIS_PASSWORD} ~* &* +@read +@connection
+user ${READER_REDIS_USER} on >${READER_REDIS_PASSWORD} %R~* %W~balance:* %W~refresh:* %W~sessions:* %W~velocity:* &* +@read +@connection +set +getdel +del +sadd +srem +incr +expire +multi +exec
 EOF
 
 exec redis-server /usr/local/etc/redis/redis.conf
@@ -15,6 +15,7 @@ pub struct Application {
     cache_repo: Arc<CacheRepository>,
     transaction_channel: Arc<Channel>,
     balance_channel: Arc<Channel>,
+    charge_overdraft_limit_cents: i64,
 }
 
 impl Application {
@@ -37,6 +38,7 @@ impl Application {
             cache_repo,
             transaction_channel,
             balance_channel,
+            charge_overdraft_limit_cents: config.charge_overdraft_limit_cents,
         })
     }
 
@@ -57,6 +59,7 @@ impl Application {
         let pool = Arc::clone(&self.db_pool);
         let channel = Arc::clone(&self.transaction_channel);
         let cache_repo = Arc::clone(&self.cache_repo);
+        let charge_overdraft_limit_cents = self.charge_overdraft_limit_cents;
         tokio::spawn(async move {
             let handler = move |msg: String| {
                 let pool = Arc::clone(&pool);
@@ -68,6 +71,7 @@ impl Application {
                                 &pool,
                                 &cache_repo_clone,
                                 tx,
+                                charge_overdraft_limit_cents,
                             )
                             .await
                             {
@@ -19,6 +19,9 @@ pub struct Config {
 
     pub writer_redis_user: String,
     pub writer_redis_password: String,
+
+    #[serde(default)]
+    pub charge_overdraft_limit_cents: i64,
 }
 
 impl Config {
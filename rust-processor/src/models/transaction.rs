@@ -13,6 +13,9 @@ pub enum TransactionStatus {
     APPROVED,
     REJECTED,
     ERROR,
+    AUTHORIZED,
+    VOIDED,
+    EXPIRED,
 }
 
 impl TransactionStatus {
@@ -22,15 +25,22 @@ impl TransactionStatus {
             TransactionStatus::APPROVED => "APPROVED",
             TransactionStatus::REJECTED => "REJECTED",
             TransactionStatus::ERROR => "ERROR",
+            TransactionStatus::AUTHORIZED => "AUTHORIZED",
+            TransactionStatus::VOIDED => "VOIDED",
+            TransactionStatus::EXPIRED => "EXPIRED",
         }
     }
 }
 
+#[allow(non_camel_case_types)]
 #[derive(Debug, Serialize, Deserialize)]
 pub enum TransactionType {
     DEPOSIT,
     PURCHASE,
     REFUND,
+    CHARGE,
+    TRANSFER_OUT,
+    TRANSFER_IN,
 }
 
 impl FromStr for TransactionType {
@@ -41,6 +51,9 @@ impl FromStr for TransactionType {
             "DEPOSIT" => Ok(TransactionType::DEPOSIT),
             "PURCHASE" => Ok(TransactionType::PURCHASE),
             "REFUND" => Ok(TransactionType::REFUND),
+            "CHARGE" => Ok(TransactionType::CHARGE),
+            "TRANSFER_OUT" => Ok(TransactionType::TRANSFER_OUT),
+            "TRANSFER_IN" => Ok(TransactionType::TRANSFER_IN),
             _ => Err(format!("Invalid transaction type: {}", s)),
         }
     }
@@ -54,6 +67,8 @@ pub struct DbTransaction {
     #[sqlx(rename = "type")]
     pub transaction_type: String,
     pub refund_transaction_id: Option<Uuid>,
+    pub counterpart_transaction_id: Option<Uuid>,
+    pub hold_expires_at: Option<DateTime<Utc>>,
     pub status: TransactionStatus,
     pub created_at: DateTime<Utc>,
 }
@@ -10,6 +10,7 @@ pub async fn process_transaction(
     pool: &PgPool,
     cache_repository: &CacheRepository,
     tx: QueueTransaction,
+    charge_overdraft_limit_cents: i64,
 ) -> Result<()> {
     let account_repo = AccountRepository::new(pool);
     let transaction_repo = TransactionRepository::new(pool);
@@ -46,10 +47,44 @@ pub async fn process_transaction(
             )
             .await?;
         }
+        Ok(TransactionType::CHARGE) => {
+            services::charge_service::process_charge(
+                &transaction_repo,
+                &account_repo,
+                tx.clone(),
+                charge_overdraft_limit_cents,
+            )
+            .await?;
+        }
         Ok(TransactionType::REFUND) => {
             services::refund_service::process_refund(&transaction_repo, &account_repo, tx.clone())
                 .await?;
         }
+        Ok(TransactionType::TRANSFER_OUT) => {
+            let destination_account_id = services::transfer_service::process_transfer(
+                &transaction_repo,
+                &account_repo,
+                tx.clone(),
+            )
+            .await?;
+
+            if let Some(account_id) = destination_account_id {
+                crate::processors::processor_balance::process_balance_request(
+                    &account_repo,
+                    &transaction_repo,
+                    &cache_repository,
+                    BalanceRequest { account_id },
+                )
+                .await?;
+            }
+        }
+        Ok(TransactionType::TRANSFER_IN) => {
+            println!(
+                "Transaction {} is the credit leg of a transfer and is settled with its debit leg. Ignoring.",
+                tx.id
+            );
+            return Ok(());
+        }
         Err(e) => {
             transaction_repo
                 .update_status(tx.id, TransactionStatus::REJECTED)
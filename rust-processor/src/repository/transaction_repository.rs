@@ -9,6 +9,12 @@ use async_trait::async_trait;
 pub trait TTransactionRepository {
     async fn find_by_id(&self, tx_id: Uuid) -> Result<Option<DbTransaction>>;
     async fn update_status(&self, tx_id: Uuid, status: TransactionStatus) -> Result<()>;
+    async fn update_transfer_status(
+        &self,
+        debit_tx_id: Uuid,
+        credit_tx_id: Uuid,
+        status: TransactionStatus,
+    ) -> Result<()>;
     async fn update_refund_transaction_id(
         &self,
         tx_id: Uuid,
@@ -16,7 +22,7 @@ pub trait TTransactionRepository {
         status: TransactionStatus,
     ) -> Result<()>;
     async fn get_balance(&self, account_id: Uuid) -> Result<i64>;
-    async fn has_been_refunded(&self, original_tx_id: Uuid) -> Result<bool>;
+    async fn get_refunded_amount(&self, original_tx_id: Uuid) -> Result<i64>;
 }
 
 pub struct TransactionRepository<'a> {
@@ -47,20 +53,41 @@ impl TTransactionRepository for TransactionRepository<'_> {
         Ok(())
     }
 
-    async fn has_been_refunded(&self, original_tx_id: Uuid) -> Result<bool> {
-        let exists: (bool,) = sqlx::query_as(
+    async fn update_transfer_status(
+        &self,
+        debit_tx_id: Uuid,
+        credit_tx_id: Uuid,
+        status: TransactionStatus,
+    ) -> Result<()> {
+        sqlx::query(
+            r#"
+            UPDATE transactions
+            SET status = $1
+            WHERE id IN ($2, $3)
+            "#,
+        )
+        .bind(status.as_str())
+        .bind(debit_tx_id)
+        .bind(credit_tx_id)
+        .execute(self.pool)
+        .await?;
+
+        Ok(())
+    }
+
+    async fn get_refunded_amount(&self, original_tx_id: Uuid) -> Result<i64> {
+        let row: (Option<i64>,) = sqlx::query_as(
             r#"
-            SELECT EXISTS (
-                SELECT 1 FROM transactions
-                WHERE refund_transaction_id = $1 AND status = 'APPROVED'
-            )
+            SELECT SUM(amount_cents)::BIGINT
+            FROM transactions
+            WHERE type = 'REFUND' AND refund_transaction_id = $1 AND status = 'APPROVED'
             "#,
         )
         .bind(original_tx_id)
         .fetch_one(self.pool)
         .await?;
 
-        Ok(exists.0)
+        Ok(row.0.unwrap_or(0))
     }
 
     async fn update_refund_transaction_id(
@@ -72,7 +99,7 @@ impl TTransactionRepository for TransactionRepository<'_> {
         sqlx::query(
             r#"
             UPDATE transactions
-            SET refund_transaction_id  = $1, status = $2, amount_cents = (SELECT amount_cents FROM transactions WHERE id = $1)
+            SET refund_transaction_id = $1, status = $2
             WHERE id = $3
             "#,
         )
@@ -88,7 +115,7 @@ impl TTransactionRepository for TransactionRepository<'_> {
     async fn find_by_id(&self, tx_id: Uuid) -> Result<Option<DbTransaction>> {
         let maybe_transaction = sqlx::query_as::<_, DbTransaction>(
             r#"
-            SELECT id, account_id, amount_cents, "type", refund_transaction_id, status, created_at
+            SELECT id, account_id, amount_cents, "type", refund_transaction_id, counterpart_transaction_id, hold_expires_at, status, created_at
             FROM transactions
             WHERE id = $1
             "#,
@@ -100,18 +127,29 @@ impl TTransactionRepository for TransactionRepository<'_> {
         Ok(maybe_transaction)
     }
 
+    /// Available balance: approved movements minus purchases still held by an
+    /// open authorization.
     async fn get_balance(&self, account_id: Uuid) -> Result<i64> {
         let row: (Option<i64>,) = sqlx::query_as(
             r#"
             SELECT
                 SUM(
                     CASE
+                        WHEN t1.status = 'AUTHORIZED' THEN
+                            CASE
+                                WHEN t1.hold_expires_at > NOW() THEN -t1.amount_cents
+                                ELSE 0
+                            END
                         WHEN t1.type = 'DEPOSIT' THEN t1.amount_cents
                         WHEN t1.type = 'PURCHASE' THEN -t1.amount_cents
+                        WHEN t1.type = 'CHARGE' THEN -t1.amount_cents
+                        WHEN t1.type = 'TRANSFER_IN' THEN t1.amount_cents
+                        WHEN t1.type = 'TRANSFER_OUT' THEN -t1.amount_cents
                         WHEN t1.type = 'REFUND' THEN
                             CASE t_orig.type
                                 WHEN 'DEPOSIT' THEN -t1.amount_cents
                                 WHEN 'PURCHASE' THEN t1.amount_cents
+                                WHEN 'CHARGE' THEN t1.amount_cents
                                 ELSE 0
                             END
                         ELSE 0
@@ -124,7 +162,7 @@ impl TTransactionRepository for TransactionRepository<'_> {
             WHERE
                 t1.account_id = $1
             AND
-                t1.status = 'APPROVED'
+                t1.status IN ('APPROVED', 'AUTHORIZED')
             "#,
         )
         .bind(account_id)
@@ -0,0 +1,45 @@
+use crate::{
+    models::{QueueTransaction, TransactionStatus},
+    repository::{TAccountRepository, TTransactionRepository},
+};
+use anyhow::{Result, anyhow};
+
+// Charges are fees or merchant initiated debits. Unlike purchases they are
+// allowed to take the balance below zero, down to -overdraft_limit_cents.
+pub async fn process_charge(
+    transaction_repo: &impl TTransactionRepository,
+    account_repo: &impl TAccountRepository,
+    tx: QueueTransaction,
+    overdraft_limit_cents: i64,
+) -> Result<()> {
+    let account = account_repo.find_by_id(tx.account_id).await?;
+    if account.is_none() {
+        transaction_repo
+            .update_status(tx.id, TransactionStatus::REJECTED)
+            .await?;
+        return Ok(());
+    }
+
+    let maybe_tx = transaction_repo.find_by_id(tx.id).await?;
+    if maybe_tx.is_none() {
+        return Err(anyhow!("Transaction {} not found in DB.", tx.id));
+    }
+
+    let existing_tx = maybe_tx.unwrap();
+    if existing_tx.status != TransactionStatus::PENDING {
+        return Ok(());
+    }
+
+    let account_balance = transaction_repo.get_balance(tx.account_id).await?;
+    if account_balance - tx.amount_cents < -overdraft_limit_cents {
+        transaction_repo
+            .update_status(tx.id, TransactionStatus::REJECTED)
+            .await?;
+        return Ok(());
+    }
+
+    transaction_repo
+        .update_status(tx.id, TransactionStatus::APPROVED)
+        .await?;
+    Ok(())
+}
@@ -1,3 +1,5 @@
+pub mod charge_service;
 pub mod deposit_service;
 pub mod purchase_service;
 pub mod refund_service;
+pub mod transfer_service;
@@ -35,8 +35,14 @@ pub async fn process_purchase(
         return Ok(());
     }
 
-    transaction_repo
-        .update_status(tx.id, TransactionStatus::APPROVED)
-        .await?;
+    // Purchases created with capture=false only reserve the funds until go-api
+    // captures, voids or expires the hold.
+    let status = if existing_tx.hold_expires_at.is_some() {
+        TransactionStatus::AUTHORIZED
+    } else {
+        TransactionStatus::APPROVED
+    };
+
+    transaction_repo.update_status(tx.id, status).await?;
     Ok(())
 }
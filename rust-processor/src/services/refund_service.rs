@@ -75,13 +75,15 @@ pub async fn process_refund(
 
     let existing_refund_tx = maybe_refund_tx.unwrap();
 
-    let already_refunded = transaction_repo.has_been_refunded(refund_uuid).await?;
-    if already_refunded {
+    let refunded_amount = transaction_repo.get_refunded_amount(refund_uuid).await?;
+    if refunded_amount + tx.amount_cents > existing_refund_tx.amount_cents {
         transaction_repo
             .update_status(tx.id, TransactionStatus::REJECTED)
             .await?;
         return Err(anyhow!(
-            "Transaction {} has already been refunded.",
+            "Refund of {} cents exceeds the {} cents still refundable on transaction {}.",
+            tx.amount_cents,
+            existing_refund_tx.amount_cents - refunded_amount,
             refund_uuid
         ));
     }
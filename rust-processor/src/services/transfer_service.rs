@@ -0,0 +1,71 @@
+use crate::{
+    models::{QueueTransaction, TransactionStatus},
+    repository::{TAccountRepository, TTransactionRepository},
+};
+use anyhow::{Result, anyhow};
+use uuid::Uuid;
+
+/// Approves or rejects both legs of a transfer together. Returns the
+/// destination account when its balance changed.
+pub async fn process_transfer(
+    transaction_repo: &impl TTransactionRepository,
+    account_repo: &impl TAccountRepository,
+    tx: QueueTransaction,
+) -> Result<Option<Uuid>> {
+    let maybe_tx = transaction_repo.find_by_id(tx.id).await?;
+    if maybe_tx.is_none() {
+        return Err(anyhow!("Transaction {} not found in DB.", tx.id));
+    }
+
+    let existing_tx = maybe_tx.unwrap();
+    if existing_tx.status != TransactionStatus::PENDING {
+        return Ok(None);
+    }
+
+    let credit_tx_id = match existing_tx.counterpart_transaction_id {
+        Some(id) => id,
+        None => {
+            transaction_repo
+                .update_status(tx.id, TransactionStatus::REJECTED)
+                .await?;
+            return Err(anyhow!(
+                "Transfer {} has no counterpart transaction.",
+                tx.id
+            ));
+        }
+    };
+
+    let maybe_credit_tx = transaction_repo.find_by_id(credit_tx_id).await?;
+    if maybe_credit_tx.is_none() {
+        transaction_repo
+            .update_status(tx.id, TransactionStatus::REJECTED)
+            .await?;
+        return Err(anyhow!(
+            "Counterpart transaction {} not found in DB.",
+            credit_tx_id
+        ));
+    }
+    let credit_tx = maybe_credit_tx.unwrap();
+
+    let source_account = account_repo.find_by_id(tx.account_id).await?;
+    let destination_account = account_repo.find_by_id(credit_tx.account_id).await?;
+    if source_account.is_none() || destination_account.is_none() {
+        transaction_repo
+            .update_transfer_status(tx.id, credit_tx_id, TransactionStatus::REJECTED)
+            .await?;
+        return Ok(None);
+    }
+
+    let account_balance = transaction_repo.get_balance(tx.account_id).await?;
+    if tx.amount_cents > account_balance {
+        transaction_repo
+            .update_transfer_status(tx.id, credit_tx_id, TransactionStatus::REJECTED)
+            .await?;
+        return Ok(None);
+    }
+
+    transaction_repo
+        .update_transfer_status(tx.id, credit_tx_id, TransactionStatus::APPROVED)
+        .await?;
+    Ok(Some(credit_tx.account_id))
+}